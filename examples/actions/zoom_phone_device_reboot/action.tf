@@ -0,0 +1,22 @@
+resource "zoom_phone_user" "example" {
+  user_id          = "AAggbuS-Q6aXXcsv2wnug"
+  extension_number = 101
+  template_id      = "3BC-FcS7SYGDYKK0AnsQmA"
+
+  lifecycle {
+    # Reboot the desk phone of the user when its provision template is changed.
+    action_trigger {
+      events  = [after_update]
+      actions = [action.zoom_phone_device_reboot.example]
+    }
+  }
+}
+
+action "zoom_phone_device_reboot" "example" {
+  config {
+    device_ids = ["qVtxSVnsRbGJ5WGPlsyCIg"]
+  }
+}
+
+# The action can also be invoked on demand with:
+#   terraform apply -invoke=action.zoom_phone_device_reboot.example
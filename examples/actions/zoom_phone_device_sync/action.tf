@@ -0,0 +1,23 @@
+resource "zoom_phone_site" "example" {
+  name = "example-site"
+
+  main_auto_receptionist = {
+    name = "example-auto-receptionist"
+  }
+
+  lifecycle {
+    action_trigger {
+      events  = [after_update]
+      actions = [action.zoom_phone_device_sync.example]
+    }
+  }
+}
+
+action "zoom_phone_device_sync" "example" {
+  config {
+    site_id = zoom_phone_site.example.id
+  }
+}
+
+# Omit site_id to resync the desk phones of the whole account, e.g. on demand with:
+#   terraform apply -invoke=action.zoom_phone_device_sync.example
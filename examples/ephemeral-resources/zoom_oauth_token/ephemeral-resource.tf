@@ -0,0 +1,10 @@
+ephemeral "zoom_oauth_token" "example" {}
+
+# Call an endpoint the provider does not cover. An ephemeral value can only be passed to ephemeral contexts,
+# such as another ephemeral resource or a provider block, so that it is never written to the state.
+ephemeral "http" "call_history" {
+  url = "https://api.zoom.us/v2/phone/call_history"
+  request_headers = {
+    Authorization = "Bearer ${ephemeral.zoom_oauth_token.example.access_token}"
+  }
+}
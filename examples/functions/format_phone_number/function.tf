@@ -0,0 +1,3 @@
+output "main_number" {
+  value = provider::zoom::format_phone_number("+12015550123", "national") # (201) 555-0123
+}
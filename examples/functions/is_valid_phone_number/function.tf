@@ -0,0 +1,8 @@
+variable "external_number" {
+  type = string
+
+  validation {
+    condition     = provider::zoom::is_valid_phone_number(var.external_number, "US")
+    error_message = "The external number must be a valid phone number."
+  }
+}
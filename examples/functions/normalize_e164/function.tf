@@ -0,0 +1,6 @@
+resource "zoom_phone_blocked_list" "example" {
+  block_type   = "inbound"
+  match_type   = "phoneNumber"
+  phone_number = provider::zoom::normalize_e164("(201) 555-0123", "US") # +12015550123
+  status       = "active"
+}
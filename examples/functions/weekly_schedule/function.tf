@@ -0,0 +1,12 @@
+resource "zoom_phone_call_handling_business_hours" "example" {
+  extension_id = "wGJDBcnJQC6tV86BbtlXXX"
+
+  custom_hours = {
+    type     = 2 # Custom hours
+    settings = provider::zoom::weekly_schedule("Mon-Fri 09:00-12:00,13:00-18:00; Sat 10:00-14:00")
+  }
+
+  call_handling = {
+    call_not_answer_action = 1 # Forward to a voicemail
+  }
+}
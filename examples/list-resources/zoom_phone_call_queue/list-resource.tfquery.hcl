@@ -0,0 +1,9 @@
+# Run `terraform query -generate-config-out=generated.tf` to generate the import blocks and the configuration.
+list "zoom_phone_call_queue" "example" {
+  provider         = zoom
+  include_resource = true
+
+  config {
+    site_id = "CAUYYsOXRH-xp4hd3cd5A"
+  }
+}
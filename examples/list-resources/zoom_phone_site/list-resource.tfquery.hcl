@@ -0,0 +1,5 @@
+# Run `terraform query -generate-config-out=generated.tf` to generate the import blocks and the configuration.
+list "zoom_phone_site" "example" {
+  provider         = zoom
+  include_resource = true
+}
@@ -0,0 +1,6 @@
+import {
+  to = zoom_phone_auto_receptionist.example
+  identity = {
+    id = "t6wyhAZRQXXX_Rv3jj3XXX"
+  }
+}
@@ -1,2 +1,3 @@
-# ${auto_reception_id}
+# ${auto_receptionist_id} or ${site_name}/${name} or ${name} or ${extension_number}
 terraform import zoom_phone_auto_receptionist_ivr.example t6wyhAZRQXXX_Rv3jj3XXX
+terraform import zoom_phone_auto_receptionist_ivr.example "Main Site/Main Auto Receptionist"
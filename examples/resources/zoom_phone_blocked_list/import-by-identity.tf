@@ -0,0 +1,6 @@
+import {
+  to = zoom_phone_blocked_list.example
+  identity = {
+    id = "lSq8jyDORe6tmbaUkOVhXx"
+  }
+}
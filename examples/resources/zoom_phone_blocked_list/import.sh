@@ -1,2 +1,3 @@
-# ${blocked_list_id}
+# ${blocked_list_id} or ${phone_number}
 terraform import zoom_phone_blocked_list.example lSq8jyDORe6tmbaUkOVhXx
+terraform import zoom_phone_blocked_list.example "+12058945752"
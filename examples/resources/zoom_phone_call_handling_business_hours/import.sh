@@ -1,2 +1,3 @@
-# ${extension_id}
+# ${extension_id} or ${extension_number} or ${email} of a phone user
 terraform import zoom_phone_call_handling_business_hours.example t6wyhAZRQXXX_Rv3jj3XXX
+terraform import zoom_phone_call_handling_business_hours.example 101
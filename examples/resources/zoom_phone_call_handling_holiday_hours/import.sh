@@ -1,2 +1,3 @@
-# ${extension_id/holiday_id}
+# ${extension_id/holiday_id}, where the extension can also be its ${extension_number} or the ${email} of a phone user
 terraform import zoom_phone_call_handling_holiday_hours.example t6wyhAZRQXXX_Rv3jj3XXX/gkqphABALXXX_Al0g13XXX
+terraform import zoom_phone_call_handling_holiday_hours.example 101/gkqphABALXXX_Al0g13XXX
@@ -0,0 +1,6 @@
+import {
+  to = zoom_phone_call_queue.example
+  identity = {
+    id = "wGJDBcnJQC6tV86BbtlXXX"
+  }
+}
@@ -1,2 +1,3 @@
-# ${call_queue_id}
+# ${call_queue_id} or ${site_name}/${name} or ${name} or ${extension_number}
 terraform import zoom_phone_call_queue.example wGJDBcnJQC6tV86BbtlXXX
+terraform import zoom_phone_call_queue.example "Main Site/Support"
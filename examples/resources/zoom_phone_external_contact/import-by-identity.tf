@@ -0,0 +1,6 @@
+import {
+  to = zoom_phone_external_contact.example
+  identity = {
+    external_contact_id = "lSq8jyDORe6tmbaUkOVhXx"
+  }
+}
@@ -1,2 +1,3 @@
-# ${external_contact_id}
+# ${external_contact_id} or ${email} or ${extension_number} or ${name}
 terraform import zoom_phone_external_contact.example lSq8jyDORe6tmbaUkOVhXx
+terraform import zoom_phone_external_contact.example partner@example.com
@@ -0,0 +1,6 @@
+import {
+  to = zoom_phone_shared_line_group.example
+  identity = {
+    id = "wGJDBcnJQC6tV86BbtlXXX"
+  }
+}
@@ -1,2 +1,3 @@
-# ${shared_line_group_id}
+# ${shared_line_group_id} or ${site_name}/${display_name} or ${display_name} or ${extension_number}
 terraform import zoom_phone_shared_line_group.example wGJDBcnJQC6tV86BbtlXXX
+terraform import zoom_phone_shared_line_group.example "Main Site/Reception"
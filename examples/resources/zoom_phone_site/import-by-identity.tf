@@ -0,0 +1,6 @@
+import {
+  to = zoom_phone_site.example
+  identity = {
+    id = "CAUYYsOXRH-xp4hd3cd5A"
+  }
+}
@@ -1,2 +1,3 @@
-# ${site_id}
+# ${site_id} or ${name}
 terraform import zoom_phone_site.example CAUYYsOXRH-xp4hd3cd5A
+terraform import zoom_phone_site.example "Main Site"
@@ -0,0 +1,6 @@
+import {
+  to = zoom_phone_user.example
+  identity = {
+    user_id = "AAggbuS-Q6aXXcsv2wnug"
+  }
+}
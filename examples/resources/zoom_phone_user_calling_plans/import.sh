@@ -1,2 +1,3 @@
-# ${user_id}
+# ${user_id} or ${email} or ${extension_number}
 terraform import zoom_phone_user_calling_plans.example AAggbuS-Q6aXXcsv2wnug
+terraform import zoom_phone_user_calling_plans.example user@example.com
@@ -1,2 +1,3 @@
-# ${user_id}
+# ${user_id} or ${email} or ${extension_number}
 terraform import zoom_phone_user_phone_numbers.example LLgNJuS-Q6aYBXXX2wJnug
+terraform import zoom_phone_user_phone_numbers.example user@example.com
@@ -1,7 +1,17 @@
 package acceptance
 
 import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance/cassette"
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance/fakezoom"
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
@@ -12,7 +22,164 @@ provider "zoom" {
 }`
 )
 
-var Provider = provider.New("test")().(*provider.ZoomProvider)
-var TestAccProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"zoom": providerserver.NewProtocol6WithError(Provider),
+var (
+	fakeZoomOnce sync.Once
+	fakeZoom     *fakezoom.Server
+)
+
+// FakeZoom starts the in-memory fake Zoom API once and points the provider at it through the environment variables.
+// The provider factories call it when TF_ACC_FAKE is set, so acceptance tests run without a Zoom account or a network.
+func FakeZoom() *fakezoom.Server {
+	fakeZoomOnce.Do(func() {
+		fakeZoom = fakezoom.NewServer()
+
+		// Users and common areas cannot be created by the provider, so seed the ones the acceptance tests need.
+		fakeZoom.AddUser(fakezoom.User{Email: "acctest-user1@example.com", FirstName: "Acctest", LastName: "User1", Phone: true})
+		fakeZoom.AddUser(fakezoom.User{Email: "acctest-user2@example.com", FirstName: "Acctest", LastName: "User2", Phone: true})
+		userID := fakeZoom.AddUser(fakezoom.User{Email: "acctest-user3@example.com", FirstName: "Acctest", LastName: "User3"})
+		commonAreaID := fakeZoom.AddCommonArea(fakezoom.CommonArea{Name: "Acctest Common Area"})
+
+		fakeFixtures = map[string]string{
+			EnvSiteID:          fakeZoom.MainSiteID(),
+			EnvPhoneUserEmails: "acctest-user1@example.com,acctest-user2@example.com",
+			EnvUserID:          userID,
+			EnvCommonAreaID:    commonAreaID,
+		}
+
+		for key, value := range map[string]string{
+			"ZOOM_ACCOUNT_ID":    "fake-account",
+			"ZOOM_CLIENT_ID":     "fake-client",
+			"ZOOM_CLIENT_SECRET": "fake-secret",
+			"ZOOM_API_URL":       fakeZoom.APIURL(),
+			"ZOOM_OAUTH_URL":     fakeZoom.TokenURL(),
+		} {
+			_ = os.Setenv(key, value)
+		}
+	})
+	return fakeZoom
+}
+
+// ProviderFactories returns the provider factories for the test t. Unless TF_ACC_FAKE is set, the interactions with Zoom
+// are recorded to testdata/cassettes/<test name>.json when TF_ACC_RECORD=1, and replayed from it when TF_ACC_REPLAY=1,
+// so that the test runs offline. Otherwise the test runs against Zoom.
+// Each test gets its own provider, so that tests running in parallel do not share the transport of a cassette.
+func ProviderFactories(t *testing.T) map[string]func() (tfprotov6.ProviderServer, error) {
+	t.Helper()
+	p := provider.New("test")().(*provider.ZoomProvider)
+	if recorder, mode, ok := testRecorder(t); ok {
+		if mode == cassette.ModeReplay {
+			replayCredentialsOnce.Do(func() {
+				// The credentials are not used by the replay, but the provider requires them.
+				for key, value := range map[string]string{
+					"ZOOM_ACCOUNT_ID":    "cassette-account",
+					"ZOOM_CLIENT_ID":     "cassette-client",
+					"ZOOM_CLIENT_SECRET": "cassette-secret",
+				} {
+					if os.Getenv(key) == "" {
+						_ = os.Setenv(key, value)
+					}
+				}
+			})
+		}
+		p.Transport = recorder
+	}
+
+	providersMu.Lock()
+	providers[t] = p
+	providersMu.Unlock()
+	t.Cleanup(func() {
+		providersMu.Lock()
+		delete(providers, t)
+		providersMu.Unlock()
+	})
+
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"zoom": func() (tfprotov6.ProviderServer, error) {
+			if os.Getenv("TF_ACC_FAKE") != "" {
+				FakeZoom()
+			}
+			return providerserver.NewProtocol6WithError(p)()
+		},
+	}
+}
+
+var (
+	replayCredentialsOnce sync.Once
+
+	providersMu sync.Mutex
+	providers   = map[*testing.T]*provider.ZoomProvider{}
+)
+
+// ProviderData returns the data of the provider of the test t, e.g. to look objects up in CheckDestroy.
+// It is set once Terraform has configured the provider.
+func ProviderData(t *testing.T) *shared.ProviderData {
+	t.Helper()
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	p, ok := providers[t]
+	if !ok {
+		t.Fatal("ProviderData is called before ProviderFactories")
+	}
+	return p.ProviderData
+}
+
+var (
+	recordersMu sync.Mutex
+	recorders   = map[*testing.T]*cassette.Recorder{}
+)
+
+// testRecorder returns the recorder of the cassette of the test t, which is shared by its provider and its fixtures.
+func testRecorder(t *testing.T) (*cassette.Recorder, cassette.Mode, bool) {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		return nil, 0, false
+	}
+	mode, ok := cassetteMode(t)
+	if !ok {
+		return nil, 0, false
+	}
+	if mode == cassette.ModeReplay && !cassette.Exists(cassettePath(t)) {
+		t.Skipf("%s has not been recorded against a Zoom account yet, record it with TF_ACC_RECORD=1", cassettePath(t))
+	}
+
+	recordersMu.Lock()
+	defer recordersMu.Unlock()
+	if recorder, ok := recorders[t]; ok {
+		return recorder, mode, true
+	}
+	recorder, err := cassette.New(cassettePath(t), mode, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorders[t] = recorder
+	t.Cleanup(func() {
+		recordersMu.Lock()
+		delete(recorders, t)
+		recordersMu.Unlock()
+		if t.Skipped() {
+			return // an empty cassette would fail the replays
+		}
+		if err := recorder.Save(); err != nil {
+			t.Errorf("unable to save cassette: %v", err)
+		}
+	})
+	return recorder, mode, true
+}
+
+// cassetteMode tells whether the test t records or replays a cassette.
+func cassetteMode(t *testing.T) (cassette.Mode, bool) {
+	if os.Getenv("TF_ACC_FAKE") != "" {
+		return 0, false
+	}
+	if os.Getenv("TF_ACC_RECORD") == "1" {
+		return cassette.ModeRecord, true
+	}
+	if os.Getenv("TF_ACC_REPLAY") == "1" {
+		return cassette.ModeReplay, true
+	}
+	return 0, false
+}
+
+func cassettePath(t *testing.T) string {
+	return filepath.Join("testdata", "cassettes", strings.ReplaceAll(t.Name(), "/", "_")+".json")
 }
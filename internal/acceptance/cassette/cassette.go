@@ -0,0 +1,294 @@
+// Package cassette records the HTTP interactions of an acceptance test to a file and replays them later,
+// so that tests which mutate a Zoom account can be rerun offline.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type Mode int
+
+const (
+	// ModeRecord sends requests to Zoom and records the sanitized interactions.
+	ModeRecord Mode = iota
+	// ModeReplay answers requests from the recorded interactions without any network access.
+	ModeReplay
+)
+
+// Cassette is the file format of recorded interactions.
+type Cassette struct {
+	// Fixtures are the sanitized values of the objects the test was recorded with but the provider cannot create,
+	// e.g. the site of a call queue, so that the replay configures the same objects.
+	Fixtures     map[string]string `json:"fixtures,omitempty"`
+	Interactions []*Interaction    `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// recordedHeaders are the response headers the provider depends on. Others, such as cookies, are not recorded.
+var recordedHeaders = []string{
+	"Content-Type",
+	"Content-Length",
+	"Retry-After",
+	"X-RateLimit-Category",
+	"X-RateLimit-Type",
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions. It is safe for concurrent use.
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New creates a recorder for the cassette at path. In ModeReplay the cassette must exist,
+// in ModeRecord transport sends the requests and the cassette is written by Save.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: transport,
+	}
+	if mode == ModeReplay {
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = *c
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Load reads the cassette at path.
+func Load(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cassette: %w", err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("unable to parse cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// SetFixture records the value of a fixture, sanitized like the interactions, so that it matches them when replayed.
+func (r *Recorder) SetFixture(key, value string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cassette.Fixtures == nil {
+		r.cassette.Fixtures = map[string]string{}
+	}
+	r.cassette.Fixtures[key] = Sanitize(value)
+}
+
+// Fixture returns the recorded value of a fixture.
+func (r *Recorder) Fixture(key string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.cassette.Fixtures[key]
+	return value, ok
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+// replay answers with the first unused interaction of the same method and URL, so that repeated requests,
+// e.g. polling until an object is ready, get their responses in the recorded order.
+// Request bodies are not compared, as they carry the values the responses were sanitized from.
+//
+// Terraform versions differ in how often they refresh and configure the provider, so reads and token requests
+// may outnumber the recorded ones. Such a request gets the last response recorded before the next change,
+// which is what Zoom would answer as nothing has changed since.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	url := Sanitize(req.URL.String())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i, ok := r.find(req.Method, url)
+	if !ok {
+		return nil, fmt.Errorf("cassette %s has no more recorded interactions for %s %s, record it again with TF_ACC_RECORD=1", r.path, req.Method, url)
+	}
+	r.used[i] = true
+	interaction := r.cassette.Interactions[i]
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        interaction.Response.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// find returns the index of the interaction that answers the request.
+func (r *Recorder) find(method, url string) (int, bool) {
+	matches := func(i int) bool {
+		request := r.cassette.Interactions[i].Request
+		return request.Method == method && request.URL == url
+	}
+	if !repeatable(method, url) {
+		for i := range r.cassette.Interactions {
+			if !r.used[i] && matches(i) {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+
+	// The last change replayed so far, and the next one to be replayed after it.
+	last := -1
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] && !repeatable(interaction.Request.Method, interaction.Request.URL) {
+			last = i
+		}
+	}
+	next := len(r.cassette.Interactions)
+	for i := last + 1; i < len(r.cassette.Interactions); i++ {
+		interaction := r.cassette.Interactions[i]
+		if !r.used[i] && !repeatable(interaction.Request.Method, interaction.Request.URL) {
+			next = i
+			break
+		}
+	}
+
+	for i := last + 1; i < next; i++ {
+		if !r.used[i] && matches(i) {
+			return i, true
+		}
+	}
+	for i := next - 1; i >= 0; i-- {
+		if matches(i) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// repeatable reports whether the request changes nothing in Zoom, so that it can be answered more than once.
+func repeatable(method, url string) bool {
+	if method == http.MethodGet {
+		return true
+	}
+	path, _, _ := strings.Cut(url, "?")
+	return method == http.MethodPost && strings.HasSuffix(path, "/oauth/token")
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err // network errors are not recorded, the retry layer deals with them
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	header := http.Header{}
+	for _, key := range recordedHeaders {
+		if value := resp.Header.Get(key); value != "" {
+			header.Set(key, value)
+		}
+	}
+
+	body := Sanitize(string(respBody))
+	if header.Get("Content-Length") != "" {
+		// Sanitizing changes the length of the body.
+		header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    Sanitize(req.URL.String()),
+			Body:   Sanitize(string(reqBody)),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       body,
+		},
+	})
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette. It does nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(b, '\n'), 0o644)
+}
+
+// Exists reports whether a cassette has been recorded at path.
+func Exists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, os.ErrNotExist)
+}
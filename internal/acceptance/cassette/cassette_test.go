@@ -0,0 +1,169 @@
+package cassette_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance/cassette"
+)
+
+func TestSanitize(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{`{"access_token":"eyJhbGciOi","token_type":"bearer"}`, `{"access_token":"REDACTED","token_type":"bearer"}`},
+		{`grant_type=account_credentials&account_id=abcDEF123`, `grant_type=account_credentials&account_id=REDACTED`},
+		{`{"email":"acctest-user1@example.com"}`, `{"email":"acctest-user1@example.com"}`},
+		{`{"number":"+12025550101"}`, `{"number":"+12025550101"}`},
+	} {
+		if got := cassette.Sanitize(tc.in); got != tc.want {
+			t.Errorf("Sanitize(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+
+	body := `{"email":"Jane.Doe@corp.co.jp","phone_numbers":[{"number":"+81312345678"}]}`
+	got := cassette.Sanitize(body)
+	if strings.Contains(got, "corp.co.jp") || strings.Contains(got, "+81312345678") {
+		t.Fatalf("expected the email and the phone number to be scrubbed, got %s", got)
+	}
+	if got != cassette.Sanitize(body) || cassette.Sanitize(got) != got {
+		t.Fatalf("expected sanitizing to be stable, got %s", got)
+	}
+	url := cassette.Sanitize("https://api.zoom.us/v2/users/Jane.Doe%40corp.co.jp")
+	if !strings.Contains(got, strings.TrimPrefix(strings.ReplaceAll(url, "%40", "@"), "https://api.zoom.us/v2/users/")) {
+		t.Fatalf("expected an email in a URL to get the same placeholder as in a body, got %s and %s", url, got)
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		_, _ = fmt.Fprintf(w, `{"status":"pending %d","owner":"owner@corp.example.org","access_token":"secret"}`, calls)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "testdata", "cassettes", "TestRecordAndReplay.json")
+	recorder, err := cassette.New(path, cassette.ModeRecord, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: recorder}
+	for i := range 2 {
+		res, err := client.Post(server.URL+"/v2/phone/sites", "application/json", strings.NewReader(`{"name":"acctest"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		if !strings.Contains(string(body), fmt.Sprintf("pending %d", i+1)) || !strings.Contains(string(body), `"secret"`) {
+			t.Fatalf("expected the live response while recording, got %s", body)
+		}
+	}
+	recorder.SetFixture("ZOOM_ACCTEST_SITE_ID", "site-id")
+	recorder.SetFixture("ZOOM_ACCTEST_PHONE_USER_EMAILS", "owner@corp.example.org,acctest-user1@example.com")
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"secret", "owner@corp.example.org"} {
+		if strings.Contains(string(saved), secret) {
+			t.Fatalf("expected %q not to be recorded:\n%s", secret, saved)
+		}
+	}
+
+	server.Close()
+	replayer, err := cassette.New(path, cassette.ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if siteID, ok := replayer.Fixture("ZOOM_ACCTEST_SITE_ID"); !ok || siteID != "site-id" {
+		t.Fatalf("unexpected site ID fixture: %q", siteID)
+	}
+	if emails, _ := replayer.Fixture("ZOOM_ACCTEST_PHONE_USER_EMAILS"); emails != cassette.Sanitize("owner@corp.example.org,acctest-user1@example.com") {
+		t.Fatalf("expected the emails fixture to be sanitized like the interactions, got %q", emails)
+	}
+	client = &http.Client{Transport: replayer}
+	for i := range 2 {
+		res, err := client.Post(server.URL+"/v2/phone/sites", "application/json", strings.NewReader(`{"name":"other"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		if !strings.Contains(string(body), fmt.Sprintf("pending %d", i+1)) {
+			t.Fatalf("expected the recorded responses in order, got %s", body)
+		}
+		if res.Header.Get("Content-Type") != "application/json" || res.Header.Get("Content-Length") != fmt.Sprint(len(body)) || res.Header.Get("Set-Cookie") != "" {
+			t.Fatalf("unexpected replayed headers: %v", res.Header)
+		}
+	}
+	if _, err := client.Post(server.URL+"/v2/phone/sites", "application/json", nil); err == nil {
+		t.Fatal("expected an error once the recorded interactions are used up")
+	}
+	if _, err := client.Get(server.URL + "/v2/phone/sites"); err == nil {
+		t.Fatal("expected an error for a request that was not recorded")
+	}
+}
+
+func TestReplayRepeatedReads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TestReplayRepeatedReads.json")
+	err := os.WriteFile(path, []byte(`{"interactions": [
+  {"request": {"method": "POST", "url": "https://zoom.us/oauth/token?grant_type=account_credentials"}, "response": {"status_code": 200, "body": "token"}},
+  {"request": {"method": "GET", "url": "https://api.zoom.us/v2/phone/sites/1"}, "response": {"status_code": 200, "body": "created"}},
+  {"request": {"method": "PATCH", "url": "https://api.zoom.us/v2/phone/sites/1"}, "response": {"status_code": 204}},
+  {"request": {"method": "GET", "url": "https://api.zoom.us/v2/phone/sites/1"}, "response": {"status_code": 200, "body": "updated"}}
+]}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayer, err := cassette.New(path, cassette.ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: replayer}
+
+	send := func(method, url string) string {
+		t.Helper()
+		req, _ := http.NewRequest(method, url, nil)
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		return string(body)
+	}
+	for _, step := range []struct {
+		method, url, want string
+	}{
+		{http.MethodPost, "https://zoom.us/oauth/token?grant_type=account_credentials", "token"},
+		{http.MethodGet, "https://api.zoom.us/v2/phone/sites/1", "created"},
+		{http.MethodPost, "https://zoom.us/oauth/token?grant_type=account_credentials", "token"},
+		{http.MethodGet, "https://api.zoom.us/v2/phone/sites/1", "created"},
+		{http.MethodPatch, "https://api.zoom.us/v2/phone/sites/1", ""},
+		{http.MethodGet, "https://api.zoom.us/v2/phone/sites/1", "updated"},
+		{http.MethodGet, "https://api.zoom.us/v2/phone/sites/1", "updated"},
+	} {
+		if body := send(step.method, step.url); body != step.want {
+			t.Fatalf("%s %s: expected %q, got %q", step.method, step.url, step.want, body)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodPatch, "https://api.zoom.us/v2/phone/sites/1", nil)
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected an error for a change that was replayed already")
+	}
+}
@@ -0,0 +1,50 @@
+package cassette
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	tokenPattern     = regexp.MustCompile(`"(access_token|refresh_token|id_token)"\s*:\s*"[^"]*"`)
+	accountIDPattern = regexp.MustCompile(`("account_id"\s*:\s*"|account_id=)[^"&\s]*`)
+	emailPattern     = regexp.MustCompile(`[A-Za-z0-9._+\-]+(@|%40)([A-Za-z0-9\-]+\.)+[A-Za-z]{2,}`)
+	phonePattern     = regexp.MustCompile(`\+[1-9][0-9]{7,14}\b`)
+	// fictionalPhonePattern matches the North American numbers reserved for fictional use, 555-0100 to 555-0199.
+	fictionalPhonePattern = regexp.MustCompile(`^\+1[2-9][0-9]{2}55501[0-9]{2}$`)
+)
+
+// Sanitize scrubs credentials and personal data from a recorded URL or body.
+// Tokens and account IDs are redacted. Emails and E.164 phone numbers are replaced with stable placeholders,
+// so that the same value is replaced the same way across requests and responses, and lookups still match.
+// Values that are placeholders already, i.e. example.com emails and fictional phone numbers, are kept.
+func Sanitize(s string) string {
+	s = tokenPattern.ReplaceAllString(s, `"$1":"REDACTED"`)
+	s = accountIDPattern.ReplaceAllString(s, "${1}REDACTED")
+	s = emailPattern.ReplaceAllStringFunc(s, func(email string) string {
+		at := emailPattern.FindStringSubmatch(email)[1]
+		local, domain, _ := strings.Cut(strings.ToLower(email), strings.ToLower(at))
+		if domain == "example.com" {
+			return email
+		}
+		return fmt.Sprintf("user-%s%sexample.com", hash(local + "@" + domain)[:8], at)
+	})
+	s = phonePattern.ReplaceAllStringFunc(s, func(number string) string {
+		if fictionalPhonePattern.MatchString(number) {
+			return number
+		}
+		sum := sha256.Sum256([]byte(number))
+		n := binary.BigEndian.Uint32(sum[:4])
+		return fmt.Sprintf("+1%03d55501%02d", 200+n%800, n/800%100)
+	})
+	return s
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,42 @@
+package acceptance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// CheckDestroy returns a CheckDestroy function that fails unless read answers not found for every resource of
+// resourceType in the state. read looks the object of the resource up with the client of Provider.
+func CheckDestroy(resourceType string, read func(ctx context.Context, rs *terraform.ResourceState) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for name, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			err := read(context.Background(), rs)
+			if err == nil {
+				return fmt.Errorf("%q still exists", name)
+			}
+			if !util.IsNotFound(err) {
+				return fmt.Errorf("unable to check if %q has been destroyed: %w", name, err)
+			}
+		}
+		return nil
+	}
+}
+
+// ImportStateIDFromAttribute returns the attribute of the resource as the import ID,
+// for the resources whose ID is not in the id attribute.
+func ImportStateIDFromAttribute(resourceName, attribute string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("%q not found in the state", resourceName)
+		}
+		return rs.Primary.Attributes[attribute], nil
+	}
+}
@@ -2,6 +2,8 @@ package acceptance
 
 import (
 	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
@@ -16,16 +18,32 @@ type TestData struct {
 
 	// TerraformResourceType is the Terraform resource type, e.g. "zoom_phone_user".
 	TerraformResourceType string
+
+	// rand generates the random strings of a test with a cassette, which must be the same when recorded and replayed.
+	rand *rand.Rand
 }
 
-func NewTestData(_ *testing.T, tfResourceType, resourceLabel string) TestData {
-	return TestData{
+func NewTestData(t *testing.T, tfResourceType, resourceLabel string) TestData {
+	td := TestData{
 		ResourceLabel:         resourceLabel,
 		ResourceName:          fmt.Sprintf("%[1]s.%[2]s", tfResourceType, resourceLabel),
 		TerraformResourceType: tfResourceType,
 	}
+	if _, ok := cassetteMode(t); ok {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(t.Name() + "/" + resourceLabel))
+		td.rand = rand.New(rand.NewPCG(h.Sum64(), 0))
+	}
+	return td
 }
 
-func (td TestData) RandomStringOfLength(len int) string {
-	return acctest.RandString(len)
+func (td TestData) RandomStringOfLength(n int) string {
+	if td.rand == nil {
+		return acctest.RandString(n)
+	}
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = acctest.CharSetAlphaNum[td.rand.IntN(len(acctest.CharSetAlphaNum))]
+	}
+	return string(b)
 }
@@ -0,0 +1,112 @@
+package fakezoom
+
+import (
+	"net/http"
+)
+
+func (s *Server) createAutoReceptionist(req object) string {
+	id := s.newID("ar")
+	extensionID, extensionNumber := s.newExtension()
+	s.autoReceptionists[id] = object{
+		"id":                    id,
+		"name":                  req["name"],
+		"extension_id":          extensionID,
+		"extension_number":      extensionNumber,
+		"site":                  s.siteRef(str(req, "site_id")),
+		"timezone":              "America/Los_Angeles",
+		"audio_prompt_language": "en-US",
+		"holiday_hours":         []any{},
+	}
+	return id
+}
+
+func (s *Server) listAutoReceptionists(w http.ResponseWriter, r *http.Request) {
+	var autoReceptionists []object
+	for _, autoReceptionist := range sorted(s.autoReceptionists) {
+		item := pick(autoReceptionist, "id", "name", "extension_id", "extension_number", "site", "timezone", "audio_prompt_language", "holiday_hours")
+		item["phone_numbers"] = []any{}
+		autoReceptionists = append(autoReceptionists, item)
+	}
+	page, next, size := paginate(r, autoReceptionists, 30)
+	writeJSON(w, http.StatusOK, object{
+		"auto_receptionists": page,
+		"next_page_token":    next,
+		"page_size":          size,
+		"total_records":      len(autoReceptionists),
+	})
+}
+
+func (s *Server) postAutoReceptionist(w http.ResponseWriter, r *http.Request) {
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	if str(req, "name") == "" {
+		writeError(w, http.StatusBadRequest, 300, "name is required.")
+		return
+	}
+	if siteID := str(req, "site_id"); siteID != "" {
+		if _, ok := s.sites[siteID]; !ok {
+			writeNotExist(w, "Site")
+			return
+		}
+	}
+	id := s.createAutoReceptionist(req)
+	writeJSON(w, http.StatusCreated, pick(s.autoReceptionists[id], "id", "name", "extension_number"))
+}
+
+func (s *Server) getAutoReceptionist(w http.ResponseWriter, r *http.Request) {
+	autoReceptionist, ok := s.autoReceptionists[r.PathValue("autoReceptionistId")]
+	if !ok {
+		writeNotExist(w, "Auto Receptionist")
+		return
+	}
+	ret := object{}
+	merge(ret, autoReceptionist)
+	ret["phone_numbers"] = []any{}
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (s *Server) patchAutoReceptionist(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("autoReceptionistId")
+	autoReceptionist, ok := s.autoReceptionists[id]
+	if !ok {
+		writeNotExist(w, "Auto Receptionist")
+		return
+	}
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	merge(autoReceptionist, pick(req, "cost_center", "department", "extension_number", "name", "audio_prompt_language", "timezone", "recording_storage_location"))
+	s.syncMainAutoReceptionist(id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// syncMainAutoReceptionist reflects the auto receptionist on the site when it is the main auto receptionist.
+func (s *Server) syncMainAutoReceptionist(id string) {
+	autoReceptionist := s.autoReceptionists[id]
+	for _, site := range s.sites {
+		if main := child(site, "main_auto_receptionist"); str(main, "id") == id {
+			merge(main, pick(autoReceptionist, "name", "extension_number"))
+		}
+	}
+}
+
+func (s *Server) deleteAutoReceptionist(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("autoReceptionistId")
+	autoReceptionist, ok := s.autoReceptionists[id]
+	if !ok {
+		writeError(w, http.StatusBadRequest, 404, "Auto Receptionist does not exist.")
+		return
+	}
+	for _, site := range s.sites {
+		if str(child(site, "main_auto_receptionist"), "id") == id {
+			writeError(w, http.StatusBadRequest, 300, "The main auto receptionist of a site cannot be deleted.")
+			return
+		}
+	}
+	s.deleteExtension(str(autoReceptionist, "extension_id"))
+	delete(s.autoReceptionists, id)
+	w.WriteHeader(http.StatusNoContent)
+}
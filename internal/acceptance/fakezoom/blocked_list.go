@@ -0,0 +1,54 @@
+package fakezoom
+
+import "net/http"
+
+func (s *Server) listBlockedList(w http.ResponseWriter, r *http.Request) {
+	page, next, size := paginate(r, sorted(s.blockedList), 30)
+	writeJSON(w, http.StatusOK, object{
+		"blocked_list":    page,
+		"next_page_token": next,
+		"page_size":       size,
+		"total_records":   len(s.blockedList),
+	})
+}
+
+func (s *Server) postBlockedList(w http.ResponseWriter, r *http.Request) {
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	if str(req, "phone_number") == "" {
+		writeError(w, http.StatusBadRequest, 300, "phone_number is required.")
+		return
+	}
+
+	id := s.newID("bl")
+	blockedList := object{
+		"id":         id,
+		"block_type": "inbound",
+		"match_type": "phoneNumber",
+		"status":     "active",
+	}
+	merge(blockedList, pick(req, "block_type", "comment", "match_type", "phone_number", "status"))
+	s.blockedList[id] = blockedList
+	writeJSON(w, http.StatusCreated, object{"id": id})
+}
+
+func (s *Server) getBlockedList(w http.ResponseWriter, r *http.Request) {
+	blockedList, ok := s.blockedList[r.PathValue("blockedListId")]
+	if !ok {
+		writeNotExist(w, "Blocked list")
+		return
+	}
+	writeJSON(w, http.StatusOK, blockedList)
+}
+
+func (s *Server) deleteBlockedList(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("blockedListId")
+	if _, ok := s.blockedList[id]; !ok {
+		writeError(w, http.StatusBadRequest, 404, "Blocked list does not exist.")
+		return
+	}
+	delete(s.blockedList, id)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,345 @@
+package fakezoom
+
+import (
+	"net/http"
+	"slices"
+)
+
+const (
+	settingTypeBusinessHours = "business_hours"
+	settingTypeClosedHours   = "closed_hours"
+	settingTypeHolidayHours  = "holiday_hours"
+)
+
+// defaultCallHandling returns the call handling settings of a new extension,
+// including the call forwarding settings Zoom provides for its own apps.
+func defaultCallHandling(s *Server) object {
+	return object{
+		settingTypeBusinessHours: []any{
+			object{
+				"sub_setting_type": "custom_hours",
+				"settings": object{
+					"type":                   1,
+					"allow_members_to_reset": false,
+					"custom_hours_settings":  []any{},
+				},
+			},
+			object{
+				"sub_setting_type": "call_handling",
+				"settings":         defaultCallHandlingSettings(),
+			},
+			object{
+				"sub_setting_type": "call_forwarding",
+				"settings":         defaultCallForwardingSettings(s),
+			},
+		},
+		settingTypeClosedHours: []any{
+			object{
+				"sub_setting_type": "call_handling",
+				"settings":         defaultCallHandlingSettings(),
+			},
+			object{
+				"sub_setting_type": "call_forwarding",
+				"settings":         defaultCallForwardingSettings(s),
+			},
+		},
+		settingTypeHolidayHours: []any{},
+	}
+}
+
+func defaultCallHandlingSettings() object {
+	return object{
+		"allow_callers_check_voicemail": true,
+		"call_not_answer_action":        1,
+		"connect_to_operator":           false,
+		"max_wait_time":                 30,
+		"ring_mode":                     "simultaneous",
+	}
+}
+
+func defaultCallForwardingSettings(s *Server) object {
+	var settings []object
+	for _, description := range []string{"Zoom Mobile Apps", "Zoom Desktop Apps", "Zoom Phone Appliance Apps"} {
+		settings = append(settings, object{
+			"id":          s.newID("cf"),
+			"description": description,
+			"enable":      true,
+		})
+	}
+	return object{
+		"require_press_1_before_connecting": false,
+		"call_forwarding_settings":          list(settings),
+	}
+}
+
+// callHandlingSettings returns the sub settings of the setting type.
+// The sub settings of a holiday are looked up by the holiday id.
+func (s *Server) callHandlingSettings(w http.ResponseWriter, r *http.Request, holidayID string) ([]object, bool) {
+	callHandling, ok := s.callHandlings[r.PathValue("extensionId")]
+	if !ok {
+		writeNotExist(w, "Extension")
+		return nil, false
+	}
+	switch settingType := r.PathValue("settingType"); settingType {
+	case settingTypeBusinessHours, settingTypeClosedHours:
+		return items(callHandling, settingType), true
+	case settingTypeHolidayHours:
+		for _, holiday := range items(callHandling, settingTypeHolidayHours) {
+			if str(holiday, "holiday_id") == holidayID {
+				return items(holiday, "details"), true
+			}
+		}
+		writeError(w, http.StatusNotFound, 404, "Holiday does not exist.")
+		return nil, false
+	}
+	writeError(w, http.StatusBadRequest, 300, "Invalid setting type.")
+	return nil, false
+}
+
+// subSetting finds the settings of the sub setting type.
+func subSetting(subSettings []object, subSettingType string) (object, bool) {
+	for _, subSetting := range subSettings {
+		if str(subSetting, "sub_setting_type") == subSettingType {
+			return child(subSetting, "settings"), true
+		}
+	}
+	return nil, false
+}
+
+func (s *Server) getCallHandling(w http.ResponseWriter, r *http.Request) {
+	callHandling, ok := s.callHandlings[r.PathValue("extensionId")]
+	if !ok {
+		writeNotExist(w, "Extension")
+		return
+	}
+	writeJSON(w, http.StatusOK, callHandling)
+}
+
+func (s *Server) postCallHandling(w http.ResponseWriter, r *http.Request) {
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	settings := child(req, "settings")
+	switch str(req, "sub_setting_type") {
+	case "holiday":
+		callHandling, ok := s.callHandlings[r.PathValue("extensionId")]
+		if !ok {
+			writeNotExist(w, "Extension")
+			return
+		}
+		if r.PathValue("settingType") != settingTypeHolidayHours {
+			writeError(w, http.StatusBadRequest, 300, "Holidays can only be added to holiday hours.")
+			return
+		}
+		holidayID := s.newID("hol")
+		holiday := object{
+			"holiday_id": holidayID,
+			"details": []any{
+				object{
+					"sub_setting_type": "holiday",
+					"settings":         pick(settings, "name", "from", "to"),
+				},
+				object{
+					"sub_setting_type": "call_handling",
+					"settings":         defaultCallHandlingSettings(),
+				},
+				object{
+					"sub_setting_type": "call_forwarding",
+					"settings":         defaultCallForwardingSettings(s),
+				},
+			},
+		}
+		callHandling[settingTypeHolidayHours] = list(append(items(callHandling, settingTypeHolidayHours), holiday))
+		writeJSON(w, http.StatusCreated, object{"holiday_id": holidayID})
+	case "call_forwarding":
+		subSettings, ok := s.callHandlingSettings(w, r, str(settings, "holiday_id"))
+		if !ok {
+			return
+		}
+		callForwarding, ok := subSetting(subSettings, "call_forwarding")
+		if !ok {
+			writeError(w, http.StatusBadRequest, 300, "Call forwarding is not available.")
+			return
+		}
+		callForwardingID := s.newID("cf")
+		setting := pick(settings, "description", "phone_number")
+		setting["id"] = callForwardingID
+		setting["enable"] = true
+		callForwarding["call_forwarding_settings"] = list(append(items(callForwarding, "call_forwarding_settings"), setting))
+		writeJSON(w, http.StatusCreated, object{"call_forwarding_id": callForwardingID})
+	default:
+		writeError(w, http.StatusBadRequest, 300, "Invalid sub setting type.")
+	}
+}
+
+func (s *Server) patchCallHandling(w http.ResponseWriter, r *http.Request) {
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	settings := child(req, "settings")
+	subSettings, ok := s.callHandlingSettings(w, r, str(settings, "holiday_id"))
+	if !ok {
+		return
+	}
+	subSettingType := str(req, "sub_setting_type")
+	current, ok := subSetting(subSettings, subSettingType)
+	if !ok {
+		writeError(w, http.StatusBadRequest, 300, "Invalid sub setting type.")
+		return
+	}
+
+	switch subSettingType {
+	case "custom_hours":
+		merge(current, pick(settings, "type", "allow_members_to_reset", "custom_hours_settings"))
+	case "holiday":
+		merge(current, pick(settings, "name", "from", "to"))
+	case "call_forwarding":
+		merge(current, pick(settings, "require_press_1_before_connecting"))
+		callForwardingSettings := items(current, "call_forwarding_settings")
+		for _, update := range items(settings, "call_forwarding_settings") {
+			i := slices.IndexFunc(callForwardingSettings, func(setting object) bool {
+				return str(setting, "id") == str(update, "id")
+			})
+			if i < 0 {
+				setting := object{"id": s.newID("cf"), "enable": true}
+				merge(setting, pick(update, "description", "enable", "phone_number", "external_contact"))
+				callForwardingSettings = append(callForwardingSettings, setting)
+				continue
+			}
+			merge(callForwardingSettings[i], pick(update, "description", "enable", "phone_number", "external_contact"))
+		}
+		current["call_forwarding_settings"] = list(callForwardingSettings)
+	case "call_handling":
+		patchCallHandlingSettings(current, settings)
+	default:
+		writeError(w, http.StatusBadRequest, 300, "Invalid sub setting type.")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// patchCallHandlingSettings applies the flat fields of a call_handling request to the nested shape of the get API.
+func patchCallHandlingSettings(current, settings object) {
+	merge(current, pick(settings, "allow_callers_check_voicemail", "allow_members_to_reset", "call_distribution",
+		"call_not_answer_action", "connect_to_operator", "max_call_in_queue", "max_wait_time", "receive_call", "ring_mode",
+		"wrap_up_time"))
+
+	for key, target := range map[string]string{
+		"audio_while_connecting_id": "audio_while_connecting",
+		"greeting_prompt_id":        "greeting_prompt",
+		"music_on_hold_id":          "music_on_hold",
+	} {
+		if id, ok := settings[key]; ok {
+			current[target] = object{"id": id}
+		}
+	}
+
+	routing := object{}
+	forwardTo := object{}
+	for key, target := range map[string]string{
+		"un_answered_require_press_1_before_connecting": "require_press_1_before_connecting",
+		"overflow_play_callee_voicemail_greeting":       "overflow_play_callee_voicemail_greeting",
+		"play_callee_voicemail_greeting":                "play_callee_voicemail_greeting",
+	} {
+		if value, ok := settings[key]; ok {
+			routing[target] = value
+		}
+	}
+	if id, ok := settings["voicemail_greeting_id"]; ok {
+		routing["voicemail_greeting"] = object{"id": id}
+	}
+	if id, ok := settings["operator_extension_id"]; ok {
+		routing["operator"] = object{"extension_id": id}
+	}
+	merge(forwardTo, pick(settings, "phone_number", "description"))
+	if id, ok := settings["forward_to_extension_id"]; ok {
+		forwardTo["extension_id"] = id
+		current["call_forwarding_settings"] = []any{object{"id": id}}
+	}
+	if len(forwardTo) > 0 {
+		routing["forward_to"] = forwardTo
+	}
+	merge(current, object{"routing": routing})
+
+	busyRouting := object{}
+	busyForwardTo := object{}
+	for key, target := range map[string]string{
+		"busy_on_another_call_action":            "action",
+		"busy_require_press_1_before_connecting": "require_press_1_before_connecting",
+		"busy_play_callee_voicemail_greeting":    "play_callee_voicemail_greeting",
+	} {
+		if value, ok := settings[key]; ok {
+			busyRouting[target] = value
+		}
+	}
+	for key, target := range map[string]string{
+		"busy_forward_to_extension_id": "extension_id",
+		"busy_phone_number":            "phone_number",
+		"busy_description":             "description",
+	} {
+		if value, ok := settings[key]; ok {
+			busyForwardTo[target] = value
+		}
+	}
+	if len(busyForwardTo) > 0 {
+		busyRouting["forward_to"] = busyForwardTo
+	}
+	merge(current, object{"busy_routing": busyRouting})
+}
+
+func (s *Server) deleteCallHandling(w http.ResponseWriter, r *http.Request) {
+	callHandling, ok := s.callHandlings[r.PathValue("extensionId")]
+	if !ok {
+		writeNotExist(w, "Extension")
+		return
+	}
+	query := r.URL.Query()
+	settingType := r.PathValue("settingType")
+
+	if callForwardingID := query.Get("call_forwarding_id"); callForwardingID != "" {
+		var subSettings []object
+		switch settingType {
+		case settingTypeBusinessHours, settingTypeClosedHours:
+			subSettings = items(callHandling, settingType)
+		case settingTypeHolidayHours:
+			for _, holiday := range items(callHandling, settingTypeHolidayHours) {
+				subSettings = append(subSettings, items(holiday, "details")...)
+			}
+		}
+		for _, subSetting := range subSettings {
+			if str(subSetting, "sub_setting_type") != "call_forwarding" {
+				continue
+			}
+			settings := child(subSetting, "settings")
+			callForwardingSettings := items(settings, "call_forwarding_settings")
+			kept := slices.DeleteFunc(slices.Clone(callForwardingSettings), func(setting object) bool {
+				return str(setting, "id") == callForwardingID
+			})
+			if len(kept) != len(callForwardingSettings) {
+				settings["call_forwarding_settings"] = list(kept)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, 404, "Call forwarding does not exist.")
+		return
+	}
+
+	if holidayID := query.Get("holiday_id"); holidayID != "" && settingType == settingTypeHolidayHours {
+		holidays := items(callHandling, settingTypeHolidayHours)
+		kept := slices.DeleteFunc(slices.Clone(holidays), func(holiday object) bool {
+			return str(holiday, "holiday_id") == holidayID
+		})
+		if len(kept) != len(holidays) {
+			callHandling[settingTypeHolidayHours] = list(kept)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeError(w, http.StatusNotFound, 404, "Holiday does not exist.")
+		return
+	}
+
+	writeError(w, http.StatusBadRequest, 300, "call_forwarding_id or holiday_id is required.")
+}
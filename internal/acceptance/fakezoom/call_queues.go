@@ -0,0 +1,173 @@
+package fakezoom
+
+import (
+	"net/http"
+	"slices"
+)
+
+var callQueueMemberDefaults = object{
+	"receive_call": true,
+}
+
+func (s *Server) listCallQueues(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var callQueues []object
+	for _, callQueue := range sorted(s.callQueues) {
+		if siteID := query.Get("site_id"); siteID != "" && str(child(callQueue, "site"), "id") != siteID {
+			continue
+		}
+		item := pick(callQueue, "id", "name", "extension_id", "extension_number", "site", "status")
+		item["phone_numbers"] = []any{}
+		callQueues = append(callQueues, item)
+	}
+	page, next, size := paginate(r, callQueues, 30)
+	writeJSON(w, http.StatusOK, object{
+		"call_queues":     page,
+		"next_page_token": next,
+		"page_size":       size,
+		"total_records":   len(callQueues),
+	})
+}
+
+func (s *Server) postCallQueue(w http.ResponseWriter, r *http.Request) {
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	if str(req, "name") == "" {
+		writeError(w, http.StatusBadRequest, 300, "name is required.")
+		return
+	}
+	if siteID := str(req, "site_id"); siteID != "" {
+		if _, ok := s.sites[siteID]; !ok {
+			writeNotExist(w, "Site")
+			return
+		}
+	}
+
+	id := s.newID("cq")
+	extensionID, extensionNumber := s.newExtension()
+	callQueue := object{
+		"id":                    id,
+		"name":                  req["name"],
+		"extension_id":          extensionID,
+		"extension_number":      extensionNumber,
+		"site":                  s.siteRef(str(req, "site_id")),
+		"status":                "active",
+		"timezone":              "America/Los_Angeles",
+		"audio_prompt_language": "en-US",
+		"policy":                object{"voicemail_access_members": []any{}},
+	}
+	merge(callQueue, pick(req, "cost_center", "department", "extension_number"))
+	s.callQueues[id] = callQueue
+	if child(req, "members") != nil && !s.addMembers(w, id, req, callQueueMemberDefaults) {
+		delete(s.callQueues, id)
+		return
+	}
+	writeJSON(w, http.StatusCreated, pick(callQueue, "id", "name", "extension_number", "status"))
+}
+
+func (s *Server) getCallQueue(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("callQueueId")
+	callQueue, ok := s.callQueues[id]
+	if !ok {
+		writeNotExist(w, "Call queue")
+		return
+	}
+	ret := pick(callQueue, "id", "name", "extension_id", "extension_number", "site", "status", "timezone",
+		"audio_prompt_language", "cost_center", "department", "recording_storage_location", "policy")
+	ret["members"] = s.groupMembers(id, "level", "receive_call")
+	ret["phone_numbers"] = []any{}
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (s *Server) patchCallQueue(w http.ResponseWriter, r *http.Request) {
+	callQueue, ok := s.callQueues[r.PathValue("callQueueId")]
+	if !ok {
+		writeNotExist(w, "Call queue")
+		return
+	}
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	if siteID := str(req, "site_id"); siteID != "" {
+		if _, ok := s.sites[siteID]; !ok {
+			writeNotExist(w, "Site")
+			return
+		}
+		callQueue["site"] = s.siteRef(siteID)
+	}
+	merge(callQueue, pick(req, "cost_center", "department", "extension_number", "name", "status", "timezone",
+		"audio_prompt_language", "recording_storage_location"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteCallQueue(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("callQueueId")
+	callQueue, ok := s.callQueues[id]
+	if !ok {
+		writeError(w, http.StatusBadRequest, 404, "Call queue does not exist.")
+		return
+	}
+	s.deleteExtension(str(callQueue, "extension_id"))
+	delete(s.members, id)
+	delete(s.callQueues, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listCallQueueMembers(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("callQueueId")
+	if _, ok := s.callQueues[id]; !ok {
+		writeNotExist(w, "Call queue")
+		return
+	}
+	var members []object
+	for _, member := range s.members[id] {
+		members = append(members, pick(member, "id", "name", "level", "receive_call", "extension_id"))
+	}
+	page, next, size := paginate(r, members, 30)
+	writeJSON(w, http.StatusOK, object{
+		"call_queue_members": page,
+		"next_page_token":    next,
+		"page_size":          size,
+		"total_records":      len(members),
+	})
+}
+
+func (s *Server) postCallQueueMembers(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("callQueueId")
+	if _, ok := s.callQueues[id]; !ok {
+		writeNotExist(w, "Call queue")
+		return
+	}
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	if !s.addMembers(w, id, req, callQueueMemberDefaults) {
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) deleteCallQueueMembers(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("callQueueId")
+	if _, ok := s.callQueues[id]; !ok {
+		writeError(w, http.StatusNotFound, 404, "Call queue does not exist.")
+		return
+	}
+	delete(s.members, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteCallQueueMember(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("callQueueId")
+	i, ok := s.findMember(id, r.PathValue("memberId"))
+	if !ok {
+		writeError(w, http.StatusNotFound, 404, "Member does not exist in the call queue.")
+		return
+	}
+	s.members[id] = slices.Delete(s.members[id], i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,84 @@
+package fakezoom
+
+import "net/http"
+
+// Device is a desk phone seeded with AddDevice. Devices cannot be created through the provider.
+type Device struct {
+	Name   string
+	SiteID string
+}
+
+// AddDevice seeds an online desk phone and returns its id.
+func (s *Server) AddDevice(device Device) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.newID("dev")
+	s.devices[id] = object{
+		"id":           id,
+		"display_name": device.Name,
+		"status":       "online",
+		"site":         s.siteRef(device.SiteID),
+		"reboots":      0,
+		"syncs":        0,
+	}
+	return id
+}
+
+// Reboots is the number of times the device has been rebooted, or -1 when it does not exist.
+func (s *Server) Reboots(deviceID string) int {
+	return s.deviceCount(deviceID, "reboots")
+}
+
+// Syncs is the number of times the device has been resynced, or -1 when it does not exist.
+func (s *Server) Syncs(deviceID string) int {
+	return s.deviceCount(deviceID, "syncs")
+}
+
+func (s *Server) deviceCount(deviceID, key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	device, ok := s.devices[deviceID]
+	if !ok {
+		return -1
+	}
+	return device[key].(int)
+}
+
+func (s *Server) postDeviceReboot(w http.ResponseWriter, r *http.Request) {
+	device, ok := s.devices[r.PathValue("deviceId")]
+	if !ok {
+		writeNotExist(w, "Device")
+		return
+	}
+	device["reboots"] = device["reboots"].(int) + 1
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) postDeviceSync(w http.ResponseWriter, r *http.Request) {
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	level, _ := integer(req["level"])
+	siteID := str(req, "site_id")
+	switch level {
+	case 1:
+	case 2:
+		if _, ok := s.sites[siteID]; !ok {
+			writeNotExist(w, "Site")
+			return
+		}
+	default:
+		writeError(w, http.StatusBadRequest, 300, "Invalid field: level.")
+		return
+	}
+	for _, device := range s.devices {
+		if level == 2 && str(child(device, "site"), "id") != siteID {
+			continue
+		}
+		device["syncs"] = device["syncs"].(int) + 1
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,162 @@
+package fakezoom
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// CommonArea is a common area phone seeded with AddCommonArea.
+type CommonArea struct {
+	Name   string
+	SiteID string
+}
+
+// AddCommonArea seeds a common area phone, which can be a member of call queues and shared line groups.
+func (s *Server) AddCommonArea(commonArea CommonArea) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.newID("ca")
+	extensionID, extensionNumber := s.newExtension()
+	s.commonAreas[id] = object{
+		"id":               id,
+		"name":             commonArea.Name,
+		"extension_id":     extensionID,
+		"extension_number": extensionNumber,
+		"site":             s.siteRef(commonArea.SiteID),
+	}
+	return id
+}
+
+// extension is the common view of the objects that own an extension.
+type extension struct {
+	id     string
+	typ    string
+	object object
+}
+
+func (e extension) name() string {
+	return lo.CoalesceOrEmpty(str(e.object, "name"), str(e.object, "display_name"))
+}
+
+func (s *Server) extensions() []extension {
+	var ret []extension
+	for typ, m := range map[string]map[string]object{
+		"autoReceptionist": s.autoReceptionists,
+		"callQueue":        s.callQueues,
+		"sharedLineGroup":  s.sharedLineGroups,
+		"commonArea":       s.commonAreas,
+		"user":             s.phoneUsers,
+	} {
+		for id, o := range m {
+			ret = append(ret, extension{id: id, typ: typ, object: o})
+		}
+	}
+	return ret
+}
+
+// deleteExtension cleans up what belongs to the extension of a deleted object.
+func (s *Server) deleteExtension(extensionID string) {
+	delete(s.callHandlings, extensionID)
+	for groupID, members := range s.members {
+		var kept []object
+		for _, member := range members {
+			if str(member, "extension_id") != extensionID {
+				kept = append(kept, member)
+			}
+		}
+		s.members[groupID] = kept
+	}
+}
+
+// member returns the group member for a user id, an email or a common area id.
+func (s *Server) member(userID, email, commonAreaID string) (object, bool) {
+	if commonAreaID != "" {
+		commonArea, ok := s.commonAreas[commonAreaID]
+		if !ok {
+			return nil, false
+		}
+		return object{
+			"id":           commonAreaID,
+			"name":         commonArea["name"],
+			"extension_id": commonArea["extension_id"],
+			"type":         "commonArea",
+			"level":        "commonArea",
+		}, true
+	}
+	for id, phoneUser := range s.phoneUsers {
+		if id == userID || (email != "" && strings.EqualFold(str(phoneUser, "email"), email)) {
+			return object{
+				"id":           id,
+				"name":         phoneUser["name"],
+				"extension_id": phoneUser["extension_id"],
+				"type":         "user",
+				"level":        "user",
+			}, true
+		}
+	}
+	return nil, false
+}
+
+// addMembers adds the members of an add members request to a call queue or shared line group.
+// The defaults are set to the new members.
+func (s *Server) addMembers(w http.ResponseWriter, groupID string, req object, defaults object) bool {
+	members := child(req, "members")
+	users := items(members, "users")
+	commonAreaIDs, _ := members["common_area_ids"].([]any)
+	if len(users) > 10 || len(commonAreaIDs) > 10 {
+		writeError(w, http.StatusBadRequest, 300, "A maximum of 10 members can be added at a time.")
+		return false
+	}
+
+	var added []object
+	for _, user := range users {
+		member, ok := s.member(str(user, "id"), str(user, "email"), "")
+		if !ok {
+			writeError(w, http.StatusBadRequest, 1001, "User does not exist or does not have a Zoom Phone license.")
+			return false
+		}
+		added = append(added, member)
+	}
+	for _, commonAreaID := range commonAreaIDs {
+		id, _ := commonAreaID.(string)
+		member, ok := s.member("", "", id)
+		if !ok {
+			writeNotExist(w, "Common area")
+			return false
+		}
+		added = append(added, member)
+	}
+
+	for _, member := range added {
+		if _, ok := s.findMember(groupID, str(member, "id")); !ok {
+			merge(member, defaults)
+			s.members[groupID] = append(s.members[groupID], member)
+		}
+	}
+	return true
+}
+
+func (s *Server) findMember(groupID, memberID string) (int, bool) {
+	for i, member := range s.members[groupID] {
+		if str(member, "id") == memberID {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// groupMembers returns the members in the shape of the get call queue and shared line group APIs.
+func (s *Server) groupMembers(groupID string, keys ...string) object {
+	users, commonAreas := []any{}, []any{}
+	for _, member := range s.members[groupID] {
+		if member["type"] == "commonArea" {
+			commonAreas = append(commonAreas, pick(member, "id", "name", "extension_id"))
+		} else {
+			users = append(users, pick(member, append([]string{"id", "name", "extension_id"}, keys...)...))
+		}
+	}
+	return object{"users": users, "common_areas": commonAreas}
+}
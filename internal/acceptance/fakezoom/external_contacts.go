@@ -0,0 +1,68 @@
+package fakezoom
+
+import "net/http"
+
+var externalContactKeys = []string{"description", "email", "extension_number", "id", "name", "phone_numbers", "routing_path", "auto_call_recorded"}
+
+func (s *Server) listExternalContacts(w http.ResponseWriter, r *http.Request) {
+	page, next, size := paginate(r, sorted(s.externalContacts), 30)
+	writeJSON(w, http.StatusOK, object{
+		"external_contacts": page,
+		"next_page_token":   next,
+		"page_size":         size,
+		"total_records":     len(s.externalContacts),
+	})
+}
+
+func (s *Server) postExternalContact(w http.ResponseWriter, r *http.Request) {
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	if str(req, "name") == "" {
+		writeError(w, http.StatusBadRequest, 300, "name is required.")
+		return
+	}
+
+	externalContactID := s.newID("ec")
+	externalContact := object{
+		"external_contact_id": externalContactID,
+		"phone_numbers":       []any{},
+	}
+	merge(externalContact, pick(req, externalContactKeys...))
+	s.externalContacts[externalContactID] = externalContact
+	writeJSON(w, http.StatusCreated, pick(externalContact, "name", "external_contact_id"))
+}
+
+func (s *Server) getExternalContact(w http.ResponseWriter, r *http.Request) {
+	externalContact, ok := s.externalContacts[r.PathValue("externalContactId")]
+	if !ok {
+		writeNotExist(w, "External contact")
+		return
+	}
+	writeJSON(w, http.StatusOK, externalContact)
+}
+
+func (s *Server) patchExternalContact(w http.ResponseWriter, r *http.Request) {
+	externalContact, ok := s.externalContacts[r.PathValue("externalContactId")]
+	if !ok {
+		writeNotExist(w, "External contact")
+		return
+	}
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	merge(externalContact, pick(req, externalContactKeys...))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteExternalContact(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("externalContactId")
+	if _, ok := s.externalContacts[id]; !ok {
+		writeNotExist(w, "External contact")
+		return
+	}
+	delete(s.externalContacts, id)
+	w.WriteHeader(http.StatusNoContent)
+}
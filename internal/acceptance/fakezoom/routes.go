@@ -0,0 +1,62 @@
+package fakezoom
+
+import (
+	"net/http"
+)
+
+func (s *Server) routePhone(mux *http.ServeMux) {
+	handle(mux, s, "GET /v2/phone/sites", s.listSites)
+	handle(mux, s, "POST /v2/phone/sites", s.postSite)
+	handle(mux, s, "GET /v2/phone/sites/{siteId}", s.getSite)
+	handle(mux, s, "PATCH /v2/phone/sites/{siteId}", s.patchSite)
+	handle(mux, s, "DELETE /v2/phone/sites/{siteId}", s.deleteSite)
+
+	handle(mux, s, "GET /v2/phone/auto_receptionists", s.listAutoReceptionists)
+	handle(mux, s, "POST /v2/phone/auto_receptionists", s.postAutoReceptionist)
+	handle(mux, s, "GET /v2/phone/auto_receptionists/{autoReceptionistId}", s.getAutoReceptionist)
+	handle(mux, s, "PATCH /v2/phone/auto_receptionists/{autoReceptionistId}", s.patchAutoReceptionist)
+	handle(mux, s, "DELETE /v2/phone/auto_receptionists/{autoReceptionistId}", s.deleteAutoReceptionist)
+
+	handle(mux, s, "GET /v2/phone/call_queues", s.listCallQueues)
+	handle(mux, s, "POST /v2/phone/call_queues", s.postCallQueue)
+	handle(mux, s, "GET /v2/phone/call_queues/{callQueueId}", s.getCallQueue)
+	handle(mux, s, "PATCH /v2/phone/call_queues/{callQueueId}", s.patchCallQueue)
+	handle(mux, s, "DELETE /v2/phone/call_queues/{callQueueId}", s.deleteCallQueue)
+	handle(mux, s, "GET /v2/phone/call_queues/{callQueueId}/members", s.listCallQueueMembers)
+	handle(mux, s, "POST /v2/phone/call_queues/{callQueueId}/members", s.postCallQueueMembers)
+	handle(mux, s, "DELETE /v2/phone/call_queues/{callQueueId}/members", s.deleteCallQueueMembers)
+	handle(mux, s, "DELETE /v2/phone/call_queues/{callQueueId}/members/{memberId}", s.deleteCallQueueMember)
+
+	handle(mux, s, "GET /v2/phone/shared_line_groups", s.listSharedLineGroups)
+	handle(mux, s, "POST /v2/phone/shared_line_groups", s.postSharedLineGroup)
+	handle(mux, s, "GET /v2/phone/shared_line_groups/{sharedLineGroupId}", s.getSharedLineGroup)
+	handle(mux, s, "PATCH /v2/phone/shared_line_groups/{sharedLineGroupId}", s.patchSharedLineGroup)
+	handle(mux, s, "DELETE /v2/phone/shared_line_groups/{sharedLineGroupId}", s.deleteSharedLineGroup)
+
+	handle(mux, s, "GET /v2/phone/blocked_list", s.listBlockedList)
+	handle(mux, s, "POST /v2/phone/blocked_list", s.postBlockedList)
+	handle(mux, s, "GET /v2/phone/blocked_list/{blockedListId}", s.getBlockedList)
+	handle(mux, s, "DELETE /v2/phone/blocked_list/{blockedListId}", s.deleteBlockedList)
+
+	handle(mux, s, "GET /v2/phone/external_contacts", s.listExternalContacts)
+	handle(mux, s, "POST /v2/phone/external_contacts", s.postExternalContact)
+	handle(mux, s, "GET /v2/phone/external_contacts/{externalContactId}", s.getExternalContact)
+	handle(mux, s, "PATCH /v2/phone/external_contacts/{externalContactId}", s.patchExternalContact)
+	handle(mux, s, "DELETE /v2/phone/external_contacts/{externalContactId}", s.deleteExternalContact)
+
+	handle(mux, s, "POST /v2/phone/devices/{deviceId}/reboot", s.postDeviceReboot)
+	handle(mux, s, "POST /v2/phone/devices/sync", s.postDeviceSync)
+
+	handle(mux, s, "GET /v2/phone/users", s.listPhoneUsers)
+	handle(mux, s, "GET /v2/phone/users/{userId}", s.getPhoneUser)
+	handle(mux, s, "PATCH /v2/phone/users/{userId}", s.patchPhoneUser)
+
+	handle(mux, s, "GET /v2/phone/extension/{extensionId}/call_handling/settings", s.getCallHandling)
+	handle(mux, s, "POST /v2/phone/extension/{extensionId}/call_handling/settings/{settingType}", s.postCallHandling)
+	handle(mux, s, "PATCH /v2/phone/extension/{extensionId}/call_handling/settings/{settingType}", s.patchCallHandling)
+	handle(mux, s, "DELETE /v2/phone/extension/{extensionId}/call_handling/settings/{settingType}", s.deleteCallHandling)
+}
+
+func (s *Server) routeUser(mux *http.ServeMux) {
+	handle(mux, s, "PATCH /v2/users/{userId}", s.patchUser)
+}
@@ -0,0 +1,291 @@
+// Package fakezoom provides an in-memory fake of the Zoom Phone and User APIs.
+// It serves the endpoints used by this provider and the OAuth token endpoint,
+// so resources can be created, read, updated, deleted and imported without a network.
+package fakezoom
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// AccessToken is the token issued by the OAuth endpoint of the fake and required by every API request.
+const AccessToken = "fake-zoom-access-token"
+
+// object is a JSON object in the shape of the Zoom API.
+type object = map[string]any
+
+type Server struct {
+	server *httptest.Server
+
+	mu  sync.Mutex
+	seq int
+
+	mainSiteID        string
+	sites             map[string]object
+	autoReceptionists map[string]object
+	callQueues        map[string]object
+	sharedLineGroups  map[string]object
+	members           map[string][]object // call queue or shared line group id -> members
+	blockedList       map[string]object
+	externalContacts  map[string]object
+	users             map[string]object
+	phoneUsers        map[string]object
+	commonAreas       map[string]object
+	devices           map[string]object
+	callHandlings     map[string]object // extension id -> call handling settings
+	nextExtension     int64
+}
+
+// NewServer starts a fake Zoom API server with an account that has only the main site.
+// Use AddUser, AddCommonArea and AddDevice to seed objects that the provider cannot create.
+func NewServer() *Server {
+	s := &Server{
+		sites:             map[string]object{},
+		autoReceptionists: map[string]object{},
+		callQueues:        map[string]object{},
+		members:           map[string][]object{},
+		sharedLineGroups:  map[string]object{},
+		blockedList:       map[string]object{},
+		externalContacts:  map[string]object{},
+		users:             map[string]object{},
+		phoneUsers:        map[string]object{},
+		commonAreas:       map[string]object{},
+		devices:           map[string]object{},
+		callHandlings:     map[string]object{},
+		nextExtension:     1000,
+	}
+	s.mainSiteID = s.createSite(object{
+		"name":                   "Main Site",
+		"auto_receptionist_name": "Main Auto Receptionist",
+	}, "main")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", s.handleToken)
+	s.routePhone(mux)
+	s.routeUser(mux)
+	s.server = httptest.NewServer(s.authorize(mux))
+	return s
+}
+
+// URL is the base URL of the server.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// APIURL is the URL to be used as api_url of the provider.
+func (s *Server) APIURL() string {
+	return s.server.URL + "/v2"
+}
+
+// TokenURL is the URL to be used as oauth_url of the provider.
+func (s *Server) TokenURL() string {
+	return s.server.URL + "/oauth/token"
+}
+
+// MainSiteID is the id of the main site, which always exists like a real Zoom Phone account.
+func (s *Server) MainSiteID() string {
+	return s.mainSiteID
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if _, _, ok := r.BasicAuth(); !ok {
+		writeJSON(w, http.StatusUnauthorized, object{
+			"reason": "Invalid client_id or client_secret",
+			"error":  "invalid_client",
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, object{
+		"access_token": AccessToken,
+		"token_type":   "bearer",
+		"expires_in":   3600,
+		"scope":        "phone:read:admin phone:write:admin user:read:admin user:write:admin",
+		"api_url":      s.server.URL,
+	})
+}
+
+func (s *Server) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth/token" && r.Header.Get("Authorization") != "Bearer "+AccessToken {
+			writeError(w, http.StatusUnauthorized, 124, "Invalid access token.")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handle registers an API handler, which is called while holding the state lock.
+func handle(mux *http.ServeMux, s *Server, pattern string, h func(w http.ResponseWriter, r *http.Request)) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		h(w, r)
+	})
+}
+
+func (s *Server) newID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s%08d", prefix, s.seq)
+}
+
+// newExtension allocates an extension and initializes its call handling settings.
+func (s *Server) newExtension() (string, int64) {
+	extensionID := s.newID("ext")
+	s.nextExtension++
+	s.callHandlings[extensionID] = defaultCallHandling(s)
+	return extensionID, s.nextExtension
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code int, message string) {
+	writeJSON(w, status, object{
+		"code":    code,
+		"message": message,
+	})
+}
+
+// writeNotExist writes the error Zoom returns for an unknown object id.
+func writeNotExist(w http.ResponseWriter, what string) {
+	writeError(w, http.StatusBadRequest, 300, fmt.Sprintf("%s does not exist.", what))
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request) (object, bool) {
+	body := object{}
+	if r.ContentLength == 0 {
+		return body, true
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, 300, fmt.Sprintf("Invalid request body: %v", err))
+		return nil, false
+	}
+	return body, true
+}
+
+// merge applies a PATCH request to the object. Nested objects are merged, other values are replaced.
+// An empty string removes the value, as Zoom omits a field that has been cleared from its responses.
+func merge(dst, src object) {
+	for key, value := range src {
+		if value == "" {
+			delete(dst, key)
+			continue
+		}
+		if child, ok := value.(object); ok {
+			if current, ok := dst[key].(object); ok {
+				merge(current, child)
+				continue
+			}
+			copied := object{}
+			merge(copied, child)
+			dst[key] = copied
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+func pick(src object, keys ...string) object {
+	dst := object{}
+	for _, key := range keys {
+		if value, ok := src[key]; ok {
+			dst[key] = value
+		}
+	}
+	return dst
+}
+
+// integer converts a JSON number, which is decoded as float64, or a number set by the fake.
+func integer(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	case float64:
+		return int64(n), true
+	}
+	return 0, false
+}
+
+func str(o object, key string) string {
+	v, _ := o[key].(string)
+	return v
+}
+
+func child(o object, key string) object {
+	v, _ := o[key].(object)
+	return v
+}
+
+func items(o object, key string) []object {
+	values, _ := o[key].([]any)
+	ret := make([]object, 0, len(values))
+	for _, value := range values {
+		if item, ok := value.(object); ok {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
+
+func list(values []object) []any {
+	ret := make([]any, len(values))
+	for i, value := range values {
+		ret[i] = value
+	}
+	return ret
+}
+
+// queryList returns the values of a query parameter given either repeatedly or comma separated.
+func queryList(r *http.Request, key string) []string {
+	var ret []string
+	for _, value := range r.URL.Query()[key] {
+		ret = append(ret, strings.Split(value, ",")...)
+	}
+	return ret
+}
+
+// sorted returns the objects ordered by id, i.e. in creation order.
+func sorted(m map[string]object) []object {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	ret := make([]object, len(keys))
+	for i, key := range keys {
+		ret[i] = m[key]
+	}
+	return ret
+}
+
+// paginate cuts a page out of values according to page_size and next_page_token.
+// The token is the offset of the page, which is enough for a fake.
+func paginate(r *http.Request, values []object, defaultSize int) ([]any, string, int) {
+	size, err := strconv.Atoi(r.URL.Query().Get("page_size"))
+	if err != nil || size <= 0 {
+		size = defaultSize
+	}
+	offset, _ := strconv.Atoi(r.URL.Query().Get("next_page_token"))
+	offset = min(max(offset, 0), len(values))
+	end := min(offset+size, len(values))
+	next := ""
+	if end < len(values) {
+		next = strconv.Itoa(end)
+	}
+	return list(values[offset:end]), next, size
+}
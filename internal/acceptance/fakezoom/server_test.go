@@ -0,0 +1,441 @@
+package fakezoom_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomuser"
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance/fakezoom"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/zoomclient"
+	"github.com/folio-sec/terraform-provider-zoom/internal/zoomoauth"
+)
+
+func newClients(t *testing.T, server *fakezoom.Server) (*zoomphone.Client, *zoomuser.Client) {
+	t.Helper()
+
+	oauthClient, err := zoomoauth.NewClient(zoomoauth.WithTokenURL(server.TokenURL()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenSource := zoomoauth.NewTokenSource(oauthClient, "account", "client", "secret")
+
+	phoneClient, err := zoomphone.NewClient(server.APIURL(), zoomclient.ZoomPhoneClientSecurity{TokenSource: tokenSource})
+	if err != nil {
+		t.Fatal(err)
+	}
+	userClient, err := zoomuser.NewClient(server.APIURL(), zoomclient.ZoomUserClientSecurity{TokenSource: tokenSource})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return phoneClient, userClient
+}
+
+func assertStatus(t *testing.T, err error, statusCode, code int) {
+	t.Helper()
+
+	var status *zoomphone.ErrorResponseStatusCode
+	if !errors.As(err, &status) {
+		t.Fatalf("expected an error response, got: %v", err)
+	}
+	if status.StatusCode != statusCode || status.Response.Code.Value != code {
+		t.Fatalf("expected %d/%d, got %d/%d", statusCode, code, status.StatusCode, status.Response.Code.Value)
+	}
+}
+
+type invalidToken struct{}
+
+func (invalidToken) OpenapiAuthorization(_ context.Context, _ string) (zoomphone.OpenapiAuthorization, error) {
+	return zoomphone.OpenapiAuthorization{}, nil
+}
+
+func (invalidToken) OpenapiOAuth(_ context.Context, _ string) (zoomphone.OpenapiOAuth, error) {
+	return zoomphone.OpenapiOAuth{Token: "invalid"}, nil
+}
+
+func TestServerUnauthorized(t *testing.T) {
+	server := fakezoom.NewServer()
+	defer server.Close()
+
+	client, err := zoomphone.NewClient(server.APIURL(), invalidToken{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.GetASite(context.Background(), zoomphone.GetASiteParams{SiteId: server.MainSiteID()})
+	assertStatus(t, err, 401, 124)
+}
+
+func TestServerSite(t *testing.T) {
+	ctx := context.Background()
+	server := fakezoom.NewServer()
+	defer server.Close()
+	client, _ := newClients(t, server)
+
+	created, err := client.CreatePhoneSite(ctx, zoomphone.NewOptCreatePhoneSiteReq(zoomphone.CreatePhoneSiteReq{
+		Name:                 "Tokyo",
+		AutoReceptionistName: "Tokyo Receptionist",
+		DefaultEmergencyAddress: zoomphone.CreatePhoneSiteReqDefaultEmergencyAddress{
+			AddressLine1: "1-1-1 Chiyoda",
+			City:         "Chiyoda-ku",
+			Country:      "JP",
+			StateCode:    "13",
+			Zip:          "100-0001",
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	site, err := client.GetASite(ctx, zoomphone.GetASiteParams{SiteId: created.ID.Value})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if site.Name.Value != "Tokyo" || site.MainAutoReceptionist.Value.Name.Value != "Tokyo Receptionist" || site.Country.Value.Code.Value != "JP" {
+		t.Fatalf("unexpected site: %+v", site)
+	}
+
+	err = client.UpdateSiteDetails(ctx, zoomphone.NewOptUpdateSiteDetailsReq(zoomphone.UpdateSiteDetailsReq{
+		Name: zoomphone.NewOptString("Osaka"),
+	}), zoomphone.UpdateSiteDetailsParams{SiteId: created.ID.Value})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sites, err := client.ListPhoneSites(ctx, zoomphone.ListPhoneSitesParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sites.Sites) != 2 || sites.Sites[1].Name.Value != "Osaka" {
+		t.Fatalf("unexpected sites: %+v", sites.Sites)
+	}
+
+	err = client.DeletePhoneSite(ctx, zoomphone.DeletePhoneSiteParams{SiteId: server.MainSiteID(), TransferSiteID: created.ID.Value})
+	assertStatus(t, err, 400, 300)
+
+	err = client.DeletePhoneSite(ctx, zoomphone.DeletePhoneSiteParams{SiteId: created.ID.Value, TransferSiteID: server.MainSiteID()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.GetASite(ctx, zoomphone.GetASiteParams{SiteId: created.ID.Value})
+	assertStatus(t, err, 400, 300)
+	err = client.DeletePhoneSite(ctx, zoomphone.DeletePhoneSiteParams{SiteId: created.ID.Value, TransferSiteID: server.MainSiteID()})
+	assertStatus(t, err, 400, 404)
+}
+
+func TestServerCallQueue(t *testing.T) {
+	ctx := context.Background()
+	server := fakezoom.NewServer()
+	defer server.Close()
+	client, _ := newClients(t, server)
+
+	userID := server.AddUser(fakezoom.User{Email: "alice@example.com", FirstName: "Alice", Phone: true})
+	commonAreaID := server.AddCommonArea(fakezoom.CommonArea{Name: "Lobby"})
+
+	created, err := client.CreateCallQueue(ctx, zoomphone.NewOptCreateCallQueueReq(zoomphone.CreateCallQueueReq{
+		Name: "Support",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	callQueueID := created.ID.Value
+
+	err = client.AddMembersToCallQueue(ctx, zoomphone.NewOptAddMembersToCallQueueReq(zoomphone.AddMembersToCallQueueReq{
+		Members: zoomphone.NewOptAddMembersToCallQueueReqMembers(zoomphone.AddMembersToCallQueueReqMembers{
+			CommonAreaIds: []string{commonAreaID},
+			Users: []zoomphone.AddMembersToCallQueueReqMembersUsersItem{
+				{Email: zoomphone.NewOptString("alice@example.com")},
+			},
+		}),
+	}), zoomphone.AddMembersToCallQueueParams{CallQueueId: callQueueID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	callQueue, err := client.GetACallQueue(ctx, zoomphone.GetACallQueueParams{CallQueueId: callQueueID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(callQueue.Members.Value.Users) != 1 || callQueue.Members.Value.Users[0].ID.Value != userID {
+		t.Fatalf("unexpected users: %+v", callQueue.Members.Value.Users)
+	}
+	if len(callQueue.Members.Value.CommonAreas) != 1 || callQueue.Members.Value.CommonAreas[0].ID.Value != commonAreaID {
+		t.Fatalf("unexpected common areas: %+v", callQueue.Members.Value.CommonAreas)
+	}
+
+	err = client.UnassignMemberFromCallQueue(ctx, zoomphone.UnassignMemberFromCallQueueParams{CallQueueId: callQueueID, MemberId: userID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.UnassignMemberFromCallQueue(ctx, zoomphone.UnassignMemberFromCallQueueParams{CallQueueId: callQueueID, MemberId: userID})
+	assertStatus(t, err, 404, 404)
+
+	err = client.DeleteACallQueue(ctx, zoomphone.DeleteACallQueueParams{CallQueueId: callQueueID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.GetACallQueue(ctx, zoomphone.GetACallQueueParams{CallQueueId: callQueueID})
+	assertStatus(t, err, 400, 300)
+}
+
+func TestServerSharedLineGroup(t *testing.T) {
+	ctx := context.Background()
+	server := fakezoom.NewServer()
+	defer server.Close()
+	client, _ := newClients(t, server)
+
+	created, err := client.CreateASharedLineGroup(ctx, zoomphone.NewOptCreateASharedLineGroupReq(zoomphone.CreateASharedLineGroupReq{
+		DisplayName: "Reception",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	slgID := created.ID.Value
+
+	err = client.UpdateASharedLineGroup(ctx, zoomphone.NewOptUpdateASharedLineGroupReq(zoomphone.UpdateASharedLineGroupReq{
+		DisplayName: zoomphone.NewOptString("Front Desk"),
+		Status:      zoomphone.NewOptString("inactive"),
+	}), zoomphone.UpdateASharedLineGroupParams{SharedLineGroupId: slgID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slg, err := client.GetASharedLineGroup(ctx, zoomphone.GetASharedLineGroupParams{SharedLineGroupId: slgID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slg.DisplayName.Value != "Front Desk" || slg.Status.Value != "inactive" {
+		t.Fatalf("unexpected shared line group: %+v", slg)
+	}
+
+	err = client.DeleteASharedLineGroup(ctx, zoomphone.DeleteASharedLineGroupParams{SharedLineGroupId: slgID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.DeleteASharedLineGroup(ctx, zoomphone.DeleteASharedLineGroupParams{SharedLineGroupId: slgID})
+	assertStatus(t, err, 400, 404)
+}
+
+func TestServerPhoneUser(t *testing.T) {
+	ctx := context.Background()
+	server := fakezoom.NewServer()
+	defer server.Close()
+	phoneClient, userClient := newClients(t, server)
+
+	userID := server.AddUser(fakezoom.User{Email: "carol@example.com", FirstName: "Carol"})
+
+	_, err := phoneClient.PhoneUser(ctx, zoomphone.PhoneUserParams{UserId: userID})
+	assertStatus(t, err, 404, 1001)
+
+	err = userClient.UserUpdate(ctx, zoomuser.NewOptUserUpdateReq(zoomuser.UserUpdateReq{
+		Feature: zoomuser.NewOptUserUpdateReqFeature(zoomuser.UserUpdateReqFeature{ZoomPhone: zoomuser.NewOptBool(true)}),
+	}), zoomuser.UserUpdateParams{UserId: userID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = phoneClient.UpdateUserProfile(ctx, zoomphone.NewOptUpdateUserProfileReq(zoomphone.UpdateUserProfileReq{
+		ExtensionNumber: zoomphone.NewOptString("2001"),
+	}), zoomphone.UpdateUserProfileParams{UserId: userID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	phoneUser, err := phoneClient.PhoneUser(ctx, zoomphone.PhoneUserParams{UserId: userID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if phoneUser.Email.Value != "carol@example.com" || phoneUser.ExtensionNumber.Value != 2001 || phoneUser.SiteID.Value != server.MainSiteID() {
+		t.Fatalf("unexpected phone user: %+v", phoneUser)
+	}
+
+	phoneUsers, err := phoneClient.ListPhoneUsers(ctx, zoomphone.ListPhoneUsersParams{Keyword: zoomphone.NewOptString("carol")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(phoneUsers.Users) != 1 || phoneUsers.Users[0].Site.Value.ID.Value != server.MainSiteID() {
+		t.Fatalf("unexpected phone users: %+v", phoneUsers.Users)
+	}
+
+	err = userClient.UserUpdate(ctx, zoomuser.NewOptUserUpdateReq(zoomuser.UserUpdateReq{
+		Feature: zoomuser.NewOptUserUpdateReqFeature(zoomuser.UserUpdateReqFeature{ZoomPhone: zoomuser.NewOptBool(false)}),
+	}), zoomuser.UserUpdateParams{UserId: userID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = phoneClient.PhoneUser(ctx, zoomphone.PhoneUserParams{UserId: userID})
+	assertStatus(t, err, 404, 1001)
+}
+
+func TestServerCallHandling(t *testing.T) {
+	ctx := context.Background()
+	server := fakezoom.NewServer()
+	defer server.Close()
+	client, _ := newClients(t, server)
+
+	created, err := client.CreateCallQueue(ctx, zoomphone.NewOptCreateCallQueueReq(zoomphone.CreateCallQueueReq{Name: "Sales"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	callQueue, err := client.GetACallQueue(ctx, zoomphone.GetACallQueueParams{CallQueueId: created.ID.Value})
+	if err != nil {
+		t.Fatal(err)
+	}
+	extensionID := callQueue.ExtensionID.Value
+
+	res, err := client.AddCallHandling(ctx, zoomphone.NewOptAddCallHandlingReq(zoomphone.AddCallHandlingReq{
+		Type: zoomphone.PostCallHandlingSettingsHolidayAddCallHandlingReq,
+		PostCallHandlingSettingsHoliday: zoomphone.PostCallHandlingSettingsHoliday{
+			Settings: zoomphone.NewOptPostCallHandlingSettingsHolidaySettings(zoomphone.PostCallHandlingSettingsHolidaySettings{
+				Name: zoomphone.NewOptString("New Year"),
+				From: zoomphone.NewOptDateTime(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)),
+				To:   zoomphone.NewOptDateTime(time.Date(2030, 1, 3, 0, 0, 0, 0, time.UTC)),
+			}),
+			SubSettingType: zoomphone.NewOptString("holiday"),
+		},
+	}), zoomphone.AddCallHandlingParams{ExtensionId: extensionID, SettingType: "holiday_hours"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	holidayID := res.AddCallHandlingCreated1.HolidayID.Value
+
+	err = client.UpdateCallHandling(ctx, zoomphone.NewOptUpdateCallHandlingReq(zoomphone.UpdateCallHandlingReq{
+		Type: zoomphone.PatchCallHandlingSettingsCallHandlingUpdateCallHandlingReq,
+		PatchCallHandlingSettingsCallHandling: zoomphone.PatchCallHandlingSettingsCallHandling{
+			Settings: zoomphone.NewOptPatchCallHandlingSettingsCallHandlingSettings(zoomphone.PatchCallHandlingSettingsCallHandlingSettings{
+				CallNotAnswerAction: zoomphone.NewOptInt(9),
+				PhoneNumber:         zoomphone.NewOptString("+81312345671"),
+				MaxWaitTime:         zoomphone.NewOptInt(60),
+			}),
+			SubSettingType: zoomphone.NewOptString("call_handling"),
+		},
+	}), zoomphone.UpdateCallHandlingParams{ExtensionId: extensionID, SettingType: "business_hours"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	callHandling, err := client.GetCallHandling(ctx, zoomphone.GetCallHandlingParams{ExtensionId: extensionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range callHandling.BusinessHours {
+		if item.SubSettingType.Value != "call_handling" {
+			continue
+		}
+		settings := item.Settings.Value
+		if settings.CallNotAnswerAction.Value != 9 || settings.MaxWaitTime.Value != 60 || settings.Routing.Value.ForwardTo.Value.PhoneNumber.Value != "+81312345671" {
+			t.Fatalf("unexpected call handling: %+v", settings)
+		}
+	}
+	if len(callHandling.HolidayHours) != 1 || callHandling.HolidayHours[0].HolidayID.Value != holidayID {
+		t.Fatalf("unexpected holiday hours: %+v", callHandling.HolidayHours)
+	}
+
+	err = client.DeleteCallHandling(ctx, zoomphone.DeleteCallHandlingParams{
+		ExtensionId: extensionID,
+		SettingType: "holiday_hours",
+		HolidayID:   zoomphone.NewOptString(holidayID),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.DeleteCallHandling(ctx, zoomphone.DeleteCallHandlingParams{
+		ExtensionId: extensionID,
+		SettingType: "holiday_hours",
+		HolidayID:   zoomphone.NewOptString(holidayID),
+	})
+	assertStatus(t, err, 404, 404)
+}
+
+func TestServerBlockedListAndExternalContact(t *testing.T) {
+	ctx := context.Background()
+	server := fakezoom.NewServer()
+	defer server.Close()
+	client, _ := newClients(t, server)
+
+	blocked, err := client.AddAnumberToBlockedList(ctx, zoomphone.NewOptAddAnumberToBlockedListReq(zoomphone.AddAnumberToBlockedListReq{
+		PhoneNumber: zoomphone.NewOptString("+81312345672"),
+		Comment:     zoomphone.NewOptString("spam"),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockedList, err := client.GetABlockedList(ctx, zoomphone.GetABlockedListParams{BlockedListId: blocked.ID.Value})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blockedList.PhoneNumber.Value != "+81312345672" || blockedList.Comment.Value != "spam" {
+		t.Fatalf("unexpected blocked list: %+v", blockedList)
+	}
+	blockedLists, err := client.ListBlockedList(ctx, zoomphone.ListBlockedListParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blockedLists.BlockedList) != 1 || blockedLists.BlockedList[0].ID.Value != blocked.ID.Value {
+		t.Fatalf("unexpected blocked lists: %+v", blockedLists.BlockedList)
+	}
+
+	contact, err := client.AddExternalContact(ctx, zoomphone.NewOptAddExternalContactReq(zoomphone.AddExternalContactReq{
+		Name:         "Partner",
+		PhoneNumbers: []string{"+81312345673"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	externalContact, err := client.GetAExternalContact(ctx, zoomphone.GetAExternalContactParams{ExternalContactId: contact.ExternalContactID.Value})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if externalContact.Name.Value != "Partner" || len(externalContact.PhoneNumbers) != 1 {
+		t.Fatalf("unexpected external contact: %+v", externalContact)
+	}
+	externalContacts, err := client.ListExternalContacts(ctx, zoomphone.ListExternalContactsParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(externalContacts.ExternalContacts) != 1 || externalContacts.ExternalContacts[0].ExternalContactID.Value != contact.ExternalContactID.Value {
+		t.Fatalf("unexpected external contacts: %+v", externalContacts.ExternalContacts)
+	}
+}
+
+func TestServerDevice(t *testing.T) {
+	ctx := context.Background()
+	server := fakezoom.NewServer()
+	defer server.Close()
+	client, _ := newClients(t, server)
+
+	site, err := client.CreatePhoneSite(ctx, zoomphone.NewOptCreatePhoneSiteReq(zoomphone.CreatePhoneSiteReq{
+		Name:                 "Branch",
+		AutoReceptionistName: "Branch Auto Receptionist",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mainDevice := server.AddDevice(fakezoom.Device{Name: "Lobby"})
+	branchDevice := server.AddDevice(fakezoom.Device{Name: "Desk", SiteID: site.ID.Value})
+
+	if err := client.RebootPhoneDevice(ctx, zoomphone.RebootPhoneDeviceParams{DeviceId: mainDevice}); err != nil {
+		t.Fatal(err)
+	}
+	if server.Reboots(mainDevice) != 1 || server.Reboots(branchDevice) != 0 {
+		t.Fatalf("expected only %s to be rebooted", mainDevice)
+	}
+	err = client.RebootPhoneDevice(ctx, zoomphone.RebootPhoneDeviceParams{DeviceId: "unknown"})
+	assertStatus(t, err, 400, 300)
+
+	if err := client.SyncPhoneDevice(ctx, zoomphone.NewOptSyncPhoneDeviceReq(zoomphone.SyncPhoneDeviceReq{
+		Level:  2,
+		SiteID: zoomphone.NewOptString(site.ID.Value),
+	})); err != nil {
+		t.Fatal(err)
+	}
+	if server.Syncs(mainDevice) != 0 || server.Syncs(branchDevice) != 1 {
+		t.Fatalf("expected only the devices of site %s to be resynced", site.ID.Value)
+	}
+	if err := client.SyncPhoneDevice(ctx, zoomphone.NewOptSyncPhoneDeviceReq(zoomphone.SyncPhoneDeviceReq{Level: 1})); err != nil {
+		t.Fatal(err)
+	}
+	if server.Syncs(mainDevice) != 1 || server.Syncs(branchDevice) != 2 {
+		t.Fatal("expected all devices of the account to be resynced")
+	}
+}
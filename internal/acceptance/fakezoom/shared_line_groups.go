@@ -0,0 +1,96 @@
+package fakezoom
+
+import (
+	"net/http"
+)
+
+func (s *Server) listSharedLineGroups(w http.ResponseWriter, r *http.Request) {
+	var sharedLineGroups []object
+	for _, sharedLineGroup := range sorted(s.sharedLineGroups) {
+		item := pick(sharedLineGroup, "id", "display_name", "extension_id", "extension_number", "site", "status")
+		item["phone_numbers"] = []any{}
+		sharedLineGroups = append(sharedLineGroups, item)
+	}
+	page, next, size := paginate(r, sharedLineGroups, 30)
+	writeJSON(w, http.StatusOK, object{
+		"shared_line_groups": page,
+		"next_page_token":    next,
+		"page_size":          size,
+		"total_records":      len(sharedLineGroups),
+	})
+}
+
+func (s *Server) postSharedLineGroup(w http.ResponseWriter, r *http.Request) {
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	if str(req, "display_name") == "" {
+		writeError(w, http.StatusBadRequest, 300, "display_name is required.")
+		return
+	}
+	if siteID := str(req, "site_id"); siteID != "" {
+		if _, ok := s.sites[siteID]; !ok {
+			writeNotExist(w, "Site")
+			return
+		}
+	}
+
+	id := s.newID("slg")
+	extensionID, extensionNumber := s.newExtension()
+	sharedLineGroup := object{
+		"id":               id,
+		"display_name":     req["display_name"],
+		"extension_id":     extensionID,
+		"extension_number": extensionNumber,
+		"site":             s.siteRef(str(req, "site_id")),
+		"status":           "active",
+		"timezone":         "America/Los_Angeles",
+	}
+	merge(sharedLineGroup, pick(req, "extension_number"))
+	s.sharedLineGroups[id] = sharedLineGroup
+	writeJSON(w, http.StatusCreated, pick(sharedLineGroup, "id", "display_name"))
+}
+
+func (s *Server) getSharedLineGroup(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("sharedLineGroupId")
+	sharedLineGroup, ok := s.sharedLineGroups[id]
+	if !ok {
+		writeNotExist(w, "Shared line group")
+		return
+	}
+	ret := pick(sharedLineGroup, "id", "display_name", "extension_id", "extension_number", "site", "status", "timezone",
+		"primary_number", "cost_center", "department", "audio_prompt_language", "recording_storage_location", "allow_privacy")
+	ret["members"] = s.groupMembers(id)
+	ret["phone_numbers"] = []any{}
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (s *Server) patchSharedLineGroup(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("sharedLineGroupId")
+	sharedLineGroup, ok := s.sharedLineGroups[id]
+	if !ok {
+		writeNotExist(w, "Shared line group")
+		return
+	}
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	merge(sharedLineGroup, pick(req, "display_name", "extension_number", "primary_number", "status", "timezone",
+		"cost_center", "department", "audio_prompt_language", "recording_storage_location", "allow_privacy"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteSharedLineGroup(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("sharedLineGroupId")
+	sharedLineGroup, ok := s.sharedLineGroups[id]
+	if !ok {
+		writeError(w, http.StatusBadRequest, 404, "Shared line group does not exist.")
+		return
+	}
+	s.deleteExtension(str(sharedLineGroup, "extension_id"))
+	delete(s.members, id)
+	delete(s.sharedLineGroups, id)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,142 @@
+package fakezoom
+
+import (
+	"net/http"
+)
+
+const defaultSipZoneID = "fake-sip-zone"
+
+func (s *Server) createSite(req object, level string) string {
+	id := s.newID("site")
+	site := object{
+		"id":              id,
+		"name":            req["name"],
+		"level":           level,
+		"short_extension": object{"length": 3},
+		"sip_zone":        object{"id": defaultSipZoneID, "name": "Default SIP Zone"},
+	}
+	if address := child(req, "default_emergency_address"); address != nil {
+		site["country"] = object{"code": address["country"], "name": address["country"]}
+	}
+	if length, ok := child(req, "short_extension")["length"]; ok {
+		site["short_extension"] = object{"length": length}
+	}
+	if zoneID := str(child(req, "sip_zone"), "id"); zoneID != "" {
+		site["sip_zone"] = object{"id": zoneID, "name": zoneID}
+	}
+	merge(site, pick(req, "site_code", "india_state_code", "india_city", "india_sdca_npa", "india_entity_name"))
+	s.sites[id] = site
+
+	autoReceptionistID := s.createAutoReceptionist(object{
+		"name":    req["auto_receptionist_name"],
+		"site_id": id,
+	})
+	autoReceptionist := s.autoReceptionists[autoReceptionistID]
+	site["main_auto_receptionist"] = object{
+		"id":               autoReceptionistID,
+		"name":             autoReceptionist["name"],
+		"extension_id":     autoReceptionist["extension_id"],
+		"extension_number": autoReceptionist["extension_number"],
+	}
+	return id
+}
+
+func (s *Server) listSites(w http.ResponseWriter, r *http.Request) {
+	var sites []object
+	for _, site := range sorted(s.sites) {
+		sites = append(sites, pick(site, "country", "id", "main_auto_receptionist", "name", "site_code", "level"))
+	}
+	page, next, size := paginate(r, sites, 30)
+	writeJSON(w, http.StatusOK, object{
+		"sites":           page,
+		"next_page_token": next,
+		"page_size":       size,
+		"total_records":   len(sites),
+	})
+}
+
+func (s *Server) postSite(w http.ResponseWriter, r *http.Request) {
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	if str(req, "name") == "" || str(req, "auto_receptionist_name") == "" || child(req, "default_emergency_address") == nil {
+		writeError(w, http.StatusBadRequest, 300, "name, auto_receptionist_name and default_emergency_address are required.")
+		return
+	}
+	id := s.createSite(req, "sub")
+	writeJSON(w, http.StatusCreated, pick(s.sites[id], "id", "name"))
+}
+
+func (s *Server) getSite(w http.ResponseWriter, r *http.Request) {
+	site, ok := s.sites[r.PathValue("siteId")]
+	if !ok {
+		writeNotExist(w, "Site")
+		return
+	}
+	writeJSON(w, http.StatusOK, site)
+}
+
+func (s *Server) patchSite(w http.ResponseWriter, r *http.Request) {
+	site, ok := s.sites[r.PathValue("siteId")]
+	if !ok {
+		writeNotExist(w, "Site")
+		return
+	}
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	if zoneID := str(child(req, "sip_zone"), "id"); zoneID != "" && zoneID != str(child(site, "sip_zone"), "id") {
+		site["sip_zone"] = object{"id": zoneID, "name": zoneID}
+	}
+	delete(req, "sip_zone")
+	delete(req, "default_emergency_address")
+	merge(site, req)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteSite(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("siteId")
+	if _, ok := s.sites[id]; !ok {
+		writeError(w, http.StatusBadRequest, 404, "Site does not exist.")
+		return
+	}
+	if id == s.mainSiteID {
+		writeError(w, http.StatusBadRequest, 300, "The main site cannot be deleted.")
+		return
+	}
+	transferSiteID := r.URL.Query().Get("transfer_site_id")
+	if _, ok := s.sites[transferSiteID]; !ok || transferSiteID == id {
+		writeError(w, http.StatusBadRequest, 300, "The transfer site does not exist.")
+		return
+	}
+
+	// objects of the site are moved to the transfer site, except for the main auto receptionist.
+	mainAutoReceptionistID := str(child(s.sites[id], "main_auto_receptionist"), "id")
+	s.deleteExtension(str(s.autoReceptionists[mainAutoReceptionistID], "extension_id"))
+	delete(s.autoReceptionists, mainAutoReceptionistID)
+	for _, m := range []map[string]object{s.autoReceptionists, s.callQueues, s.sharedLineGroups} {
+		for _, o := range m {
+			if str(child(o, "site"), "id") == id {
+				o["site"] = s.siteRef(transferSiteID)
+			}
+		}
+	}
+	for _, phoneUser := range s.phoneUsers {
+		if str(phoneUser, "site_id") == id {
+			phoneUser["site_id"] = transferSiteID
+		}
+	}
+	delete(s.sites, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// siteRef is the reference to a site embedded in other objects.
+func (s *Server) siteRef(siteID string) object {
+	site, ok := s.sites[siteID]
+	if !ok {
+		site = s.sites[s.mainSiteID]
+	}
+	return pick(site, "id", "name")
+}
@@ -0,0 +1,177 @@
+package fakezoom
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// User is a Zoom user seeded with AddUser. Users cannot be created through the provider.
+type User struct {
+	Email     string
+	FirstName string
+	LastName  string
+	// Phone enables Zoom Phone for the user, as the zoom_phone_user resource does.
+	Phone bool
+}
+
+// AddUser seeds an active Zoom user and returns its id.
+func (s *Server) AddUser(user User) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.newID("u")
+	s.users[id] = object{
+		"id":           id,
+		"email":        user.Email,
+		"first_name":   user.FirstName,
+		"last_name":    user.LastName,
+		"display_name": strings.TrimSpace(user.FirstName + " " + user.LastName),
+		"type":         1,
+		"status":       "active",
+		"timezone":     "America/Los_Angeles",
+	}
+	if user.Phone {
+		s.enablePhone(id)
+	}
+	return id
+}
+
+// enablePhone creates the phone user of a Zoom user in the main site like enabling the Zoom Phone feature.
+func (s *Server) enablePhone(userID string) {
+	if _, ok := s.phoneUsers[userID]; ok {
+		return
+	}
+	user := s.users[userID]
+	extensionID, extensionNumber := s.newExtension()
+	s.phoneUsers[userID] = object{
+		"id":               userID,
+		"phone_user_id":    s.newID("pu"),
+		"email":            user["email"],
+		"name":             user["display_name"],
+		"extension_id":     extensionID,
+		"extension_number": extensionNumber,
+		"site_id":          s.mainSiteID,
+		"status":           "activate",
+		"calling_plans":    []any{},
+	}
+}
+
+func (s *Server) disablePhone(userID string) {
+	phoneUser, ok := s.phoneUsers[userID]
+	if !ok {
+		return
+	}
+	s.deleteExtension(str(phoneUser, "extension_id"))
+	delete(s.phoneUsers, userID)
+}
+
+func (s *Server) patchUser(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("userId")
+	user, ok := s.users[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, 1001, "User does not exist: "+id+".")
+		return
+	}
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	if zoomPhone, ok := child(req, "feature")["zoom_phone"].(bool); ok {
+		if zoomPhone {
+			s.enablePhone(id)
+		} else {
+			s.disablePhone(id)
+		}
+	}
+	merge(user, pick(req, "first_name", "last_name", "display_name", "dept", "timezone"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// phoneUser returns the phone user, or writes the error Zoom returns for a user without Zoom Phone.
+func (s *Server) phoneUser(w http.ResponseWriter, r *http.Request) (object, bool) {
+	id := r.PathValue("userId")
+	phoneUser, ok := s.phoneUsers[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, 1001, "User does not exist or does not have a Zoom Phone license.")
+		return nil, false
+	}
+	return phoneUser, true
+}
+
+func (s *Server) listPhoneUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var phoneUsers []object
+	for _, phoneUser := range sorted(s.phoneUsers) {
+		if siteID := query.Get("site_id"); siteID != "" && str(phoneUser, "site_id") != siteID {
+			continue
+		}
+		if status := query.Get("status"); status != "" && str(phoneUser, "status") != status {
+			continue
+		}
+		if department := query.Get("department"); department != "" && str(phoneUser, "department") != department {
+			continue
+		}
+		if costCenter := query.Get("cost_center"); costCenter != "" && str(phoneUser, "cost_center") != costCenter {
+			continue
+		}
+		if keyword := strings.ToLower(query.Get("keyword")); keyword != "" &&
+			!strings.Contains(strings.ToLower(str(phoneUser, "name")), keyword) &&
+			!strings.Contains(strings.ToLower(str(phoneUser, "email")), keyword) {
+			continue
+		}
+		item := pick(phoneUser, "id", "phone_user_id", "email", "name", "extension_id", "extension_number", "status",
+			"calling_plans", "cost_center", "department")
+		item["site"] = s.siteRef(str(phoneUser, "site_id"))
+		item["phone_numbers"] = []any{}
+		phoneUsers = append(phoneUsers, item)
+	}
+	page, next, size := paginate(r, phoneUsers, 30)
+	writeJSON(w, http.StatusOK, object{
+		"users":           page,
+		"next_page_token": next,
+		"page_size":       size,
+		"total_records":   len(phoneUsers),
+	})
+}
+
+func (s *Server) getPhoneUser(w http.ResponseWriter, r *http.Request) {
+	phoneUser, ok := s.phoneUser(w, r)
+	if !ok {
+		return
+	}
+	ret := pick(phoneUser, "id", "phone_user_id", "email", "extension_id", "extension_number", "site_id", "status",
+		"calling_plans", "cost_center", "department", "emergency_address")
+	ret["phone_numbers"] = []any{}
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (s *Server) patchPhoneUser(w http.ResponseWriter, r *http.Request) {
+	phoneUser, ok := s.phoneUser(w, r)
+	if !ok {
+		return
+	}
+	req, ok := readJSON(w, r)
+	if !ok {
+		return
+	}
+	if extensionNumber := str(req, "extension_number"); extensionNumber != "" {
+		number, err := strconv.ParseInt(extensionNumber, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, 300, "Invalid extension number.")
+			return
+		}
+		phoneUser["extension_number"] = number
+	}
+	if siteID := str(req, "site_id"); siteID != "" {
+		if _, ok := s.sites[siteID]; !ok {
+			writeNotExist(w, "Site")
+			return
+		}
+		phoneUser["site_id"] = siteID
+	}
+	if emergencyAddressID := str(req, "emergency_address_id"); emergencyAddressID != "" {
+		phoneUser["emergency_address"] = object{"id": emergencyAddressID}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
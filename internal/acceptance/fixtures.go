@@ -0,0 +1,79 @@
+package acceptance
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance/cassette"
+)
+
+// The environment variables that give the objects some acceptance tests need but the provider cannot create.
+// With TF_ACC_FAKE they are the objects seeded by FakeZoom, and a replayed test uses the ones it was recorded with.
+// Otherwise the tests that need them are skipped without them.
+const (
+	// EnvSiteID is the ID of the site in which the tests create call queues, shared line groups and so on.
+	EnvSiteID = "ZOOM_ACCTEST_SITE_ID"
+	// EnvPhoneUserEmails are the comma separated emails of two users of the site with Zoom Phone,
+	// which are made members of call queues.
+	EnvPhoneUserEmails = "ZOOM_ACCTEST_PHONE_USER_EMAILS"
+	// EnvUserID is the ID of a user without Zoom Phone, for which the tests enable Zoom Phone.
+	EnvUserID = "ZOOM_ACCTEST_USER_ID"
+	// EnvCommonAreaID is the ID of a common area of the site, which is made a member of call queues.
+	EnvCommonAreaID = "ZOOM_ACCTEST_COMMON_AREA_ID"
+)
+
+// fakeFixtures are the values of the environment variables above for the objects seeded by FakeZoom.
+var fakeFixtures map[string]string
+
+func fixture(t *testing.T, key string) string {
+	t.Helper()
+	if os.Getenv("TF_ACC_FAKE") != "" {
+		FakeZoom()
+		return fakeFixtures[key]
+	}
+	recorder, mode, ok := testRecorder(t)
+	if ok && mode == cassette.ModeReplay {
+		value, ok := recorder.Fixture(key)
+		if !ok {
+			t.Fatalf("%s has no %s, record it again with TF_ACC_RECORD=1", cassettePath(t), key)
+		}
+		return value
+	}
+	value := os.Getenv(key)
+	if value == "" {
+		t.Skipf("%s must be set for this acceptance test", key)
+	}
+	if ok {
+		recorder.SetFixture(key, value)
+	}
+	return value
+}
+
+// SiteID returns the ID of the site given by ZOOM_ACCTEST_SITE_ID.
+func SiteID(t *testing.T) string {
+	t.Helper()
+	return fixture(t, EnvSiteID)
+}
+
+// PhoneUserEmails returns the two emails given by ZOOM_ACCTEST_PHONE_USER_EMAILS.
+func PhoneUserEmails(t *testing.T) (string, string) {
+	t.Helper()
+	emails := strings.Split(fixture(t, EnvPhoneUserEmails), ",")
+	if len(emails) != 2 {
+		t.Fatalf("%s must be two comma separated emails", EnvPhoneUserEmails)
+	}
+	return strings.TrimSpace(emails[0]), strings.TrimSpace(emails[1])
+}
+
+// UserID returns the ID of the user without Zoom Phone given by ZOOM_ACCTEST_USER_ID.
+func UserID(t *testing.T) string {
+	t.Helper()
+	return fixture(t, EnvUserID)
+}
+
+// CommonAreaID returns the ID of the common area given by ZOOM_ACCTEST_COMMON_AREA_ID.
+func CommonAreaID(t *testing.T) string {
+	t.Helper()
+	return fixture(t, EnvCommonAreaID)
+}
@@ -0,0 +1,66 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/nyaruka/phonenumbers"
+	"github.com/samber/lo"
+)
+
+var _ function.Function = &formatPhoneNumberFunction{}
+
+func NewFormatPhoneNumberFunction() function.Function {
+	return &formatPhoneNumberFunction{}
+}
+
+type formatPhoneNumberFunction struct{}
+
+func (f *formatPhoneNumberFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "format_phone_number"
+}
+
+func (f *formatPhoneNumberFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Format a phone number for display",
+		MarkdownDescription: "Formats a phone number with its country calling code, e.g. the one of a `zoom_phone_phone_numbers` data source, " +
+			"for display such as in the name of a call queue. A number without the country calling code must be normalized by `normalize_e164` first.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "number",
+				MarkdownDescription: "The phone number starting with `+`, e.g. `+12015550123`.",
+			},
+			function.StringParameter{
+				Name: "style",
+				MarkdownDescription: "One of `e164` (`+12015550123`), `international` (`+1 201-555-0123`), " +
+					"`national` (`(201) 555-0123`) and `rfc3966` (`tel:+1-201-555-0123`).",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *formatPhoneNumberFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var number, style string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &number, &style))
+	if resp.Error != nil {
+		return
+	}
+
+	format, ok := phoneNumberStyles[strings.ToLower(style)]
+	if !ok {
+		styles := lo.Keys(phoneNumberStyles)
+		slices.Sort(styles)
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("unknown style %q, expected one of %s", style, strings.Join(styles, ", ")))
+		return
+	}
+	parsed, err := parsePhoneNumber(number, "")
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, phonenumbers.Format(parsed, format)))
+}
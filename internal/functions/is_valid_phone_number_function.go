@@ -0,0 +1,54 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nyaruka/phonenumbers"
+)
+
+var _ function.Function = &isValidPhoneNumberFunction{}
+
+func NewIsValidPhoneNumberFunction() function.Function {
+	return &isValidPhoneNumberFunction{}
+}
+
+type isValidPhoneNumberFunction struct{}
+
+func (f *isValidPhoneNumberFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_phone_number"
+}
+
+func (f *isValidPhoneNumberFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check whether a phone number is valid",
+		MarkdownDescription: "Returns whether a phone number written in any common format is assigned in the numbering plan of its country, " +
+			"e.g. to validate the input variables of a module. It never fails, and returns `false` for text which is not a phone number.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "number",
+				MarkdownDescription: "The phone number to check.",
+			},
+			function.StringParameter{
+				Name:                "default_country",
+				MarkdownDescription: "The ISO 3166-1 alpha-2 code of the country of a number without the international prefix, e.g. `US`. It may be null when the number starts with `+`.",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *isValidPhoneNumberFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var number string
+	var defaultCountry types.String
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &number, &defaultCountry))
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := parsePhoneNumber(number, defaultCountry.ValueString())
+	valid := err == nil && phonenumbers.IsValidNumber(parsed)
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, valid))
+}
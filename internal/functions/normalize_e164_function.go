@@ -0,0 +1,62 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nyaruka/phonenumbers"
+)
+
+var _ function.Function = &normalizeE164Function{}
+
+func NewNormalizeE164Function() function.Function {
+	return &normalizeE164Function{}
+}
+
+type normalizeE164Function struct{}
+
+func (f *normalizeE164Function) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_e164"
+}
+
+func (f *normalizeE164Function) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalize a phone number to E.164",
+		MarkdownDescription: "Converts a phone number written in any common format, e.g. `(201) 555-0123` or `03-1234-5678`, to the [E.164](https://en.wikipedia.org/wiki/E.164) format Zoom returns, e.g. `+12015550123`. " +
+			"It fails when the number has too few or too many digits for its country.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "number",
+				MarkdownDescription: "The phone number to normalize.",
+			},
+			function.StringParameter{
+				Name:                "default_country",
+				MarkdownDescription: "The ISO 3166-1 alpha-2 code of the country of a number without the international prefix, e.g. `US`. It may be null when the number starts with `+`.",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *normalizeE164Function) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var number string
+	var defaultCountry types.String
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &number, &defaultCountry))
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := parsePhoneNumber(number, defaultCountry.ValueString())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	if !phonenumbers.IsPossibleNumber(parsed) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%q has too few or too many digits to be a phone number", number))
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, phonenumbers.Format(parsed, phonenumbers.E164)))
+}
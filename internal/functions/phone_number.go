@@ -0,0 +1,34 @@
+package functions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nyaruka/phonenumbers"
+)
+
+// parsePhoneNumber parses a number written in any common format, e.g. with spaces, dashes or parentheses.
+// defaultCountry is the ISO 3166-1 alpha-2 code of the country a number without the international prefix is in,
+// and may be empty when the number starts with a plus sign.
+func parsePhoneNumber(number, defaultCountry string) (*phonenumbers.PhoneNumber, error) {
+	defaultCountry = strings.ToUpper(strings.TrimSpace(defaultCountry))
+	if defaultCountry != "" && phonenumbers.GetCountryCodeForRegion(defaultCountry) == 0 {
+		return nil, fmt.Errorf("unknown country code %q, expected an ISO 3166-1 alpha-2 code such as US or JP", defaultCountry)
+	}
+	if defaultCountry == "" && !strings.HasPrefix(strings.TrimSpace(number), "+") {
+		return nil, fmt.Errorf("%q has no country calling code, set the default country or prefix it with +", number)
+	}
+	parsed, err := phonenumbers.Parse(number, defaultCountry)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a phone number: %w", number, err)
+	}
+	return parsed, nil
+}
+
+// phoneNumberStyles are the styles of format_phone_number.
+var phoneNumberStyles = map[string]phonenumbers.PhoneNumberFormat{
+	"e164":          phonenumbers.E164,
+	"international": phonenumbers.INTERNATIONAL,
+	"national":      phonenumbers.NATIONAL,
+	"rfc3966":       phonenumbers.RFC3966,
+}
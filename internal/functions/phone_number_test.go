@@ -0,0 +1,93 @@
+package functions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/functions"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func run(f function.Function, result attr.Value, args ...attr.Value) (attr.Value, *function.FuncError) {
+	resp := function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func TestNormalizeE164(t *testing.T) {
+	for _, tc := range []struct {
+		number         string
+		defaultCountry types.String
+		want           string
+		wantErr        bool
+	}{
+		{"(201) 555-0123", types.StringValue("US"), "+12015550123", false},
+		{"03-1234-5678", types.StringValue("jp"), "+81312345678", false},
+		{" +81 3 1234 5678 ", types.StringNull(), "+81312345678", false},
+		{"+1 201.555.0123", types.StringValue("JP"), "+12015550123", false},
+		{"201-555-0123", types.StringNull(), "", true},
+		{"201-555-0123", types.StringValue("XX"), "", true},
+		{"12", types.StringValue("US"), "", true},
+		{"not a number", types.StringValue("US"), "", true},
+	} {
+		got, err := run(functions.NewNormalizeE164Function(), types.StringUnknown(), types.StringValue(tc.number), tc.defaultCountry)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("normalize_e164(%q, %s): unexpected error %v", tc.number, tc.defaultCountry, err)
+			continue
+		}
+		if !tc.wantErr && !got.Equal(types.StringValue(tc.want)) {
+			t.Errorf("normalize_e164(%q, %s) = %s, want %s", tc.number, tc.defaultCountry, got, tc.want)
+		}
+	}
+}
+
+func TestIsValidPhoneNumber(t *testing.T) {
+	for _, tc := range []struct {
+		number         string
+		defaultCountry types.String
+		want           bool
+	}{
+		{"(201) 555-0123", types.StringValue("US"), true},
+		{"+81 3-1234-5678", types.StringNull(), true},
+		{"+1 000 555 0123", types.StringNull(), false},
+		{"201-555-0123", types.StringNull(), false},
+		{"not a number", types.StringValue("US"), false},
+	} {
+		got, err := run(functions.NewIsValidPhoneNumberFunction(), types.BoolUnknown(), types.StringValue(tc.number), tc.defaultCountry)
+		if err != nil {
+			t.Errorf("is_valid_phone_number(%q, %s): unexpected error %v", tc.number, tc.defaultCountry, err)
+			continue
+		}
+		if !got.Equal(types.BoolValue(tc.want)) {
+			t.Errorf("is_valid_phone_number(%q, %s) = %s, want %v", tc.number, tc.defaultCountry, got, tc.want)
+		}
+	}
+}
+
+func TestFormatPhoneNumber(t *testing.T) {
+	for _, tc := range []struct {
+		number  string
+		style   string
+		want    string
+		wantErr bool
+	}{
+		{"+12015550123", "e164", "+12015550123", false},
+		{"+12015550123", "international", "+1 201-555-0123", false},
+		{"+12015550123", "national", "(201) 555-0123", false},
+		{"+81312345678", "NATIONAL", "03-1234-5678", false},
+		{"+12015550123", "rfc3966", "tel:+1-201-555-0123", false},
+		{"+12015550123", "pretty", "", true},
+		{"2015550123", "e164", "", true},
+	} {
+		got, err := run(functions.NewFormatPhoneNumberFunction(), types.StringUnknown(), types.StringValue(tc.number), types.StringValue(tc.style))
+		if (err != nil) != tc.wantErr {
+			t.Errorf("format_phone_number(%q, %q): unexpected error %v", tc.number, tc.style, err)
+			continue
+		}
+		if !tc.wantErr && !got.Equal(types.StringValue(tc.want)) {
+			t.Errorf("format_phone_number(%q, %q) = %s, want %s", tc.number, tc.style, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package functions
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The types of a day of custom_hours.settings.
+const (
+	scheduleClosed     = 0
+	scheduleAllDay     = 1
+	scheduleCustomized = 2
+)
+
+// weekdays are the days in the order of the weekday numbers of Zoom, i.e. Sunday is 1.
+var weekdays = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+var scheduleTimeRegexp = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+
+type scheduleSetting struct {
+	weekday int
+	typ     int
+	from    string
+	to      string
+}
+
+// parseWeeklySchedule parses a schedule such as "Mon-Fri 09:00-12:00,13:00-18:00; Sat 24h" into the settings of
+// all seven days, where the days not in the schedule are closed.
+func parseWeeklySchedule(schedule string) ([]scheduleSetting, error) {
+	var settings []scheduleSetting
+	seen := map[int]string{}
+	for _, entry := range strings.FieldsFunc(schedule, func(r rune) bool { return r == ';' || r == '\n' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		// the days are the words before the first time or "closed", e.g. "Sat, Sun" in "Sat, Sun 10:00-14:00"
+		fields := strings.Fields(entry)
+		i := 0
+		for i < len(fields) && !unicode.IsDigit(rune(fields[i][0])) && !strings.EqualFold(fields[i], "closed") {
+			i++
+		}
+		if i == 0 || i == len(fields) {
+			return nil, fmt.Errorf("%q must be days followed by hours, e.g. \"Mon-Fri 09:00-18:00\"", entry)
+		}
+		weekdayNumbers, err := parseScheduleDays(strings.Join(fields[:i], ""))
+		if err != nil {
+			return nil, err
+		}
+		daySettings, err := parseScheduleHours(strings.Join(fields[i:], " "))
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", entry, err)
+		}
+		for _, weekday := range weekdayNumbers {
+			if previous, ok := seen[weekday]; ok {
+				return nil, fmt.Errorf("%s is in both %q and %q, list all the hours of a day in one entry, e.g. \"Mon 09:00-12:00,13:00-18:00\"",
+					dayName(weekday), previous, entry)
+			}
+			seen[weekday] = entry
+			for _, setting := range daySettings {
+				setting.weekday = weekday
+				settings = append(settings, setting)
+			}
+		}
+	}
+
+	for weekday := 1; weekday <= len(weekdays); weekday++ {
+		if _, ok := seen[weekday]; !ok {
+			settings = append(settings, scheduleSetting{weekday: weekday, typ: scheduleClosed})
+		}
+	}
+	slices.SortStableFunc(settings, func(a, b scheduleSetting) int {
+		if a.weekday != b.weekday {
+			return a.weekday - b.weekday
+		}
+		return strings.Compare(a.from, b.from)
+	})
+	return settings, nil
+}
+
+// parseScheduleDays parses days such as "Mon-Fri", "Sat,Sun" or "Fri-Mon" into the weekday numbers.
+func parseScheduleDays(days string) ([]int, error) {
+	var weekdayNumbers []int
+	for _, part := range strings.Split(days, ",") {
+		first, last, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		start, err := parseScheduleDay(first)
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if isRange {
+			if end, err = parseScheduleDay(last); err != nil {
+				return nil, err
+			}
+		}
+		for weekday := start; ; weekday = weekday%len(weekdays) + 1 {
+			if slices.Contains(weekdayNumbers, weekday) {
+				return nil, fmt.Errorf("%s is listed more than once in %q", dayName(weekday), days)
+			}
+			weekdayNumbers = append(weekdayNumbers, weekday)
+			if weekday == end {
+				break
+			}
+		}
+	}
+	return weekdayNumbers, nil
+}
+
+// parseScheduleDay parses a day by its name or the first three letters of it.
+func parseScheduleDay(day string) (int, error) {
+	day = strings.ToLower(strings.TrimSpace(day))
+	for i, name := range weekdays {
+		if len(day) >= 3 && strings.HasPrefix(name, day) {
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown day %q, expected one of Sun, Mon, Tue, Wed, Thu, Fri and Sat", day)
+}
+
+// parseScheduleHours parses "closed", "24h" or time ranges such as "09:00-12:00,13:00-18:00".
+func parseScheduleHours(hours string) ([]scheduleSetting, error) {
+	switch strings.ToLower(hours) {
+	case "closed":
+		return []scheduleSetting{{typ: scheduleClosed}}, nil
+	case "24h", "24 hours":
+		return []scheduleSetting{{typ: scheduleAllDay}}, nil
+	}
+
+	var settings []scheduleSetting
+	type minutes struct{ from, to int }
+	var ranges []minutes
+	for _, part := range strings.Split(hours, ",") {
+		from, to, ok := strings.Cut(strings.TrimSpace(part), "-")
+		if !ok {
+			return nil, fmt.Errorf("%q must be closed, 24h or time ranges such as 09:00-18:00", hours)
+		}
+		fromMinutes, fromTime, err := parseScheduleTime(from)
+		if err != nil {
+			return nil, err
+		}
+		toMinutes, toTime, err := parseScheduleTime(to)
+		if err != nil {
+			return nil, err
+		}
+		if fromMinutes >= toMinutes {
+			return nil, fmt.Errorf("%s-%s must end after it starts, split the hours past midnight into the next day", fromTime, toTime)
+		}
+		for _, r := range ranges {
+			if fromMinutes < r.to && r.from < toMinutes {
+				return nil, fmt.Errorf("%s-%s overlaps another time range", fromTime, toTime)
+			}
+		}
+		ranges = append(ranges, minutes{fromMinutes, toMinutes})
+		settings = append(settings, scheduleSetting{typ: scheduleCustomized, from: fromTime, to: toTime})
+	}
+	return settings, nil
+}
+
+// parseScheduleTime parses a time such as 9:00 or 18:30 into the minutes since midnight and the HH:mm format of Zoom.
+func parseScheduleTime(value string) (int, string, error) {
+	value = strings.TrimSpace(value)
+	match := scheduleTimeRegexp.FindStringSubmatch(value)
+	if match == nil {
+		return 0, "", fmt.Errorf("invalid time %q, expected HH:mm such as 09:00", value)
+	}
+	hour, _ := strconv.Atoi(match[1])
+	minute, _ := strconv.Atoi(match[2])
+	if hour > 23 || minute > 59 {
+		return 0, "", fmt.Errorf("invalid time %q, expected a time from 00:00 to 23:59", value)
+	}
+	return hour*60 + minute, fmt.Sprintf("%02d:%02d", hour, minute), nil
+}
+
+func dayName(weekday int) string {
+	name := weekdays[weekday-1]
+	return strings.ToUpper(name[:1]) + name[1:3]
+}
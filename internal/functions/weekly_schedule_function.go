@@ -0,0 +1,86 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+)
+
+var _ function.Function = &weeklyScheduleFunction{}
+
+// weeklyScheduleSettingType is the type of an element of custom_hours.settings of zoom_phone_call_handling_business_hours.
+var weeklyScheduleSettingType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"weekday": types.Int32Type,
+		"type":    types.Int32Type,
+		"from":    types.StringType,
+		"to":      types.StringType,
+	},
+}
+
+func NewWeeklyScheduleFunction() function.Function {
+	return &weeklyScheduleFunction{}
+}
+
+type weeklyScheduleFunction struct{}
+
+func (f *weeklyScheduleFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "weekly_schedule"
+}
+
+func (f *weeklyScheduleFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a weekly schedule into business hours settings",
+		MarkdownDescription: "Parses a weekly schedule such as `Mon-Fri 09:00-18:00; Sat 10:00-14:00` into `custom_hours.settings` of `zoom_phone_call_handling_business_hours`, " +
+			"whose `custom_hours.type` must be `2`. Each entry separated by `;` or a new line is days followed by hours:\n\n" +
+			"  - Days are `Sun`, `Mon`, `Tue`, `Wed`, `Thu`, `Fri` and `Sat`, or their full names, separated by `,` or joined by `-` as a range, e.g. `Mon-Wed,Fri`.\n" +
+			"  - Hours are `closed`, `24h`, or time ranges in HH:mm separated by `,`, e.g. `09:00-12:00,13:00-18:00`. A range must end by `23:59` of its day.\n\n" +
+			"The days not in the schedule are closed. It fails when a day is in more than one entry, time ranges of a day overlap, or a time is invalid.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "schedule",
+				MarkdownDescription: "The weekly schedule.",
+			},
+		},
+		Return: function.SetReturn{
+			ElementType: weeklyScheduleSettingType,
+		},
+	}
+}
+
+func (f *weeklyScheduleFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var schedule string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &schedule))
+	if resp.Error != nil {
+		return
+	}
+
+	settings, err := parseWeeklySchedule(schedule)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	elements := make([]attr.Value, 0, len(settings))
+	for _, setting := range settings {
+		element, diags := types.ObjectValue(weeklyScheduleSettingType.AttrTypes, map[string]attr.Value{
+			"weekday": types.Int32Value(int32(setting.weekday)),
+			"type":    types.Int32Value(int32(setting.typ)),
+			"from":    lo.Ternary(setting.typ == scheduleCustomized, types.StringValue(setting.from), types.StringNull()),
+			"to":      lo.Ternary(setting.typ == scheduleCustomized, types.StringValue(setting.to), types.StringNull()),
+		})
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+		elements = append(elements, element)
+	}
+	if resp.Error != nil {
+		return
+	}
+	result, diags := types.SetValue(weeklyScheduleSettingType, elements)
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
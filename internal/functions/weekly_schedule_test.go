@@ -0,0 +1,86 @@
+package functions_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/functions"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestWeeklySchedule(t *testing.T) {
+	settingType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"weekday": types.Int32Type,
+		"type":    types.Int32Type,
+		"from":    types.StringType,
+		"to":      types.StringType,
+	}}
+	setting := func(weekday, typ int32, from, to string) attr.Value {
+		hours := map[string]attr.Value{"from": types.StringNull(), "to": types.StringNull()}
+		if typ == 2 {
+			hours = map[string]attr.Value{"from": types.StringValue(from), "to": types.StringValue(to)}
+		}
+		return types.ObjectValueMust(settingType.AttrTypes, map[string]attr.Value{
+			"weekday": types.Int32Value(weekday),
+			"type":    types.Int32Value(typ),
+			"from":    hours["from"],
+			"to":      hours["to"],
+		})
+	}
+
+	for _, tc := range []struct {
+		schedule string
+		want     []attr.Value
+		wantErr  string
+	}{
+		{
+			schedule: "Mon-Fri 09:00-18:00; Sat 10:00-14:00",
+			want: []attr.Value{
+				setting(1, 0, "", ""),
+				setting(2, 2, "09:00", "18:00"),
+				setting(3, 2, "09:00", "18:00"),
+				setting(4, 2, "09:00", "18:00"),
+				setting(5, 2, "09:00", "18:00"),
+				setting(6, 2, "09:00", "18:00"),
+				setting(7, 2, "10:00", "14:00"),
+			},
+		},
+		{
+			schedule: "monday, Wednesday 9:00-12:00, 13:00-17:30\nSat - Sun 24h\nFri closed",
+			want: []attr.Value{
+				setting(1, 1, "", ""),
+				setting(2, 2, "09:00", "12:00"),
+				setting(2, 2, "13:00", "17:30"),
+				setting(3, 0, "", ""),
+				setting(4, 2, "09:00", "12:00"),
+				setting(4, 2, "13:00", "17:30"),
+				setting(5, 0, "", ""),
+				setting(6, 0, "", ""),
+				setting(7, 1, "", ""),
+			},
+		},
+		{schedule: "Mon-Fri 09:00-18:00; Fri 19:00-20:00", wantErr: "Fri is in both"},
+		{schedule: "Mon 09:00-13:00,12:00-18:00", wantErr: "overlaps"},
+		{schedule: "Mon 22:00-02:00", wantErr: "must end after it starts"},
+		{schedule: "Mon 09:00-24:00", wantErr: "from 00:00 to 23:59"},
+		{schedule: "Mon 9am-5pm", wantErr: "invalid time"},
+		{schedule: "Mo 09:00-18:00", wantErr: "unknown day"},
+		{schedule: "Mon-Fri", wantErr: "must be days followed by hours"},
+	} {
+		got, err := run(functions.NewWeeklyScheduleFunction(), types.SetUnknown(settingType), types.StringValue(tc.schedule))
+		if tc.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("weekly_schedule(%q): expected error %q, got %v", tc.schedule, tc.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("weekly_schedule(%q): unexpected error %v", tc.schedule, err)
+			continue
+		}
+		if want := types.SetValueMust(settingType, tc.want); !got.Equal(want) {
+			t.Errorf("weekly_schedule(%q) = %s, want %s", tc.schedule, got, want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/zoomoauth"
+	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+)
+
+// authMethod is how the provider gets access tokens.
+type authMethod string
+
+const (
+	// authMethodAccountCredentials uses the account credentials grant of a Server-to-Server OAuth app.
+	authMethodAccountCredentials authMethod = "account_credentials"
+	// authMethodRefreshToken uses the refresh token grant of a General OAuth app, acting as the user who authorized it.
+	authMethodRefreshToken authMethod = "refresh_token"
+	// authMethodAccessToken uses a pre-issued access token as is.
+	authMethodAccessToken authMethod = "access_token"
+)
+
+type credentials struct {
+	method           authMethod
+	accountID        string
+	clientID         string
+	clientSecret     string
+	refreshToken     string
+	refreshTokenFile string
+	accessToken      string
+}
+
+// ConfigValidators makes the authentication methods mutually exclusive. The top-level account_id, client_id and
+// client_secret are the shorthand of the account credentials method, so they conflict with the other methods as well.
+func (p *ZoomProvider) ConfigValidators(_ context.Context) []provider.ConfigValidator {
+	validators := []provider.ConfigValidator{
+		providervalidator.Conflicting(path.MatchRoot("access_token"), path.MatchRoot("auth")),
+		providervalidator.Conflicting(
+			path.MatchRoot("auth").AtName("account_credentials"),
+			path.MatchRoot("auth").AtName("refresh_token"),
+		),
+	}
+	for _, method := range []string{"access_token", "auth"} {
+		for _, attr := range []string{"account_id", "client_id", "client_secret"} {
+			validators = append(validators, providervalidator.Conflicting(path.MatchRoot(method), path.MatchRoot(attr)))
+		}
+	}
+	return validators
+}
+
+// resolveCredentials picks the authentication method and its credentials. A method in the provider config takes
+// precedence over environment variables, and ZOOM_ACCESS_TOKEN takes precedence over the account credentials variables.
+func resolveCredentials(config zoomProviderModel) (credentials, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case config.Auth != nil && config.Auth.RefreshToken != nil:
+		block := config.Auth.RefreshToken
+		creds := credentials{
+			method:           authMethodRefreshToken,
+			clientID:         block.ClientID.ValueString(),
+			clientSecret:     block.ClientSecret.ValueString(),
+			refreshTokenFile: block.RefreshTokenFile.ValueString(),
+		}
+		b, err := os.ReadFile(creds.refreshTokenFile)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("auth").AtName("refresh_token").AtName("refresh_token_file"),
+				"Unable to read Zoom refresh token",
+				fmt.Sprintf("The provider cannot read the refresh token file. Error: %s", err.Error()),
+			)
+			return creds, diags
+		}
+		creds.refreshToken = strings.TrimSpace(string(b))
+		if creds.refreshToken == "" {
+			diags.AddAttributeError(
+				path.Root("auth").AtName("refresh_token").AtName("refresh_token_file"),
+				"Missing Zoom Refresh Token",
+				"The provider cannot create the Zoom API client as the refresh token file is empty. Write the refresh token of the General OAuth app to the file before the first run.",
+			)
+		}
+		return creds, diags
+
+	case config.Auth != nil && config.Auth.AccountCredentials != nil:
+		block := config.Auth.AccountCredentials
+		return credentials{
+			method:       authMethodAccountCredentials,
+			accountID:    block.AccountID.ValueString(),
+			clientID:     block.ClientID.ValueString(),
+			clientSecret: block.ClientSecret.ValueString(),
+		}, diags
+
+	case config.Auth != nil:
+		diags.AddAttributeError(
+			path.Root("auth"),
+			"Missing Zoom authentication method",
+			"The auth block must contain either an account_credentials or a refresh_token block.",
+		)
+		return credentials{}, diags
+
+	case !config.AccessToken.IsNull() && !config.AccessToken.IsUnknown(),
+		config.AccountID.IsNull() && config.ClientID.IsNull() && config.ClientSecret.IsNull() && os.Getenv("ZOOM_ACCESS_TOKEN") != "":
+		creds := credentials{
+			method: authMethodAccessToken,
+			accessToken: lo.TernaryF(config.AccessToken.IsNull() || config.AccessToken.IsUnknown(), func() string {
+				return os.Getenv("ZOOM_ACCESS_TOKEN")
+			}, func() string {
+				return config.AccessToken.ValueString()
+			}),
+		}
+		if creds.accessToken == "" {
+			diags.AddAttributeError(
+				path.Root("access_token"),
+				"Missing Zoom Access Token",
+				"The provider cannot create the Zoom API client as there is a missing or empty value for the Zoom Access Token. Please set the value in provider configuration or the ZOOM_ACCESS_TOKEN environment variable. If either is already set, ensure the value is not empty.",
+			)
+		}
+		return creds, diags
+	}
+
+	creds := credentials{
+		method:       authMethodAccountCredentials,
+		accountID:    stringOrEnv(config.AccountID, "ZOOM_ACCOUNT_ID"),
+		clientID:     stringOrEnv(config.ClientID, "ZOOM_CLIENT_ID"),
+		clientSecret: stringOrEnv(config.ClientSecret, "ZOOM_CLIENT_SECRET"),
+	}
+	if creds.accountID == "" {
+		diags.AddAttributeError(
+			path.Root("account_id"),
+			"Missing Zoom Account ID",
+			"The provider cannot create the Zoom API client as there is a missing or empty value for the Zoom Account ID. Please set the value in provider configuration or the ZOOM_ACCOUNT_ID environment variable. If either is already set, ensure the value is not empty.",
+		)
+	}
+	if creds.clientID == "" {
+		diags.AddAttributeError(
+			path.Root("client_id"),
+			"Missing Zoom Client ID",
+			"The provider cannot create the Zoom API client as there is a missing or empty value for the Zoom Client ID. Please set the value in provider configuration or the ZOOM_CLIENT_ID environment variable. If either is already set, ensure the value is not empty.",
+		)
+	}
+	if creds.clientSecret == "" {
+		diags.AddAttributeError(
+			path.Root("client_secret"),
+			"Missing Zoom Client Secret",
+			"The provider cannot create the Zoom API client as there is a missing or empty value for the Zoom Client Secret. Please set the value in provider configuration or the ZOOM_CLIENT_SECRET environment variable. If either is already set, ensure the value is not empty.",
+		)
+	}
+	return creds, diags
+}
+
+func stringOrEnv(value types.String, key string) string {
+	return lo.TernaryF(value.IsNull() || value.IsUnknown(), func() string {
+		return os.Getenv(key)
+	}, func() string {
+		return value.ValueString()
+	})
+}
+
+func (c credentials) tokenSource(client *zoomoauth.Client) *zoomoauth.TokenSource {
+	switch c.method {
+	case authMethodAccessToken:
+		return zoomoauth.NewStaticTokenSource(c.accessToken)
+	case authMethodRefreshToken:
+		return zoomoauth.NewRefreshTokenSource(client, c.clientID, c.clientSecret, c.refreshToken, func(refreshToken string) error {
+			return writeFileAtomically(c.refreshTokenFile, []byte(refreshToken+"\n"))
+		})
+	default:
+		return zoomoauth.NewTokenSource(client, c.accountID, c.clientID, c.clientSecret)
+	}
+}
+
+// writeFileAtomically replaces the file by renaming a temporary file, so that the refresh token is never lost
+// to a partially written file.
+func writeFileAtomically(name string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
@@ -0,0 +1,119 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// zoomTrackingIDHeader is the response header with the ID Zoom support asks for to trace a request.
+const zoomTrackingIDHeader = "x-zm-trackingid"
+
+// AuditLog appends a JSON line for each request that changes the account. It is safe for concurrent use,
+// as Terraform creates, updates and deletes resources in parallel.
+type AuditLog struct {
+	mu   sync.Mutex
+	file *os.File
+	now  func() time.Time
+}
+
+// AuditEntry is a line of the audit log.
+type AuditEntry struct {
+	Time          time.Time       `json:"time"`
+	ResourceType  string          `json:"resource_type,omitempty"`
+	OperationID   string          `json:"operation_id,omitempty"`
+	Method        string          `json:"method"`
+	Path          string          `json:"path"`
+	RequestBody   json.RawMessage `json:"request_body,omitempty"`
+	Status        int             `json:"status,omitempty"`
+	ZoomRequestID string          `json:"zoom_request_id,omitempty"`
+	Error         string          `json:"error,omitempty"`
+}
+
+// OpenAuditLog opens the file at path for appending, creating it when it does not exist.
+func OpenAuditLog(path string) (*AuditLog, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{file: file, now: time.Now}, nil
+}
+
+// Write appends the entry as a single write, so that the lines of concurrent writers are never interleaved.
+func (l *AuditLog) Write(entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.file.Write(line); err != nil {
+		return err
+	}
+	return l.file.Sync()
+}
+
+func (l *AuditLog) Close() error {
+	return l.file.Close()
+}
+
+// AuditLogRoundTripper records the requests other than GET and HEAD in the audit log with their outcome.
+type AuditLogRoundTripper struct {
+	ctx context.Context
+	rt  http.RoundTripper
+	log *AuditLog
+}
+
+func NewAuditLogRoundTripper(ctx context.Context, rt http.RoundTripper, log *AuditLog) http.RoundTripper {
+	return &AuditLogRoundTripper{ctx: ctx, rt: rt, log: log}
+}
+
+func (t AuditLogRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return t.rt.RoundTrip(req)
+	}
+
+	entry := AuditEntry{
+		Time:         t.log.now().UTC(),
+		ResourceType: ResourceTypeFromContext(req.Context()),
+		OperationID:  OperationIDFromContext(req.Context()),
+		Method:       req.Method,
+		Path:         req.URL.Path,
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("http failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		entry.RequestBody = RedactJSON(body)
+	}
+
+	resp, err := t.rt.RoundTrip(req)
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+		entry.ZoomRequestID = resp.Header.Get(zoomTrackingIDHeader)
+	}
+	// the request has been sent already, so failing it would only make Terraform lose track of the change
+	if writeErr := t.log.Write(entry); writeErr != nil {
+		tflog.Error(t.ctx, "failed to write the audit log", map[string]interface{}{
+			"method":      req.Method,
+			"request_uri": req.URL.RequestURI(),
+			"error":       writeErr.Error(),
+		})
+	}
+	return resp, err
+}
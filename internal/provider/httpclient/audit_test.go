@@ -0,0 +1,107 @@
+package httpclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestAuditLogRoundTripper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-zm-trackingid", "WEB_"+r.Method)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	auditLogPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := OpenAuditLog(auditLogPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer auditLog.Close()
+	client := &http.Client{Transport: NewAuditLogRoundTripper(context.Background(), http.DefaultTransport, auditLog)}
+
+	ctx, span := NewOperationTracerProvider(noop.NewTracerProvider()).Tracer("test").Start(WithResourceType(context.Background(), "zoom_phone_site"), "AddPhoneSite")
+	defer span.End()
+
+	const n = 20
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			body := fmt.Sprintf(`{"name":"site %d","emergency_address":{"pin":"1234"},"voicemail_pin":"5678"}`, i)
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v2/phone/sites", strings.NewReader(body))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_ = resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	resp, err := client.Get(server.URL + "/v2/phone/sites")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	f, err := os.Open(auditLogPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var lines int
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines++
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		if entry.ResourceType != "zoom_phone_site" || entry.OperationID != "AddPhoneSite" ||
+			entry.Method != http.MethodPost || entry.Path != "/v2/phone/sites" ||
+			entry.Status != http.StatusCreated || entry.ZoomRequestID != "WEB_POST" || entry.Time.IsZero() {
+			t.Fatalf("unexpected entry: %s", scanner.Text())
+		}
+		if strings.Contains(string(entry.RequestBody), "1234") || strings.Contains(string(entry.RequestBody), "5678") ||
+			!strings.Contains(string(entry.RequestBody), `"name":"site `) {
+			t.Fatalf("expected the request body to be redacted: %s", entry.RequestBody)
+		}
+	}
+	if lines != n {
+		t.Fatalf("expected %d lines for the POST requests only, got %d", n, lines)
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	for _, tc := range []struct {
+		body string
+		want string
+	}{
+		{`{"name":"a","password":"p","items":[{"pin":1}],"pinned":true}`, `{"items":[{"pin":"REDACTED"}],"name":"a","password":"REDACTED","pinned":true}`},
+		{`{"access_token":"t","client_secret":{"x":1}}`, `{"access_token":"REDACTED","client_secret":"REDACTED"}`},
+		{`name=a&password=p`, `"REDACTED"`},
+		{``, ``},
+	} {
+		if got := string(RedactJSON([]byte(tc.body))); got != tc.want {
+			t.Errorf("RedactJSON(%s) = %s, want %s", tc.body, got, tc.want)
+		}
+	}
+}
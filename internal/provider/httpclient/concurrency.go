@@ -0,0 +1,59 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ConcurrencyLimitRoundTripper limits the requests in flight to Zoom across all resources, as Terraform runs
+// as many operations at once as its -parallelism, and each of them may send several requests.
+// A request holds its slot until its response body is read to the end or closed.
+type ConcurrencyLimitRoundTripper struct {
+	rt  http.RoundTripper
+	sem chan struct{}
+}
+
+func NewConcurrencyLimitRoundTripper(rt http.RoundTripper, maxConcurrentRequests int) http.RoundTripper {
+	return &ConcurrencyLimitRoundTripper{rt: rt, sem: make(chan struct{}, maxConcurrentRequests)}
+}
+
+func (t ConcurrencyLimitRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, req.Context().Err()
+	}
+
+	resp, err := t.rt.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		<-t.sem
+		return resp, err
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: func() { <-t.sem }}
+	return resp, nil
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+// Read releases the slot at the end of the body too, as a body read to the end is not always closed.
+func (b *releasingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.once.Do(b.release)
+	}
+	return n, err
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
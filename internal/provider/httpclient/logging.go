@@ -5,83 +5,168 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+const binaryBodyOmitted = "<binary body omitted>"
+
+// LoggingConfig is how the requests and responses are written to the debug log.
+type LoggingConfig struct {
+	// RedactPII redacts the personal data in bodies, such as emails, phone numbers and emergency addresses.
+	// Credentials such as passwords, PINs and tokens are always redacted.
+	RedactPII bool
+	// RedactFields are the JSON keys redacted in addition, matched as a whole key or its last word.
+	RedactFields []string
+	// RedactHeaders are the headers redacted in addition to the ones with credentials.
+	RedactHeaders []string
+	// MaxBodySize is the number of bytes of a body logged at most. Bodies are not logged when it is 0.
+	MaxBodySize int
+}
+
+// DefaultLoggingConfig is used when the provider config has no logging block.
+var DefaultLoggingConfig = LoggingConfig{
+	RedactPII:   true,
+	MaxBodySize: 16 * 1024,
+}
+
+// LoggingRoundTripper logs each request and response with the sensitive values redacted.
+// Only the first MaxBodySize bytes of a body are read for the log, and binary bodies such as recordings are skipped.
 type LoggingRoundTripper struct {
-	ctx context.Context
-	rt  http.RoundTripper
+	ctx     context.Context
+	rt      http.RoundTripper
+	config  LoggingConfig
+	keys    []string
+	headers []string
 }
 
-func NewLoggingRoundTripper(ctx context.Context, rt http.RoundTripper) http.RoundTripper {
-	return &LoggingRoundTripper{ctx: ctx, rt: rt}
+func NewLoggingRoundTripper(ctx context.Context, rt http.RoundTripper, config LoggingConfig) http.RoundTripper {
+	keys := append([]string{}, sensitiveKeys...)
+	if config.RedactPII {
+		keys = append(keys, piiKeys...)
+	}
+	keys = append(keys, config.RedactFields...)
+	headers := append(append([]string{}, sensitiveHeaders...), config.RedactHeaders...)
+	return &LoggingRoundTripper{ctx: ctx, rt: rt, config: config, keys: keys, headers: headers}
 }
 
-func (t LoggingRoundTripper) RoundTrip(req *http.Request) (resp *http.Response, err error) {
-	if req != nil && req.Method != "GET" {
-		buf, _ := io.ReadAll(req.Body)
-		loggingBody := io.NopCloser(bytes.NewBuffer(buf))
-		rawBody := io.NopCloser(bytes.NewBuffer(buf))
-		req.Body = rawBody
-		bodyBytes, err := io.ReadAll(loggingBody)
-		if err != nil {
-			return nil, fmt.Errorf("http failed to read response body: %w", err)
+func (t LoggingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	fields := map[string]interface{}{
+		"method":      req.Method,
+		"request_uri": req.URL.RequestURI(),
+		"headers":     redactHeaders(req.Header, t.headers),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		if err := t.addRequestBody(req, fields); err != nil {
+			return nil, fmt.Errorf("http failed to read request body: %w", err)
 		}
-		bodyString := string(bodyBytes)
-		tflog.Debug(t.ctx,
-			fmt.Sprintf("http request"),
-			map[string]interface{}{
-				"method":      req.Method,
-				"request_uri": req.URL.RequestURI(),
-				"body":        bodyString,
-			},
-		)
+	}
+	tflog.Debug(t.ctx, "http request", fields)
+
+	start := time.Now()
+	resp, err := t.rt.RoundTrip(req)
+	duration := time.Since(start)
+	if err != nil {
+		tflog.Info(t.ctx, fmt.Sprintf("http err %s", err), map[string]interface{}{
+			"method":      req.Method,
+			"request_uri": req.URL.RequestURI(),
+			"duration_ms": duration.Milliseconds(),
+		})
+		return nil, err
 	}
 
-	resp, err = t.rt.RoundTrip(req)
+	fields = map[string]interface{}{
+		"method":      req.Method,
+		"request_uri": req.URL.RequestURI(),
+		"headers":     redactHeaders(resp.Header, t.headers),
+		"duration_ms": duration.Milliseconds(),
+	}
+	if err := t.addResponseBody(resp, fields); err != nil {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("http failed to read response body: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		tflog.Info(t.ctx, fmt.Sprintf("http response %d", resp.StatusCode), fields)
+	} else {
+		tflog.Debug(t.ctx, fmt.Sprintf("http response %d", resp.StatusCode), fields)
+	}
+	return resp, nil
+}
+
+// addRequestBody reads the whole request body, which the provider builds in memory anyway, and restores it.
+func (t LoggingRoundTripper) addRequestBody(req *http.Request, fields map[string]interface{}) error {
+	if t.config.MaxBodySize <= 0 {
+		return nil
+	}
+	if contentType := req.Header.Get("Content-Type"); !isTextContent(contentType) {
+		fields["body"] = binaryBodyOmitted
+		fields["content_type"] = contentType
+		return nil
+	}
+	buf, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
 	if err != nil {
-		tflog.Info(
-			t.ctx,
-			fmt.Sprintf("http err %s", err),
-			map[string]interface{}{
-				"method":      req.Method,
-				"request_uri": req.URL.RequestURI(),
-			},
-		)
+		return err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(buf))
+	t.addBody(buf, len(buf) > t.config.MaxBodySize, fields)
+	return nil
+}
+
+// addResponseBody reads the beginning of the response body, and puts it back in front of the rest.
+func (t LoggingRoundTripper) addResponseBody(resp *http.Response, fields map[string]interface{}) error {
+	if resp.Body == nil || resp.Body == http.NoBody || t.config.MaxBodySize <= 0 {
+		return nil
+	}
+	if contentType := resp.Header.Get("Content-Type"); !isTextContent(contentType) {
+		fields["body"] = binaryBodyOmitted
+		fields["content_type"] = contentType
+		return nil
+	}
+	head, err := io.ReadAll(io.LimitReader(resp.Body, int64(t.config.MaxBodySize)+1))
+	if err != nil {
+		return err
+	}
+	resp.Body = &prefixedBody{Reader: io.MultiReader(bytes.NewReader(head), resp.Body), Closer: resp.Body}
+	t.addBody(head, len(head) > t.config.MaxBodySize, fields)
+	return nil
+}
+
+func (t LoggingRoundTripper) addBody(body []byte, truncated bool, fields map[string]interface{}) {
+	if len(body) == 0 {
 		return
 	}
-	if resp != nil {
-		buf, _ := io.ReadAll(resp.Body)
-		loggingBody := io.NopCloser(bytes.NewBuffer(buf))
-		rawBody := io.NopCloser(bytes.NewBuffer(buf))
-		resp.Body = rawBody
+	if truncated {
+		body = body[:t.config.MaxBodySize]
+		fields["body_truncated"] = true
+	}
+	if text, ok := redactJSONPrefix(body, t.keys); ok {
+		fields["body"] = text
+	} else {
+		// not JSON, e.g. an error page of a proxy
+		fields["body"] = string(body)
+	}
+}
 
-		bodyBytes, err := io.ReadAll(loggingBody)
-		if err != nil {
-			return nil, fmt.Errorf("http failed to read response body: %w", err)
-		}
-		bodyString := string(bodyBytes)
-		if resp.StatusCode >= 400 {
-			tflog.Info(t.ctx,
-				fmt.Sprintf("http response %d", resp.StatusCode),
-				map[string]interface{}{
-					"method":      req.Method,
-					"request_uri": req.URL.RequestURI(),
-					"body":        bodyString,
-				},
-			)
-		} else {
-			tflog.Debug(t.ctx,
-				fmt.Sprintf("http response %d", resp.StatusCode),
-				map[string]interface{}{
-					"method":      req.Method,
-					"request_uri": req.URL.RequestURI(),
-					"body":        bodyString,
-				},
-			)
-		}
+type prefixedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// isTextContent reports whether a body of the content type can be written to the log.
+func isTextContent(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
 	}
-	return
+	return strings.HasPrefix(mediaType, "text/") ||
+		mediaType == "application/json" ||
+		strings.HasSuffix(mediaType, "+json")
 }
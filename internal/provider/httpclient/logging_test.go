@@ -0,0 +1,113 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLoggingRoundTripper(t *testing.T) {
+	recording := bytes.Repeat([]byte{0xff}, 1024)
+	list := `{"users":[` + strings.Repeat(`{"id":"u1","email":"someone@example.com"},`, 100) + `{"id":"u2"}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret")
+		switch r.URL.Path {
+		case "/v2/phone/recording":
+			w.Header().Set("Content-Type", "audio/mpeg")
+			_, _ = w.Write(recording)
+		case "/v2/phone/users":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, list)
+		default:
+			w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(body)
+		}
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	client := &http.Client{Transport: NewLoggingRoundTripper(ctx, http.DefaultTransport, LoggingConfig{
+		RedactPII:     true,
+		RedactFields:  []string{"name"},
+		RedactHeaders: []string{"X-Custom"},
+		MaxBodySize:   256,
+	})}
+
+	body := `{"site_code":1,"display_name":"Tokyo","emergency_address":{"address_line1":"1-1"},"voicemail_pin":"1234","phone_number":"+81300000000"}`
+	req, err := http.NewRequest(http.MethodPatch, server.URL+"/v2/phone/users/u1", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Custom", "custom")
+	for _, tc := range []struct {
+		req      *http.Request
+		wantBody []byte
+	}{
+		{req, []byte(body)},
+		{mustNewRequest(t, server.URL+"/v2/phone/users"), []byte(list)},
+		{mustNewRequest(t, server.URL+"/v2/phone/recording"), recording},
+	} {
+		resp, err := client.Do(tc.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, tc.wantBody) {
+			t.Errorf("the body of %s was altered: %q", tc.req.URL.Path, got)
+		}
+	}
+
+	logs := output.String()
+	for _, leaked := range []string{"Bearer token", "custom", "session=secret", "Tokyo", "1-1", "1234", "+81300000000", "someone@example.com"} {
+		if strings.Contains(logs, leaked) {
+			t.Errorf("%q is in the logs: %s", leaked, logs)
+		}
+	}
+	for _, logged := range []string{`\"site_code\":1`, `\"emergency_address\":\"REDACTED\"`, `"body_truncated":true`, `"content_type":"audio/mpeg"`, `"duration_ms"`, `"method":"GET"`} {
+		if !strings.Contains(logs, logged) {
+			t.Errorf("%q is not in the logs: %s", logged, logs)
+		}
+	}
+}
+
+func TestRedactJSONPrefix(t *testing.T) {
+	keys := []string{"pin", "email"}
+	for _, tc := range []struct {
+		body   string
+		want   string
+		wantOK bool
+	}{
+		{`{"a":1,"pin":"1234","b":[true,null,"x"]}`, `{"a":1,"pin":"REDACTED","b":[true,null,"x"]}`, true},
+		{`{"voicemail_pin":{"x":[1,{"y":2}]},"c":"d"}`, `{"voicemail_pin":"REDACTED","c":"d"}`, true},
+		{`[{"email":"a@example.com"},{"email":"b@exa`, `[{"email":"REDACTED"},{"email"`, true},
+		{`{"name":"long na`, `{"name"`, true},
+		{`<html>Bad Gateway</html>`, ``, false},
+	} {
+		got, ok := redactJSONPrefix([]byte(tc.body), keys)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("redactJSONPrefix(%s) = %s, %v; want %s, %v", tc.body, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func mustNewRequest(t *testing.T, url string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
@@ -0,0 +1,62 @@
+package httpclient
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type operationIDKey struct{}
+type resourceTypeKey struct{}
+type terraformMethodKey struct{}
+
+// OperationIDFromContext returns the ID of the Zoom API operation a request is sent for, e.g. AddPhoneSite, or an empty string.
+func OperationIDFromContext(ctx context.Context) string {
+	operationID, _ := ctx.Value(operationIDKey{}).(string)
+	return operationID
+}
+
+// WithResourceType returns a context whose requests are made on behalf of the resource type, e.g. zoom_phone_site.
+func WithResourceType(ctx context.Context, resourceType string) context.Context {
+	return context.WithValue(ctx, resourceTypeKey{}, resourceType)
+}
+
+// ResourceTypeFromContext returns the resource type set by WithResourceType, or an empty string.
+func ResourceTypeFromContext(ctx context.Context) string {
+	resourceType, _ := ctx.Value(resourceTypeKey{}).(string)
+	return resourceType
+}
+
+// WithTerraformMethod returns a context whose requests are made by the Terraform method of a resource, e.g. Create.
+func WithTerraformMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, terraformMethodKey{}, method)
+}
+
+// TerraformMethodFromContext returns the Terraform method set by WithTerraformMethod, or an empty string.
+func TerraformMethodFromContext(ctx context.Context) string {
+	method, _ := ctx.Value(terraformMethodKey{}).(string)
+	return method
+}
+
+// NewOperationTracerProvider wraps tp to put the operation ID into the context of each request.
+// The ogen generated clients start a span named after the operation before building the request,
+// which is the only place the round trippers can learn the operation from.
+func NewOperationTracerProvider(tp trace.TracerProvider) trace.TracerProvider {
+	return operationTracerProvider{TracerProvider: tp}
+}
+
+type operationTracerProvider struct {
+	trace.TracerProvider
+}
+
+func (p operationTracerProvider) Tracer(name string, options ...trace.TracerOption) trace.Tracer {
+	return operationTracer{Tracer: p.TracerProvider.Tracer(name, options...)}
+}
+
+type operationTracer struct {
+	trace.Tracer
+}
+
+func (t operationTracer) Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return t.Tracer.Start(context.WithValue(ctx, operationIDKey{}, spanName), spanName, opts...)
+}
@@ -0,0 +1,261 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Zoom rate limit categories returned in the X-RateLimit-Category header.
+// See also: https://developers.zoom.us/docs/api/rest/rate-limits/
+const (
+	RateLimitCategoryLight             = "light"
+	RateLimitCategoryMedium            = "medium"
+	RateLimitCategoryHeavy             = "heavy"
+	RateLimitCategoryResourceIntensive = "resource-intensive"
+)
+
+// DefaultRateLimits is the number of requests per second allowed for each rate limit category.
+// These are the limits of the Pro plan, which is the lowest plan that can use the Zoom Phone API.
+var DefaultRateLimits = map[string]float64{
+	RateLimitCategoryLight:             30,
+	RateLimitCategoryMedium:            20,
+	RateLimitCategoryHeavy:             10,
+	RateLimitCategoryResourceIntensive: 10.0 / 60,
+}
+
+const (
+	rateLimitMaxRetries = 5
+	rateLimitMaxWait    = 60 * time.Second
+	// rateLimitLowRemaining is the remaining daily quota ratio from which a warning is logged.
+	rateLimitLowRemaining = 0.1
+)
+
+// DailyRateLimitError is returned when Zoom rejects a request because the daily rate limit is exhausted.
+// Retrying such a request is pointless until the limit resets.
+type DailyRateLimitError struct {
+	Category string
+	ResetAt  time.Time
+}
+
+func (e *DailyRateLimitError) Error() string {
+	category := e.Category
+	if category == "" {
+		category = "unknown"
+	}
+	resetAt := "at the start of the next day (UTC)"
+	if !e.ResetAt.IsZero() {
+		resetAt = "at " + e.ResetAt.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprintf(
+		"the daily Zoom API rate limit for %s requests has been exhausted and resets %s. "+
+			"Requests of this category fail until then, so run terraform again after the reset or reduce the number of managed objects",
+		category, resetAt,
+	)
+}
+
+type RateLimitRoundTripper struct {
+	ctx context.Context
+	rt  http.RoundTripper
+
+	mu         sync.Mutex
+	categories map[string]string    // route -> rate limit category
+	pacers     map[string]time.Time // rate limit category -> earliest time of the next request
+	limits     map[string]float64
+	warned     map[string]bool
+}
+
+func NewRateLimitRoundTripper(ctx context.Context, rt http.RoundTripper) http.RoundTripper {
+	return &RateLimitRoundTripper{
+		ctx:        ctx,
+		rt:         rt,
+		categories: map[string]string{},
+		pacers:     map[string]time.Time{},
+		limits:     DefaultRateLimits,
+		warned:     map[string]bool{},
+	}
+}
+
+func (t *RateLimitRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	route := rateLimitRoute(req)
+	for attempt := 0; ; attempt++ {
+		if err := t.pace(req, route); err != nil {
+			return nil, err
+		}
+
+		r := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(req.Context())
+			r.Body = body
+		}
+
+		resp, err := t.rt.RoundTrip(r)
+		if err != nil {
+			return resp, err
+		}
+		t.observe(route, resp)
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return resp, nil
+		}
+
+		category := strings.ToLower(resp.Header.Get("X-RateLimit-Category"))
+		retryAfter, resetAt := parseRetryAfter(resp.Header.Get("Retry-After"))
+		if isDailyRateLimit(resp) || retryAfter > rateLimitMaxWait {
+			drainBody(resp)
+			return nil, &DailyRateLimitError{
+				Category: category,
+				ResetAt:  resetAt,
+			}
+		}
+		if attempt >= rateLimitMaxRetries || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+			return resp, nil
+		}
+
+		if retryAfter <= 0 {
+			retryAfter = time.Duration(1<<attempt) * time.Second
+		}
+		tflog.Info(t.ctx, "zoom api rate limit exceeded, waiting before retry", map[string]interface{}{
+			"method":      req.Method,
+			"request_uri": req.URL.RequestURI(),
+			"category":    category,
+			"wait":        retryAfter.String(),
+			"attempt":     attempt + 1,
+		})
+		drainBody(resp)
+		recordRateLimitWait(req, category, retryAfter)
+		t.delay(category, retryAfter)
+	}
+}
+
+// pace blocks until the rate limit category of the route allows the next request.
+func (t *RateLimitRoundTripper) pace(req *http.Request, route string) error {
+	t.mu.Lock()
+	category, ok := t.categories[route]
+	if !ok {
+		t.mu.Unlock()
+		return nil
+	}
+	now := time.Now()
+	next := t.pacers[category]
+	if next.Before(now) {
+		next = now
+	}
+	if limit, ok := t.limits[category]; ok && limit > 0 {
+		t.pacers[category] = next.Add(time.Duration(float64(time.Second) / limit))
+	}
+	t.mu.Unlock()
+
+	wait := time.Until(next)
+	if wait <= 0 {
+		return nil
+	}
+	recordRateLimitWait(req, category, wait)
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// delay postpones all requests of the category by d.
+func (t *RateLimitRoundTripper) delay(category string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	next := time.Now().Add(d)
+	if next.After(t.pacers[category]) {
+		t.pacers[category] = next
+	}
+}
+
+// observe learns the rate limit category of the route and watches the remaining quota.
+func (t *RateLimitRoundTripper) observe(route string, resp *http.Response) {
+	category := strings.ToLower(resp.Header.Get("X-RateLimit-Category"))
+	if category == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.categories[route] = category
+
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	if remaining <= 0 && !isDailyRateLimit(resp) {
+		// the per second quota is used up, so wait for the next window.
+		next := time.Now().Add(time.Second)
+		if next.After(t.pacers[category]) {
+			t.pacers[category] = next
+		}
+		return
+	}
+	limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	if err != nil || limit <= 0 || !isDailyRateLimit(resp) {
+		return
+	}
+	if float64(remaining)/float64(limit) < rateLimitLowRemaining && !t.warned[category] {
+		t.warned[category] = true
+		tflog.Warn(t.ctx, "zoom api daily rate limit is almost exhausted", map[string]interface{}{
+			"category":  category,
+			"remaining": remaining,
+			"limit":     limit,
+		})
+	}
+}
+
+func isDailyRateLimit(resp *http.Response) bool {
+	return strings.Contains(strings.ToLower(resp.Header.Get("X-RateLimit-Type")), "daily")
+}
+
+// parseRetryAfter supports both delay seconds and a date, which Zoom uses for the daily rate limit.
+func parseRetryAfter(value string) (time.Duration, time.Time) {
+	if value == "" {
+		return 0, time.Time{}
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, time.Time{}
+	}
+	for _, layout := range []string{time.RFC3339, http.TimeFormat} {
+		if at, err := time.Parse(layout, value); err == nil {
+			return time.Until(at), at
+		}
+	}
+	return 0, time.Time{}
+}
+
+// rateLimitRouteStaticSegment matches the path segments that are not resource ids, including the API version such as v2.
+var rateLimitRouteStaticSegment = regexp.MustCompile(`^(?:[a-z_]+|v\d+)$`)
+
+// rateLimitRoute returns the method and the path whose resource ids are masked,
+// because Zoom applies the same rate limit category to every object of an endpoint.
+func rateLimitRoute(req *http.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+	for i, segment := range segments {
+		if segment != "" && !rateLimitRouteStaticSegment.MatchString(segment) {
+			segments[i] = "{}"
+		}
+	}
+	return req.Method + " " + strings.Join(segments, "/")
+}
+
+func drainBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
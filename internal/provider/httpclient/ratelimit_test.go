@@ -0,0 +1,81 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRateLimitRoundTripperRetriesQPSLimit(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Category", "Light")
+		if calls.Add(1) == 1 {
+			w.Header().Set("X-RateLimit-Type", "QPS")
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRateLimitRoundTripper(context.Background(), http.DefaultTransport)}
+	resp, err := client.Get(server.URL + "/v2/phone/sites/CAUYYsOXRH-xp4hd3cd5A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %d", resp.StatusCode)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls.Load())
+	}
+}
+
+func TestRateLimitRoundTripperDailyLimit(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("X-RateLimit-Category", "Heavy")
+		w.Header().Set("X-RateLimit-Type", "Daily-limit")
+		w.Header().Set("Retry-After", "2099-01-01T00:00:00Z")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRateLimitRoundTripper(context.Background(), http.DefaultTransport)}
+	_, err := client.Get(server.URL + "/v2/phone/sites")
+
+	var dailyErr *DailyRateLimitError
+	if !errors.As(err, &dailyErr) {
+		t.Fatalf("expected DailyRateLimitError, got %v", err)
+	}
+	if dailyErr.Category != RateLimitCategoryHeavy {
+		t.Fatalf("unexpected category: %s", dailyErr.Category)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("daily limit should not be retried, got %d calls", calls.Load())
+	}
+}
+
+func TestRateLimitRoute(t *testing.T) {
+	for _, tc := range []struct {
+		url  string
+		want string
+	}{
+		{"https://api.zoom.us/v2/phone/call_queues/8f71O6rWT8KFUGQmJIFAdQ/members", "GET /v2/phone/call_queues/{}/members"},
+		{"https://api.zoom.us/v2/phone/users/abc123def", "GET /v2/phone/users/{}"},
+		{"https://api.zoom.us/v2/phone/extension/12345/call_handling/settings", "GET /v2/phone/extension/{}/call_handling/settings"},
+	} {
+		req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+		if got := rateLimitRoute(req); got != tc.want {
+			t.Errorf("unexpected route of %s: %s", tc.url, got)
+		}
+	}
+}
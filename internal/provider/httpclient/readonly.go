@@ -0,0 +1,32 @@
+package httpclient
+
+import (
+	"net/http"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+)
+
+// ReadOnlyRoundTripper rejects every request but GET and HEAD, so that plan, refresh and import
+// can be run against a production account without any chance of modifying it.
+type ReadOnlyRoundTripper struct {
+	rt http.RoundTripper
+}
+
+func NewReadOnlyRoundTripper(rt http.RoundTripper) http.RoundTripper {
+	return &ReadOnlyRoundTripper{rt: rt}
+}
+
+func (t ReadOnlyRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, &util.ReadOnlyError{
+			ResourceType: ResourceTypeFromContext(req.Context()),
+			Operation:    TerraformMethodFromContext(req.Context()),
+			Method:       req.Method,
+			Path:         req.URL.Path,
+		}
+	}
+	return t.rt.RoundTrip(req)
+}
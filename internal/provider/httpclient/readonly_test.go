@@ -0,0 +1,51 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+)
+
+func TestReadOnlyRoundTripper(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewReadOnlyRoundTripper(http.DefaultTransport)}
+	resp, err := client.Get(server.URL + "/v2/phone/sites")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	for _, method := range []string{http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete} {
+		ctx := WithTerraformMethod(WithResourceType(context.Background(), "zoom_phone_site"), "Update")
+		req, err := http.NewRequestWithContext(ctx, method, server.URL+"/v2/phone/sites/abc", strings.NewReader("{}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = client.Do(req)
+		var readOnlyErr *util.ReadOnlyError
+		if !errors.As(err, &readOnlyErr) {
+			t.Fatalf("%s: expected ReadOnlyError, got %v", method, err)
+		}
+		if readOnlyErr.Method != method || readOnlyErr.Path != "/v2/phone/sites/abc" {
+			t.Fatalf("%s: unexpected error: %v", method, readOnlyErr)
+		}
+		if want := "/v2/phone/sites/abc to update zoom_phone_site"; !strings.HasSuffix(readOnlyErr.Error(), want) {
+			t.Fatalf("%s: expected the error to end with %q, got %q", method, want, readOnlyErr.Error())
+		}
+	}
+
+	if len(calls) != 1 || calls[0] != "GET /v2/phone/sites" {
+		t.Fatalf("expected only the GET request to be sent, got %v", calls)
+	}
+}
@@ -0,0 +1,192 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+// sensitiveKeys are the JSON keys whose values must not be written to logs, matched as a whole key or its last word,
+// e.g. pin and voicemail_pin.
+var sensitiveKeys = []string{"password", "passcode", "pin", "secret", "token"}
+
+// piiKeys are the JSON keys of the personal data in the Zoom API, matched as sensitiveKeys are.
+// An address is redacted as a whole, e.g. the emergency_address of a user.
+var piiKeys = []string{"email", "phone_number", "phone_numbers", "first_name", "last_name", "address"}
+
+// sensitiveHeaders are the headers whose values must not be written to logs.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// RedactJSON returns the JSON body with the values of sensitive keys replaced. A body which is not JSON is replaced
+// as a whole, as it cannot be told what it holds.
+func RedactJSON(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		b, _ := json.Marshal(redacted)
+		return b
+	}
+	b, err := json.Marshal(redactValue(v))
+	if err != nil {
+		b, _ = json.Marshal(redacted)
+	}
+	return b
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if isSensitiveKey(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(value)
+			}
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+	}
+	return v
+}
+
+func isSensitiveKey(key string) bool {
+	return matchesKey(sensitiveKeys, key)
+}
+
+func matchesKey(keys []string, key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range keys {
+		k = strings.ToLower(k)
+		if key == k || strings.HasSuffix(key, "_"+k) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactJSONPrefix redacts the values of the keys in the JSON body, which may be cut off at any byte.
+// Unlike RedactJSON, it walks the tokens, so that the keys are kept in order and a truncated body is redacted
+// up to its last complete token. It reports false when the body is not JSON at all.
+func redactJSONPrefix(body []byte, keys []string) (string, bool) {
+	type frame struct {
+		object    bool
+		expectKey bool
+		count     int
+	}
+	var (
+		out   bytes.Buffer
+		stack []*frame
+		skip  int  // the depth of the redacted value being skipped
+		drop  bool // whether the next value is redacted
+	)
+	separate := func() {
+		if len(stack) == 0 {
+			return
+		}
+		top := stack[len(stack)-1]
+		switch {
+		case top.object && !top.expectKey:
+			out.WriteByte(':')
+		case top.count > 0:
+			out.WriteByte(',')
+		}
+	}
+	complete := func() {
+		if len(stack) == 0 {
+			return
+		}
+		top := stack[len(stack)-1]
+		top.count++
+		if top.object {
+			top.expectKey = true
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			// the end of the body, which may be cut off in the middle of a token
+			return out.String(), out.Len() > 0
+		}
+
+		if skip > 0 {
+			if d, ok := tok.(json.Delim); ok {
+				if d == '{' || d == '[' {
+					skip++
+				} else {
+					skip--
+				}
+			}
+			if skip == 0 {
+				complete()
+			}
+			continue
+		}
+
+		if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+			out.WriteRune(rune(d))
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			complete()
+			continue
+		}
+
+		if len(stack) > 0 && stack[len(stack)-1].object && stack[len(stack)-1].expectKey {
+			top := stack[len(stack)-1]
+			key, _ := tok.(string)
+			if top.count > 0 {
+				out.WriteByte(',')
+			}
+			b, _ := json.Marshal(key)
+			out.Write(b)
+			top.expectKey = false
+			drop = matchesKey(keys, key)
+			continue
+		}
+
+		separate()
+		if drop {
+			drop = false
+			out.WriteString(`"` + redacted + `"`)
+			if d, ok := tok.(json.Delim); ok && (d == '{' || d == '[') {
+				skip = 1
+				continue
+			}
+			complete()
+			continue
+		}
+		if d, ok := tok.(json.Delim); ok {
+			out.WriteRune(rune(d))
+			stack = append(stack, &frame{object: d == '{', expectKey: d == '{'})
+			continue
+		}
+		b, _ := json.Marshal(tok)
+		out.Write(b)
+		complete()
+	}
+}
+
+// redactHeaders flattens the headers for a log line with the values of the sensitive ones replaced.
+func redactHeaders(header http.Header, names []string) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		headers[name] = strings.Join(values, ", ")
+		for _, sensitive := range names {
+			if strings.EqualFold(name, sensitive) {
+				headers[name] = redacted
+				break
+			}
+		}
+	}
+	return headers
+}
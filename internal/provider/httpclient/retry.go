@@ -0,0 +1,93 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// RetryConfig is how failed requests are retried. 429 responses are not covered, as RateLimitRoundTripper waits for them.
+type RetryConfig struct {
+	// MaxAttempts is the number of attempts of a request including the first one.
+	MaxAttempts int
+	// MinWait and MaxWait bound the exponential backoff between attempts.
+	MinWait time.Duration
+	MaxWait time.Duration
+	// StatusCodes are the response status codes retried.
+	StatusCodes []int
+}
+
+// DefaultRetryConfig matches the defaults of retryablehttp, except that only the 5xx status codes of transient
+// failures are retried instead of all of them but 501.
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts: 5,
+	MinWait:     1 * time.Second,
+	MaxWait:     30 * time.Second,
+	StatusCodes: []int{
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+}
+
+// Apply sets the retry settings and the retry policy on the client.
+func (c RetryConfig) Apply(client *retryablehttp.Client) {
+	client.RetryMax = c.MaxAttempts - 1
+	client.RetryWaitMin = c.MinWait
+	client.RetryWaitMax = c.MaxWait
+	client.CheckRetry = NewRetryPolicy(c.StatusCodes)
+}
+
+// NewRetryPolicy returns the retryablehttp.CheckRetry used together with RateLimitRoundTripper.
+// Requests are retried on network errors and the statusCodes, but a non-idempotent request, i.e. POST, is retried only
+// when it provably never reached Zoom, so that a retried create cannot create a duplicate.
+func NewRetryPolicy(statusCodes []int) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		var dailyErr *DailyRateLimitError
+		if errors.As(err, &dailyErr) {
+			return false, err
+		}
+
+		if err != nil {
+			var urlErr *url.Error
+			if !errors.As(err, &urlErr) || isIdempotent(strings.ToUpper(urlErr.Op)) || neverSent(err) {
+				return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+			}
+			return false, &util.AmbiguousRequestError{Method: strings.ToUpper(urlErr.Op), URL: urlErr.URL, Err: urlErr.Err}
+		}
+
+		if !slices.Contains(statusCodes, resp.StatusCode) {
+			return false, nil
+		}
+		// Zoom or a gateway in front of it has seen a non-idempotent request, so its error response is returned as is.
+		return resp.Request == nil || isIdempotent(resp.Request.Method), nil
+	}
+}
+
+// isIdempotent reports whether sending the request twice has the same effect as once.
+// PATCH is included, as the PATCH endpoints of Zoom set the given values rather than apply a change to the current ones.
+func isIdempotent(method string) bool {
+	return method != http.MethodPost
+}
+
+// neverSent reports whether err happened before any byte of the request was sent, such as a failed DNS lookup or dial.
+func neverSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && (opErr.Op == "dial" || opErr.Op == "proxyconnect")
+}
@@ -0,0 +1,126 @@
+package httpclient
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+func newTestRetryClient(statusCodes []int) *http.Client {
+	client := retryablehttp.NewClient()
+	client.Logger = nil
+	RetryConfig{
+		MaxAttempts: 3,
+		MinWait:     time.Millisecond,
+		MaxWait:     time.Millisecond,
+		StatusCodes: statusCodes,
+	}.Apply(client)
+	return client.StandardClient()
+}
+
+func TestRetryPolicyStatusCodes(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		method      string
+		statusCodes []int
+		wantCalls   int32
+	}{
+		{http.MethodGet, []int{http.StatusServiceUnavailable}, 3},
+		{http.MethodPatch, []int{http.StatusServiceUnavailable}, 3},
+		{http.MethodGet, []int{http.StatusBadGateway}, 1},
+		// Zoom has seen the create, so retrying it might create a duplicate
+		{http.MethodPost, []int{http.StatusServiceUnavailable}, 1},
+	} {
+		calls.Store(0)
+		req, err := http.NewRequest(tc.method, server.URL+"/v2/phone/call_queues", strings.NewReader("{}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := newTestRetryClient(tc.statusCodes).Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		if calls.Load() != tc.wantCalls {
+			t.Errorf("%s with %v: expected %d calls, got %d", tc.method, tc.statusCodes, tc.wantCalls, calls.Load())
+		}
+	}
+}
+
+func TestRetryPolicyAmbiguousCreate(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		// drop the connection after the request has been received
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_ = conn.Close()
+	}))
+	defer server.Close()
+
+	_, err := newTestRetryClient(DefaultRetryConfig.StatusCodes).Post(server.URL+"/v2/phone/sites", "application/json", strings.NewReader("{}"))
+	var ambiguousErr *util.AmbiguousRequestError
+	if !errors.As(err, &ambiguousErr) {
+		t.Fatalf("expected AmbiguousRequestError, got %v", err)
+	}
+	if ambiguousErr.Method != http.MethodPost {
+		t.Fatalf("unexpected method: %s", ambiguousErr.Method)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected the create not to be retried, got %d calls", calls.Load())
+	}
+
+	calls.Store(0)
+	resp, err := newTestRetryClient(DefaultRetryConfig.StatusCodes).Get(server.URL + "/v2/phone/sites")
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected the read to be retried, got %d calls", calls.Load())
+	}
+}
+
+func TestRetryPolicyCreateNeverSent(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close() // nothing listens, so dialing fails before the request is sent
+
+	var attempts int
+	client := retryablehttp.NewClient()
+	client.Logger = nil
+	client.RequestLogHook = func(_ retryablehttp.Logger, _ *http.Request, _ int) {
+		attempts++
+	}
+	RetryConfig{MaxAttempts: 3, MinWait: time.Millisecond, MaxWait: time.Millisecond}.Apply(client)
+
+	_, err = client.StandardClient().Post("http://"+addr+"/v2/phone/sites", "application/json", strings.NewReader("{}"))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	var ambiguousErr *util.AmbiguousRequestError
+	if errors.As(err, &ambiguousErr) {
+		t.Fatalf("expected a dial error not to be ambiguous, got %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected the create to be retried as it never reached the server, got %d attempts", attempts)
+	}
+}
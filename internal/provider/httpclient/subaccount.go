@@ -0,0 +1,64 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type subAccountIDKey struct{}
+
+// WithSubAccountID returns a context whose requests are sent to the sub-account, overriding the provider's sub-account.
+func WithSubAccountID(ctx context.Context, subAccountID string) context.Context {
+	return context.WithValue(ctx, subAccountIDKey{}, subAccountID)
+}
+
+// SubAccountIDFromContext returns the sub-account set by WithSubAccountID, or an empty string.
+func SubAccountIDFromContext(ctx context.Context) string {
+	subAccountID, _ := ctx.Value(subAccountIDKey{}).(string)
+	return subAccountID
+}
+
+// SubAccountRoundTripper sends requests of a master account to one of its sub-accounts,
+// by rewriting the paths under the API base URL, e.g. /v2/phone/sites, to /v2/accounts/{accountId}/phone/sites.
+type SubAccountRoundTripper struct {
+	ctx          context.Context
+	rt           http.RoundTripper
+	basePath     string
+	subAccountID string
+}
+
+// NewSubAccountRoundTripper creates a round tripper for the API at apiURL. subAccountID is used
+// for requests without a sub-account in their context, and requests are sent as is when both are empty.
+func NewSubAccountRoundTripper(ctx context.Context, rt http.RoundTripper, apiURL string, subAccountID string) (http.RoundTripper, error) {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, err
+	}
+	return &SubAccountRoundTripper{
+		ctx:          ctx,
+		rt:           rt,
+		basePath:     strings.TrimSuffix(u.Path, "/"),
+		subAccountID: subAccountID,
+	}, nil
+}
+
+func (t SubAccountRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	subAccountID := SubAccountIDFromContext(req.Context())
+	if subAccountID == "" {
+		subAccountID = t.subAccountID
+	}
+	rest, ok := strings.CutPrefix(req.URL.Path, t.basePath+"/")
+	if subAccountID == "" || !ok || strings.HasPrefix(rest, "accounts/") {
+		return t.rt.RoundTrip(req)
+	}
+
+	// a round tripper must not modify the request, so send a copy
+	req = req.Clone(req.Context())
+	req.URL.Path = t.basePath + "/accounts/" + subAccountID + "/" + rest
+	if rawRest, ok := strings.CutPrefix(req.URL.RawPath, t.basePath+"/"); ok {
+		req.URL.RawPath = t.basePath + "/accounts/" + url.PathEscape(subAccountID) + "/" + rawRest
+	}
+	return t.rt.RoundTrip(req)
+}
@@ -0,0 +1,50 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSubAccountRoundTripper(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	rt, err := NewSubAccountRoundTripper(context.Background(), http.DefaultTransport, server.URL+"/v2", "default-sub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: rt}
+
+	for _, tc := range []struct {
+		ctx  context.Context
+		path string
+		want string
+	}{
+		{context.Background(), "/v2/phone/sites", "/v2/accounts/default-sub/phone/sites"},
+		{WithSubAccountID(context.Background(), "other-sub"), "/v2/phone/users/jane%40example.com", "/v2/accounts/other-sub/phone/users/jane%40example.com"},
+		{context.Background(), "/v2/accounts/another-sub/users", "/v2/accounts/another-sub/users"},
+	} {
+		paths = nil
+		req, err := http.NewRequestWithContext(tc.ctx, http.MethodGet, server.URL+tc.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if len(paths) != 1 || paths[0] != tc.want {
+			t.Errorf("%s: expected the request to be sent to %s, got %v", tc.path, tc.want, paths)
+		}
+		if req.URL.EscapedPath() != tc.path {
+			t.Errorf("expected the original request not to be modified, got %s", req.URL.EscapedPath())
+		}
+	}
+}
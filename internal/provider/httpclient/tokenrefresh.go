@@ -0,0 +1,74 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/zoomoauth"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type TokenRefreshRoundTripper struct {
+	ctx         context.Context
+	rt          http.RoundTripper
+	tokenSource *zoomoauth.TokenSource
+}
+
+func NewTokenRefreshRoundTripper(ctx context.Context, rt http.RoundTripper, tokenSource *zoomoauth.TokenSource) http.RoundTripper {
+	return &TokenRefreshRoundTripper{ctx: ctx, rt: rt, tokenSource: tokenSource}
+}
+
+func (t TokenRefreshRoundTripper) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	resp, err = t.rt.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return
+	}
+
+	// the access token might be revoked or expired earlier than expected,
+	// so refresh it and send the request once again.
+	staleToken, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return // the request body cannot be sent twice
+	}
+
+	// The response holds a slot of ConcurrencyLimitRoundTripper until its body is closed, and the refresh sends a request
+	// through it as well, so the body is read and closed first and the response keeps a copy in case it is returned.
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	token, refreshErr := t.tokenSource.Refresh(req.Context(), staleToken)
+	if refreshErr != nil {
+		tflog.Warn(t.ctx, "failed to refresh access token", map[string]interface{}{
+			"method":      req.Method,
+			"request_uri": req.URL.RequestURI(),
+			"error":       refreshErr.Error(),
+		})
+		return
+	}
+
+	retryReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return
+		}
+		retryReq.Body = body
+	}
+	retryReq.Header.Set("Authorization", "Bearer "+token)
+
+	tflog.Debug(t.ctx, "retrying http request with refreshed access token", map[string]interface{}{
+		"method":      req.Method,
+		"request_uri": req.URL.RequestURI(),
+	})
+	return t.rt.RoundTrip(retryReq)
+}
@@ -0,0 +1,65 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/zoomoauth"
+)
+
+func TestTokenRefreshRoundTripperWithConcurrencyLimit(t *testing.T) {
+	var issued atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/oauth/token" {
+			_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":3600}`, issued.Add(1))
+			return
+		}
+		// the first token is revoked
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":124,"message":"Invalid access token."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// the token requests share the slot with the API requests, as they do in the provider
+	limited := NewConcurrencyLimitRoundTripper(http.DefaultTransport, 1)
+	oauthClient, err := zoomoauth.NewClient(zoomoauth.WithHTTPClient(&http.Client{Transport: limited}), zoomoauth.WithTokenURL(server.URL+"/oauth/token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenSource := zoomoauth.NewTokenSource(oauthClient, "account", "client", "secret")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	token, err := tokenSource.Token(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v2/phone/sites", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	client := &http.Client{Transport: NewTokenRefreshRoundTripper(ctx, limited, tokenSource)}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("expected the request to be retried with a refreshed token, got %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if issued.Load() != 2 {
+		t.Fatalf("expected the token to be refreshed once, got %d tokens", issued.Load())
+	}
+}
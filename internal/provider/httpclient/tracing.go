@@ -0,0 +1,70 @@
+package httpclient
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The attributes added to the span of a Zoom API operation besides the ones of the ogen generated clients.
+const (
+	retryCountKey    = attribute.Key("zoom.retry_count")
+	rateLimitWaitKey = attribute.Key("zoom.rate_limit.wait")
+	rateLimitCatKey  = attribute.Key("zoom.rate_limit.category")
+)
+
+// TracingRoundTripper records the outcome of a request on the span of its operation, which the ogen generated
+// clients start but leave without the response status.
+type TracingRoundTripper struct {
+	rt http.RoundTripper
+}
+
+func NewTracingRoundTripper(rt http.RoundTripper) http.RoundTripper {
+	return &TracingRoundTripper{rt: rt}
+}
+
+func (t TracingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	span := trace.SpanFromContext(req.Context())
+	resp, err := t.rt.RoundTrip(req)
+	if !span.IsRecording() {
+		return resp, err
+	}
+
+	span.SetAttributes(semconv.URLPath(req.URL.Path))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if trackingID := resp.Header.Get(zoomTrackingIDHeader); trackingID != "" {
+		span.SetAttributes(attribute.String("zoom.tracking_id", trackingID))
+	}
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	return resp, nil
+}
+
+// RecordRetry is a retryablehttp.RequestLogHook counting the retries of a request on the span of its operation.
+func RecordRetry(_ retryablehttp.Logger, req *http.Request, attempt int) {
+	if attempt == 0 {
+		return
+	}
+	span := trace.SpanFromContext(req.Context())
+	span.SetAttributes(retryCountKey.Int(attempt))
+	span.AddEvent("retry", trace.WithAttributes(retryCountKey.Int(attempt)))
+}
+
+// recordRateLimitWait adds the time a request waits for the rate limit of its category to the span of its operation.
+func recordRateLimitWait(req *http.Request, category string, wait time.Duration) {
+	trace.SpanFromContext(req.Context()).AddEvent("rate limit wait", trace.WithAttributes(
+		rateLimitCatKey.String(category),
+		rateLimitWaitKey.String(wait.String()),
+	))
+}
@@ -0,0 +1,76 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingRoundTripper(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("x-zm-trackingid", "WEB_1")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := tracerProvider.Tracer("test").Start(context.Background(), "GetSite")
+
+	retryableClient := retryablehttp.NewClient()
+	retryableClient.Logger = nil
+	RetryConfig{
+		MaxAttempts: 3,
+		MinWait:     time.Millisecond,
+		MaxWait:     time.Millisecond,
+		StatusCodes: []int{http.StatusServiceUnavailable},
+	}.Apply(retryableClient)
+	retryableClient.RequestLogHook = RecordRetry
+	client := &http.Client{Transport: NewTracingRoundTripper(retryableClient.StandardClient().Transport)}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v2/phone/sites/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range spans[0].Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	if got := attrs["http.response.status_code"].AsInt64(); got != http.StatusNotFound {
+		t.Errorf("unexpected status code: %d", got)
+	}
+	if got := attrs[retryCountKey].AsInt64(); got != 1 {
+		t.Errorf("unexpected retry count: %d", got)
+	}
+	if got := attrs["zoom.tracking_id"].AsString(); got != "WEB_1" {
+		t.Errorf("unexpected tracking id: %s", got)
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("expected the span to be an error, got %v", spans[0].Status())
+	}
+}
@@ -0,0 +1,54 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// TransportConfig is how the provider connects to Zoom, e.g. through a corporate proxy with TLS interception.
+type TransportConfig struct {
+	// ProxyURL is the proxy for all requests. When it is nil, the HTTPS_PROXY and NO_PROXY environment variables apply.
+	ProxyURL *url.URL
+	// CABundle is PEM encoded CA certificates to trust in addition to the system roots.
+	CABundle []byte
+	// ClientCertificate and ClientKey are a PEM encoded key pair presented to servers that require mutual TLS.
+	ClientCertificate []byte
+	ClientKey         []byte
+}
+
+// NewTransport creates the transport under every other round tripper, so that both the OAuth and the API requests
+// go through the same proxy and TLS settings.
+func NewTransport(config TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.ProxyURL != nil {
+		transport.Proxy = http.ProxyURL(config.ProxyURL)
+	}
+	if len(config.CABundle) == 0 && len(config.ClientCertificate) == 0 && len(config.ClientKey) == 0 {
+		return transport, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(config.CABundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(config.CABundle) {
+			return nil, errors.New("the CA bundle contains no PEM encoded certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(config.ClientCertificate) > 0 || len(config.ClientKey) > 0 {
+		cert, err := tls.X509KeyPair(config.ClientCertificate, config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
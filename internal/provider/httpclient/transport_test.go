@@ -0,0 +1,71 @@
+package httpclient
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestNewTransportCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport, err := NewTransport(TransportConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&http.Client{Transport: transport}).Get(server.URL); err == nil {
+		t.Fatal("expected the self-signed certificate to be rejected without the CA bundle")
+	}
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	transport, err = NewTransport(TransportConfig{CABundle: caBundle})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	if _, err := NewTransport(TransportConfig{CABundle: []byte("not a certificate")}); err == nil {
+		t.Fatal("expected an error for a CA bundle without certificates")
+	}
+	if _, err := NewTransport(TransportConfig{ClientCertificate: caBundle}); err == nil {
+		t.Fatal("expected an error for a client certificate without a key")
+	}
+}
+
+func TestNewTransportProxyURL(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String()+" "+r.Header.Get("Proxy-Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxyURL.User = url.UserPassword("user", "password")
+	transport, err := NewTransport(TransportConfig{ProxyURL: proxyURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get("http://api.zoom.example/v2/phone/sites")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	want := "http://api.zoom.example/v2/phone/sites Basic dXNlcjpwYXNzd29yZA=="
+	if len(proxied) != 1 || proxied[0] != want {
+		t.Fatalf("expected the request to be sent through the proxy as %q, got %v", want, proxied)
+	}
+}
@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/httpclient"
+)
+
+// resolveLoggingConfig overrides the defaults by the values set in the logging block.
+func resolveLoggingConfig(config zoomProviderModel) httpclient.LoggingConfig {
+	loggingConfig := httpclient.DefaultLoggingConfig
+	block := config.Logging
+	if block == nil {
+		return loggingConfig
+	}
+
+	if !block.RedactPII.IsNull() && !block.RedactPII.IsUnknown() {
+		loggingConfig.RedactPII = block.RedactPII.ValueBool()
+	}
+	for _, field := range block.RedactFields {
+		loggingConfig.RedactFields = append(loggingConfig.RedactFields, field.ValueString())
+	}
+	for _, header := range block.RedactHeaders {
+		loggingConfig.RedactHeaders = append(loggingConfig.RedactHeaders, header.ValueString())
+	}
+	if !block.MaxBodySize.IsNull() && !block.MaxBodySize.IsUnknown() {
+		loggingConfig.MaxBodySize = int(block.MaxBodySize.ValueInt64())
+	}
+	return loggingConfig
+}
@@ -3,7 +3,52 @@ package provider
 import "github.com/hashicorp/terraform-plugin-framework/types"
 
 type zoomProviderModel struct {
+	AccountID             types.String         `tfsdk:"account_id"`
+	ClientID              types.String         `tfsdk:"client_id"`
+	ClientSecret          types.String         `tfsdk:"client_secret"`
+	AccessToken           types.String         `tfsdk:"access_token"`
+	Auth                  *zoomProviderAuth    `tfsdk:"auth"`
+	APIURL                types.String         `tfsdk:"api_url"`
+	OAuthURL              types.String         `tfsdk:"oauth_url"`
+	SubAccountID          types.String         `tfsdk:"sub_account_id"`
+	ProxyURL              types.String         `tfsdk:"proxy_url"`
+	CABundle              types.String         `tfsdk:"ca_bundle"`
+	ClientCertificate     types.String         `tfsdk:"client_certificate"`
+	ClientKey             types.String         `tfsdk:"client_key"`
+	ReadOnly              types.Bool           `tfsdk:"read_only"`
+	AuditLogPath          types.String         `tfsdk:"audit_log_path"`
+	MaxConcurrentRequests types.Int64          `tfsdk:"max_concurrent_requests"`
+	Retry                 *zoomProviderRetry   `tfsdk:"retry"`
+	Logging               *zoomProviderLogging `tfsdk:"logging"`
+}
+
+type zoomProviderAuth struct {
+	AccountCredentials *zoomProviderAccountCredentials `tfsdk:"account_credentials"`
+	RefreshToken       *zoomProviderRefreshToken       `tfsdk:"refresh_token"`
+}
+
+type zoomProviderAccountCredentials struct {
 	AccountID    types.String `tfsdk:"account_id"`
 	ClientID     types.String `tfsdk:"client_id"`
 	ClientSecret types.String `tfsdk:"client_secret"`
 }
+
+type zoomProviderRefreshToken struct {
+	ClientID         types.String `tfsdk:"client_id"`
+	ClientSecret     types.String `tfsdk:"client_secret"`
+	RefreshTokenFile types.String `tfsdk:"refresh_token_file"`
+}
+
+type zoomProviderRetry struct {
+	MaxAttempts          types.Int64   `tfsdk:"max_attempts"`
+	MinWait              types.String  `tfsdk:"min_wait"`
+	MaxWait              types.String  `tfsdk:"max_wait"`
+	RetryableStatusCodes []types.Int64 `tfsdk:"retryable_status_codes"`
+}
+
+type zoomProviderLogging struct {
+	RedactPII     types.Bool     `tfsdk:"redact_pii"`
+	RedactFields  []types.String `tfsdk:"redact_fields"`
+	RedactHeaders []types.String `tfsdk:"redact_headers"`
+	MaxBodySize   types.Int64    `tfsdk:"max_body_size"`
+}
@@ -1 +1,164 @@
 package provider_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/httpclient"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestProviderAuthMethodsAreMutuallyExclusive(t *testing.T) {
+	ctx := context.Background()
+	p := provider.New("test")()
+	server, err := providerserver.NewProtocol6WithError(p)()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schemaResp fwprovider.SchemaResponse
+	p.Schema(ctx, fwprovider.SchemaRequest{}, &schemaResp)
+	configType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	authType := configType.AttributeTypes["auth"].(tftypes.Object)
+
+	str := func(v string) tftypes.Value { return tftypes.NewValue(tftypes.String, v) }
+	block := func(typ tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+		for name, attrType := range typ.AttributeTypes {
+			if _, ok := values[name]; !ok {
+				values[name] = tftypes.NewValue(attrType, nil)
+			}
+		}
+		return tftypes.NewValue(typ, values)
+	}
+	accountCredentials := block(authType.AttributeTypes["account_credentials"].(tftypes.Object), map[string]tftypes.Value{
+		"account_id": str("account"), "client_id": str("client"), "client_secret": str("secret"),
+	})
+	refreshToken := block(authType.AttributeTypes["refresh_token"].(tftypes.Object), map[string]tftypes.Value{
+		"client_id": str("client"), "client_secret": str("secret"), "refresh_token_file": str("refresh_token"),
+	})
+
+	for _, tc := range []struct {
+		name    string
+		config  map[string]tftypes.Value
+		wantErr bool
+	}{
+		{"account credentials shorthand", map[string]tftypes.Value{"account_id": str("account"), "client_id": str("client")}, false},
+		{"access token", map[string]tftypes.Value{"access_token": str("token")}, false},
+		{"auth block", map[string]tftypes.Value{"auth": block(authType, map[string]tftypes.Value{"refresh_token": refreshToken})}, false},
+		{"access token and shorthand", map[string]tftypes.Value{"access_token": str("token"), "client_secret": str("secret")}, true},
+		{"access token and auth block", map[string]tftypes.Value{"access_token": str("token"), "auth": block(authType, map[string]tftypes.Value{"account_credentials": accountCredentials})}, true},
+		{"auth block and shorthand", map[string]tftypes.Value{"account_id": str("account"), "auth": block(authType, map[string]tftypes.Value{"refresh_token": refreshToken})}, true},
+		{"incomplete auth block", map[string]tftypes.Value{"auth": block(authType, map[string]tftypes.Value{"account_credentials": block(authType.AttributeTypes["account_credentials"].(tftypes.Object), map[string]tftypes.Value{"account_id": str("account")})})}, true},
+		{"refresh token block without file", map[string]tftypes.Value{"auth": block(authType, map[string]tftypes.Value{"refresh_token": block(authType.AttributeTypes["refresh_token"].(tftypes.Object), map[string]tftypes.Value{"client_id": str("client"), "client_secret": str("secret")})})}, true},
+		{"two methods in auth block", map[string]tftypes.Value{"auth": block(authType, map[string]tftypes.Value{"account_credentials": accountCredentials, "refresh_token": refreshToken})}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := tfprotov6.NewDynamicValue(configType, block(configType, tc.config))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := server.ValidateProviderConfig(ctx, &tfprotov6.ValidateProviderConfigRequest{Config: &config})
+			if err != nil {
+				t.Fatal(err)
+			}
+			hasErr := false
+			for _, d := range resp.Diagnostics {
+				hasErr = hasErr || d.Severity == tfprotov6.DiagnosticSeverityError
+			}
+			if hasErr != tc.wantErr {
+				t.Fatalf("expected error %v, got diagnostics %v", tc.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestProviderLogsAndAuditsSubAccountPath(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	var paths []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer api.Close()
+
+	p := provider.New("test")().(*provider.ZoomProvider)
+	server, err := providerserver.NewProtocol6WithError(p)()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schemaResp fwprovider.SchemaResponse
+	p.Schema(ctx, fwprovider.SchemaRequest{}, &schemaResp)
+	configType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	auditLogPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	values := map[string]tftypes.Value{
+		"access_token":   tftypes.NewValue(tftypes.String, "token"),
+		"api_url":        tftypes.NewValue(tftypes.String, api.URL+"/v2"),
+		"sub_account_id": tftypes.NewValue(tftypes.String, "sub1"),
+		"audit_log_path": tftypes.NewValue(tftypes.String, auditLogPath),
+	}
+	for name, attrType := range configType.AttributeTypes {
+		if _, ok := values[name]; !ok {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+	config, err := tfprotov6.NewDynamicValue(configType, tftypes.NewValue(configType, values))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) > 0 {
+		t.Fatalf("unexpected diagnostics %v", resp.Diagnostics)
+	}
+
+	// the second request is sent to the sub-account of the resource, which overrides the provider's one
+	if err := p.ProviderData.PhoneClient.DeleteACallQueue(ctx, zoomphone.DeleteACallQueueParams{CallQueueId: "cq1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.ProviderData.PhoneClient.DeleteACallQueue(httpclient.WithSubAccountID(ctx, "sub2"), zoomphone.DeleteACallQueueParams{CallQueueId: "cq2"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/v2/accounts/sub1/phone/call_queues/cq1", "/v2/accounts/sub2/phone/call_queues/cq2"}
+	if !slices.Equal(paths, want) {
+		t.Fatalf("expected the requests to be sent to %v, got %v", want, paths)
+	}
+	for _, path := range want {
+		if !strings.Contains(output.String(), `"request_uri":"`+path+`"`) {
+			t.Fatalf("expected the logs to show the request to %s, got %s", path, output.String())
+		}
+	}
+
+	b, err := os.ReadFile(auditLogPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines in the audit log, got %q", len(want), b)
+	}
+	for i, line := range lines {
+		var entry httpclient.AuditEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid line %q: %v", line, err)
+		}
+		if entry.Method != http.MethodDelete || entry.Path != want[i] || entry.Status != http.StatusNoContent {
+			t.Fatalf("expected the audit log to record DELETE %s, got %s", want[i], line)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/httpclient"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// resolveRetryConfig overrides the defaults by the values set in the retry block.
+func resolveRetryConfig(config zoomProviderModel) (httpclient.RetryConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	retryConfig := httpclient.DefaultRetryConfig
+	block := config.Retry
+	if block == nil {
+		return retryConfig, diags
+	}
+
+	if !block.MaxAttempts.IsNull() && !block.MaxAttempts.IsUnknown() {
+		retryConfig.MaxAttempts = int(block.MaxAttempts.ValueInt64())
+	}
+	// the durations are validated by the schema
+	if !block.MinWait.IsNull() && !block.MinWait.IsUnknown() {
+		retryConfig.MinWait, _ = time.ParseDuration(block.MinWait.ValueString())
+	}
+	if !block.MaxWait.IsNull() && !block.MaxWait.IsUnknown() {
+		retryConfig.MaxWait, _ = time.ParseDuration(block.MaxWait.ValueString())
+	}
+	if block.RetryableStatusCodes != nil {
+		retryConfig.StatusCodes = make([]int, 0, len(block.RetryableStatusCodes))
+		for _, code := range block.RetryableStatusCodes {
+			retryConfig.StatusCodes = append(retryConfig.StatusCodes, int(code.ValueInt64()))
+		}
+	}
+
+	if retryConfig.MinWait > retryConfig.MaxWait {
+		diags.AddAttributeError(
+			path.Root("retry").AtName("min_wait"),
+			"Invalid retry wait",
+			"min_wait must not be longer than max_wait.",
+		)
+	}
+	return retryConfig, diags
+}
@@ -0,0 +1,12 @@
+package shared
+
+import (
+	"context"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/httpclient"
+)
+
+// WithResourceType returns a context whose Zoom API requests are recorded in the audit log as made by the resource type.
+func WithResourceType(ctx context.Context, resourceType string) context.Context {
+	return httpclient.WithResourceType(ctx, resourceType)
+}
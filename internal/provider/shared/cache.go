@@ -0,0 +1,228 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/httpclient"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/samber/lo"
+)
+
+// Cache holds account-wide lists that many resources look up, such as phone users for email to extension resolution.
+// Each list is fetched at most once per provider instance, i.e. per Terraform run, until a write invalidates it.
+// The lists are kept per sub-account, as the sub-account of a request is chosen by its context.
+type Cache struct {
+	client *zoomphone.Client
+
+	mu       sync.Mutex
+	accounts map[string]*accountCache
+}
+
+type accountCache struct {
+	phoneUsers cachedList[zoomphone.ListPhoneUsersOKUsersItem]
+	sites      cachedList[zoomphone.ListPhoneSitesOKSitesItem]
+	callQueues cachedList[zoomphone.ListCallQueuesOKCallQueuesItem]
+
+	sharedLineGroups  cachedList[zoomphone.ListSharedLineGroupsOKSharedLineGroupsItem]
+	autoReceptionists cachedList[zoomphone.ListAutoReceptionistsOKAutoReceptionistsItem]
+}
+
+func NewCache(client *zoomphone.Client) *Cache {
+	return &Cache{
+		client:   client,
+		accounts: map[string]*accountCache{},
+	}
+}
+
+// account returns the lists of the sub-account of ctx. The empty key is the account the provider is configured for.
+func (c *Cache) account(ctx context.Context) *accountCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := httpclient.SubAccountIDFromContext(ctx)
+	if _, ok := c.accounts[key]; !ok {
+		c.accounts[key] = &accountCache{}
+	}
+	return c.accounts[key]
+}
+
+// each calls f with the lists of every sub-account, e.g. to invalidate them after a write.
+// Writes are rare compared to reads, so invalidating the other sub-accounts too keeps the write paths simple.
+func (c *Cache) each(f func(*accountCache)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, account := range c.accounts {
+		f(account)
+	}
+}
+
+// PhoneUsers returns all phone users of the account.
+func (c *Cache) PhoneUsers(ctx context.Context) ([]zoomphone.ListPhoneUsersOKUsersItem, error) {
+	return c.account(ctx).phoneUsers.get(ctx, func(ctx context.Context) ([]zoomphone.ListPhoneUsersOKUsersItem, error) {
+		users, err := util.CollectAll(util.Paginate(ctx, 100, func(ctx context.Context, pageSize int, nextPageToken string) ([]zoomphone.ListPhoneUsersOKUsersItem, string, error) {
+			res, err := c.client.ListPhoneUsers(ctx, zoomphone.ListPhoneUsersParams{
+				NextPageToken: util.ToPhoneOptPageToken(nextPageToken),
+				PageSize:      zoomphone.NewOptInt(pageSize), // Max 100
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			return res.Users, res.NextPageToken.Value, nil
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("error listing phone users: %w", err)
+		}
+		// Ensure uniqueness by using user ID, as duplicate data may occasionally be retrieved.
+		return lo.UniqBy(users, func(item zoomphone.ListPhoneUsersOKUsersItem) string {
+			return item.ID.Value
+		}), nil
+	})
+}
+
+// Sites returns all phone sites of the account.
+func (c *Cache) Sites(ctx context.Context) ([]zoomphone.ListPhoneSitesOKSitesItem, error) {
+	return c.account(ctx).sites.get(ctx, func(ctx context.Context) ([]zoomphone.ListPhoneSitesOKSitesItem, error) {
+		sites, err := util.CollectAll(util.Paginate(ctx, 300, func(ctx context.Context, pageSize int, nextPageToken string) ([]zoomphone.ListPhoneSitesOKSitesItem, string, error) {
+			res, err := c.client.ListPhoneSites(ctx, zoomphone.ListPhoneSitesParams{
+				NextPageToken: util.ToPhoneOptPageToken(nextPageToken),
+				PageSize:      zoomphone.NewOptInt(pageSize), // max 300
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			return res.Sites, res.NextPageToken.Value, nil
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read sites: %w", err)
+		}
+		return sites, nil
+	})
+}
+
+// CallQueues returns all call queues of the account.
+func (c *Cache) CallQueues(ctx context.Context) ([]zoomphone.ListCallQueuesOKCallQueuesItem, error) {
+	return c.account(ctx).callQueues.get(ctx, func(ctx context.Context) ([]zoomphone.ListCallQueuesOKCallQueuesItem, error) {
+		callQueues, err := util.CollectAll(util.Paginate(ctx, 100, func(ctx context.Context, pageSize int, nextPageToken string) ([]zoomphone.ListCallQueuesOKCallQueuesItem, string, error) {
+			res, err := c.client.ListCallQueues(ctx, zoomphone.ListCallQueuesParams{
+				NextPageToken: util.ToPhoneOptPageToken(nextPageToken),
+				PageSize:      zoomphone.NewOptInt(pageSize), // max 100
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			return res.CallQueues, res.NextPageToken.Value, nil
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read call queues: %w", err)
+		}
+		return callQueues, nil
+	})
+}
+
+// SharedLineGroups returns all shared line groups of the account.
+func (c *Cache) SharedLineGroups(ctx context.Context) ([]zoomphone.ListSharedLineGroupsOKSharedLineGroupsItem, error) {
+	return c.account(ctx).sharedLineGroups.get(ctx, func(ctx context.Context) ([]zoomphone.ListSharedLineGroupsOKSharedLineGroupsItem, error) {
+		sharedLineGroups, err := util.CollectAll(util.Paginate(ctx, 100, func(ctx context.Context, pageSize int, nextPageToken string) ([]zoomphone.ListSharedLineGroupsOKSharedLineGroupsItem, string, error) {
+			res, err := c.client.ListSharedLineGroups(ctx, zoomphone.ListSharedLineGroupsParams{
+				NextPageToken: util.ToPhoneOptPageToken(nextPageToken),
+				PageSize:      zoomphone.NewOptInt(pageSize), // max 100
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			return res.SharedLineGroups, res.NextPageToken.Value, nil
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read shared line groups: %w", err)
+		}
+		return sharedLineGroups, nil
+	})
+}
+
+// AutoReceptionists returns all auto receptionists of the account, including the main auto receptionist of each site.
+func (c *Cache) AutoReceptionists(ctx context.Context) ([]zoomphone.ListAutoReceptionistsOKAutoReceptionistsItem, error) {
+	return c.account(ctx).autoReceptionists.get(ctx, func(ctx context.Context) ([]zoomphone.ListAutoReceptionistsOKAutoReceptionistsItem, error) {
+		autoReceptionists, err := util.CollectAll(util.Paginate(ctx, 100, func(ctx context.Context, pageSize int, nextPageToken string) ([]zoomphone.ListAutoReceptionistsOKAutoReceptionistsItem, string, error) {
+			res, err := c.client.ListAutoReceptionists(ctx, zoomphone.ListAutoReceptionistsParams{
+				NextPageToken: util.ToPhoneOptPageToken(nextPageToken),
+				PageSize:      zoomphone.NewOptInt(pageSize), // max 100
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			return res.AutoReceptionists, res.NextPageToken.Value, nil
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read auto receptionists: %w", err)
+		}
+		return autoReceptionists, nil
+	})
+}
+
+// InvalidatePhoneUsers must be called after a write that changes phone users, their phone numbers or calling plans.
+func (c *Cache) InvalidatePhoneUsers() {
+	c.each(func(account *accountCache) {
+		account.phoneUsers.invalidate()
+	})
+}
+
+// InvalidateSites must be called after a write that changes sites.
+func (c *Cache) InvalidateSites() {
+	c.each(func(account *accountCache) {
+		account.sites.invalidate()
+	})
+}
+
+// InvalidateCallQueues must be called after a write that changes call queues or their phone numbers.
+func (c *Cache) InvalidateCallQueues() {
+	c.each(func(account *accountCache) {
+		account.callQueues.invalidate()
+	})
+}
+
+// InvalidateSharedLineGroups must be called after a write that changes shared line groups.
+func (c *Cache) InvalidateSharedLineGroups() {
+	c.each(func(account *accountCache) {
+		account.sharedLineGroups.invalidate()
+	})
+}
+
+// InvalidateAutoReceptionists must be called after a write that changes auto receptionists, including a site's one.
+func (c *Cache) InvalidateAutoReceptionists() {
+	c.each(func(account *accountCache) {
+		account.autoReceptionists.invalidate()
+	})
+}
+
+// cachedList is a list fetched on first use. Concurrent callers wait for the single fetch in flight.
+type cachedList[T any] struct {
+	mu      sync.Mutex
+	items   []T
+	fetched bool
+}
+
+func (l *cachedList[T]) get(ctx context.Context, fetch func(ctx context.Context) ([]T, error)) ([]T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.fetched {
+		return l.items, nil
+	}
+	items, err := fetch(ctx)
+	if err != nil {
+		return nil, err // failures are not cached, so the next caller retries
+	}
+	l.items, l.fetched = items, true
+	return items, nil
+}
+
+func (l *cachedList[T]) invalidate() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.items, l.fetched = nil, false
+}
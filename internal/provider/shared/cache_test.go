@@ -0,0 +1,151 @@
+package shared_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance/fakezoom"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/httpclient"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+)
+
+type staticToken struct{}
+
+func (staticToken) OpenapiAuthorization(_ context.Context, _ string) (zoomphone.OpenapiAuthorization, error) {
+	return zoomphone.OpenapiAuthorization{}, nil
+}
+
+func (staticToken) OpenapiOAuth(_ context.Context, _ string) (zoomphone.OpenapiOAuth, error) {
+	return zoomphone.OpenapiOAuth{Token: fakezoom.AccessToken}, nil
+}
+
+type countingTransport struct {
+	calls atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestCachePhoneUsers(t *testing.T) {
+	ctx := context.Background()
+	server := fakezoom.NewServer()
+	defer server.Close()
+	for _, email := range []string{"a@example.com", "b@example.com"} {
+		server.AddUser(fakezoom.User{Email: email, Phone: true})
+	}
+
+	transport := &countingTransport{}
+	client, err := zoomphone.NewClient(server.APIURL(), staticToken{}, zoomphone.WithClient(&http.Client{Transport: transport}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := shared.NewCache(client)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			users, err := cache.PhoneUsers(ctx)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if len(users) != 2 {
+				t.Errorf("expected 2 users, got %d", len(users))
+			}
+		}()
+	}
+	wg.Wait()
+	if transport.calls.Load() != 1 {
+		t.Fatalf("expected phone users to be fetched once, got %d calls", transport.calls.Load())
+	}
+
+	server.AddUser(fakezoom.User{Email: "c@example.com", Phone: true})
+	cache.InvalidatePhoneUsers()
+	users, err := cache.PhoneUsers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 3 {
+		t.Fatalf("expected 3 users after invalidation, got %d", len(users))
+	}
+	if transport.calls.Load() != 2 {
+		t.Fatalf("expected phone users to be fetched again after invalidation, got %d calls", transport.calls.Load())
+	}
+}
+
+func TestCacheSitesAndCallQueues(t *testing.T) {
+	ctx := context.Background()
+	server := fakezoom.NewServer()
+	defer server.Close()
+
+	client, err := zoomphone.NewClient(server.APIURL(), staticToken{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := shared.NewCache(client)
+
+	sites, err := cache.Sites(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sites) != 1 || sites[0].ID.Value != server.MainSiteID() {
+		t.Fatalf("unexpected sites: %+v", sites)
+	}
+
+	callQueues, err := cache.CallQueues(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(callQueues) != 0 {
+		t.Fatalf("unexpected call queues: %+v", callQueues)
+	}
+	if _, err := client.CreateCallQueue(ctx, zoomphone.NewOptCreateCallQueueReq(zoomphone.CreateCallQueueReq{Name: "Support"})); err != nil {
+		t.Fatal(err)
+	}
+	cache.InvalidateCallQueues()
+	callQueues, err = cache.CallQueues(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(callQueues) != 1 || callQueues[0].Name.Value != "Support" {
+		t.Fatalf("unexpected call queues: %+v", callQueues)
+	}
+}
+
+func TestCacheSubAccounts(t *testing.T) {
+	ctx := context.Background()
+	server := fakezoom.NewServer()
+	defer server.Close()
+
+	transport := &countingTransport{}
+	client, err := zoomphone.NewClient(server.APIURL(), staticToken{}, zoomphone.WithClient(&http.Client{Transport: transport}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := shared.NewCache(client)
+
+	for _, ctx := range []context.Context{ctx, httpclient.WithSubAccountID(ctx, "sub"), ctx, httpclient.WithSubAccountID(ctx, "sub")} {
+		if _, err := cache.Sites(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if transport.calls.Load() != 2 {
+		t.Fatalf("expected sites to be fetched once per sub-account, got %d calls", transport.calls.Load())
+	}
+
+	cache.InvalidateSites()
+	if _, err := cache.Sites(httpclient.WithSubAccountID(ctx, "sub")); err != nil {
+		t.Fatal(err)
+	}
+	if transport.calls.Load() != 3 {
+		t.Fatalf("expected sites of the sub-account to be fetched again after invalidation, got %d calls", transport.calls.Load())
+	}
+}
@@ -3,10 +3,17 @@ package shared
 import (
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomuser"
+	"github.com/folio-sec/terraform-provider-zoom/internal/zoomoauth"
 )
 
 // ProviderData is the data that is passed to objects for provider.
 type ProviderData struct {
 	PhoneClient *zoomphone.Client
 	UserClient  *zoomuser.Client
+	Cache       *Cache
+	Locks       *ObjectLocks
+	// TokenSource supplies the access tokens the clients use.
+	TokenSource *zoomoauth.TokenSource
+	// GrantedScopes are the OAuth scopes of the access token, or nil when Zoom did not return them.
+	GrantedScopes []string
 }
@@ -0,0 +1,52 @@
+package shared
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// IdentitySchema is the identity of a resource whose object is identified by idAttr, within the sub-account if any.
+// It lets Terraform import the resource by an identity block, e.g. from the results of a list resource.
+func IdentitySchema(idAttr string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			idAttr: identityschema.StringAttribute{
+				Description:       "The ID of the object.",
+				RequiredForImport: true,
+			},
+			"sub_account_id": identityschema.StringAttribute{
+				Description:       subAccountIDDescription,
+				OptionalForImport: true,
+			},
+		},
+	}
+}
+
+// SetIdentity sets the identity of IdentitySchema. identity is nil when Terraform does not support identities.
+func SetIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, idAttr string, id, subAccountID types.String) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	var diags diag.Diagnostics
+	diags.Append(identity.SetAttribute(ctx, path.Root(idAttr), id)...)
+	diags.Append(identity.SetAttribute(ctx, path.Root("sub_account_id"), subAccountID)...)
+	return diags
+}
+
+// SetIdentityFromState sets the identity of IdentitySchema from the attributes of the same name in state,
+// once a create or update has set it.
+func SetIdentityFromState(ctx context.Context, state tfsdk.State, identity *tfsdk.ResourceIdentity, idAttr string) diag.Diagnostics {
+	var id, subAccountID types.String
+	var diags diag.Diagnostics
+	diags.Append(state.GetAttribute(ctx, path.Root(idAttr), &id)...)
+	diags.Append(state.GetAttribute(ctx, path.Root("sub_account_id"), &subAccountID)...)
+	if diags.HasError() {
+		return diags
+	}
+	return SetIdentity(ctx, identity, idAttr, id, subAccountID)
+}
@@ -0,0 +1,81 @@
+package shared_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func newImportResponse(ctx context.Context) *resource.ImportStateResponse {
+	stateSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":             schema.StringAttribute{Computed: true},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+	}
+	return &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Raw:    tftypes.NewValue(stateSchema.Type().TerraformType(ctx), nil),
+			Schema: stateSchema,
+		},
+	}
+}
+
+func newIdentity(t *testing.T, ctx context.Context, id, subAccountID types.String) *tfsdk.ResourceIdentity {
+	identitySchema := shared.IdentitySchema("id")
+	identity := &tfsdk.ResourceIdentity{
+		Raw:    tftypes.NewValue(identitySchema.Type().TerraformType(ctx), nil),
+		Schema: identitySchema,
+	}
+	if diags := shared.SetIdentity(ctx, identity, "id", id, subAccountID); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return identity
+}
+
+func TestImportStatePassthroughID(t *testing.T) {
+	ctx := context.Background()
+
+	byIdentity := newIdentity(t, ctx, types.StringValue("site-1"), types.StringValue("account-1"))
+	withoutSubAccount := newIdentity(t, ctx, types.StringValue("site-1"), types.StringNull())
+
+	for _, tc := range []struct {
+		name             string
+		req              resource.ImportStateRequest
+		wantID           string
+		wantSubAccountID types.String
+	}{
+		{"id", resource.ImportStateRequest{ID: "site-1"}, "site-1", types.StringNull()},
+		{"sub_account_id:id", resource.ImportStateRequest{ID: "account-1:site-1"}, "site-1", types.StringValue("account-1")},
+		{"identity", resource.ImportStateRequest{Identity: byIdentity}, "site-1", types.StringValue("account-1")},
+		{"identity without sub_account_id", resource.ImportStateRequest{Identity: withoutSubAccount}, "site-1", types.StringNull()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := newImportResponse(ctx)
+			shared.ImportStatePassthroughID(ctx, path.Root("id"), tc.req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var id, subAccountID types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("sub_account_id"), &subAccountID)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if id.ValueString() != tc.wantID {
+				t.Errorf("expected id %q, got %s", tc.wantID, id)
+			}
+			if !subAccountID.Equal(tc.wantSubAccountID) {
+				t.Errorf("expected sub_account_id %s, got %s", tc.wantSubAccountID, subAccountID)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ImportCandidate is an object that the import ID of ImportStateByKey matches.
+type ImportCandidate struct {
+	ID string
+	// Label tells the candidates apart when the import ID is ambiguous, e.g. `call queue Tokyo/Support (extension 101)`.
+	Label string
+}
+
+// ImportKeyResolver returns the objects whose ID or natural key, such as an email or a name, is key.
+type ImportKeyResolver func(ctx context.Context, key string) ([]ImportCandidate, error)
+
+// zoomIDPattern matches the IDs Zoom gives to accounts and objects, e.g. `lq6r8TDXSW6ZNn0XnUXgbw`.
+var zoomIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{22}$`)
+
+// ImportStateByKey is ImportStatePassthroughID that also accepts a natural key of the object in place of its ID,
+// e.g. `<email>` or `<sub_account_id>:<email>`. The key is resolved by resolve in the sub-account of the import,
+// and kind names the objects that resolve looks up in the error when none matches.
+func ImportStateByKey(ctx context.Context, attrPath path.Path, req resource.ImportStateRequest, resp *resource.ImportStateResponse, kind string, resolve ImportKeyResolver) {
+	if req.ID == "" {
+		ImportStatePassthroughID(ctx, attrPath, req, resp)
+		return
+	}
+
+	subAccountID, key := CutSubAccountID(req.ID)
+	id, diags := ResolveImportKey(WithSubAccountID(ctx, subAccountID), attrPath, key, kind, resolve)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, attrPath, id)...)
+	if !subAccountID.IsNull() {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("sub_account_id"), subAccountID)...)
+	}
+}
+
+// CutSubAccountID splits an import ID of the form `<sub_account_id>:<key>`, returning a null sub-account for a plain key.
+// The part before the first colon is a sub-account only when it is shaped like a Zoom ID, so that a name can contain colons.
+func CutSubAccountID(id string) (types.String, string) {
+	subAccountID, key, ok := strings.Cut(id, ":")
+	if !ok || !zoomIDPattern.MatchString(subAccountID) {
+		return types.StringNull(), id
+	}
+	return types.StringValue(subAccountID), key
+}
+
+// ResolveImportKey returns the ID of the single object of kind that key matches. When no object matches,
+// key is returned as is if it is shaped like a Zoom ID, so that the ID of an object that cannot be listed still imports.
+func ResolveImportKey(ctx context.Context, attrPath path.Path, key string, kind string, resolve ImportKeyResolver) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	candidates, err := resolve(ctx, key)
+	if err != nil {
+		diags.Append(util.ErrorDiagnostic(attrPath, "Unable to resolve import ID", err))
+		return "", diags
+	}
+	switch len(candidates) {
+	case 0:
+		if zoomIDPattern.MatchString(key) {
+			return key, diags
+		}
+		diags.AddAttributeError(attrPath, "Unknown import ID", fmt.Sprintf("No %s matches %q. Import it by its ID or by one of the keys in the documentation instead.", kind, key))
+		return "", diags
+	case 1:
+		return candidates[0].ID, diags
+	}
+
+	detail := fmt.Sprintf("%q matches %d objects, import one of them by its ID instead:", key, len(candidates))
+	for _, candidate := range candidates {
+		detail += fmt.Sprintf("\n  - %s: %s", candidate.ID, candidate.Label)
+	}
+	diags.AddAttributeError(attrPath, "Ambiguous import ID", detail)
+	return "", diags
+}
+
+// MatchImportKey returns the candidate whose ID is key, or else every candidate one of whose natural keys is key,
+// ignoring case. describe returns the candidate of an item along with its natural keys, where empty ones are ignored.
+func MatchImportKey[T any](items []T, key string, describe func(item T) (ImportCandidate, []string)) []ImportCandidate {
+	var matches []ImportCandidate
+	for _, item := range items {
+		candidate, naturalKeys := describe(item)
+		if candidate.ID == key {
+			return []ImportCandidate{candidate}
+		}
+		if slices.ContainsFunc(naturalKeys, func(naturalKey string) bool {
+			return naturalKey != "" && strings.EqualFold(naturalKey, key)
+		}) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// PhoneUserImportKey resolves a phone user by email or extension number.
+func (c *Cache) PhoneUserImportKey(ctx context.Context, key string) ([]ImportCandidate, error) {
+	users, err := c.PhoneUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return MatchImportKey(users, key, phoneUserCandidate), nil
+}
+
+func phoneUserCandidate(item zoomphone.ListPhoneUsersOKUsersItem) (ImportCandidate, []string) {
+	return ImportCandidate{
+		ID:    item.ID.Value,
+		Label: fmt.Sprintf("phone user %s <%s>%s", item.Name.Value, item.Email.Value, extensionLabel(item.ExtensionNumber)),
+	}, []string{item.Email.Value, extensionKey(item.ExtensionNumber)}
+}
+
+// SiteImportKey resolves a site by name.
+func (c *Cache) SiteImportKey(ctx context.Context, key string) ([]ImportCandidate, error) {
+	sites, err := c.Sites(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return MatchImportKey(sites, key, func(item zoomphone.ListPhoneSitesOKSitesItem) (ImportCandidate, []string) {
+		return ImportCandidate{
+			ID:    item.ID.Value,
+			Label: "site " + item.Name.Value,
+		}, []string{item.Name.Value}
+	}), nil
+}
+
+// CallQueueImportKey resolves a call queue by `<site name>/<name>`, name or extension number.
+func (c *Cache) CallQueueImportKey(ctx context.Context, key string) ([]ImportCandidate, error) {
+	callQueues, err := c.CallQueues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return MatchImportKey(callQueues, key, callQueueCandidate), nil
+}
+
+func callQueueCandidate(item zoomphone.ListCallQueuesOKCallQueuesItem) (ImportCandidate, []string) {
+	name := siteScopedName(item.Site.Value.Name.Value, item.Name.Value)
+	return ImportCandidate{
+		ID:    item.ID.Value,
+		Label: "call queue " + name + extensionLabel(item.ExtensionNumber),
+	}, []string{name, item.Name.Value, extensionKey(item.ExtensionNumber)}
+}
+
+// SharedLineGroupImportKey resolves a shared line group by `<site name>/<display name>`, display name or extension number.
+func (c *Cache) SharedLineGroupImportKey(ctx context.Context, key string) ([]ImportCandidate, error) {
+	sharedLineGroups, err := c.SharedLineGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return MatchImportKey(sharedLineGroups, key, sharedLineGroupCandidate), nil
+}
+
+func sharedLineGroupCandidate(item zoomphone.ListSharedLineGroupsOKSharedLineGroupsItem) (ImportCandidate, []string) {
+	name := siteScopedName(item.Site.Value.Name.Value, item.DisplayName.Value)
+	return ImportCandidate{
+		ID:    item.ID.Value,
+		Label: "shared line group " + name + extensionLabel(item.ExtensionNumber),
+	}, []string{name, item.DisplayName.Value, extensionKey(item.ExtensionNumber)}
+}
+
+// AutoReceptionistImportKey resolves an auto receptionist by `<site name>/<name>`, name or extension number.
+func (c *Cache) AutoReceptionistImportKey(ctx context.Context, key string) ([]ImportCandidate, error) {
+	autoReceptionists, err := c.AutoReceptionists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return MatchImportKey(autoReceptionists, key, autoReceptionistCandidate), nil
+}
+
+func autoReceptionistCandidate(item zoomphone.ListAutoReceptionistsOKAutoReceptionistsItem) (ImportCandidate, []string) {
+	name := siteScopedName(item.Site.Value.Name.Value, item.Name.Value)
+	return ImportCandidate{
+		ID:    item.ID.Value,
+		Label: "auto receptionist " + name + extensionLabel(item.ExtensionNumber),
+	}, []string{name, item.Name.Value, extensionKey(item.ExtensionNumber)}
+}
+
+// ExtensionImportKey resolves the extension ID of a phone user, call queue, shared line group or auto receptionist
+// by extension number, or of a phone user by email.
+func (c *Cache) ExtensionImportKey(ctx context.Context, key string) ([]ImportCandidate, error) {
+	users, err := c.PhoneUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	callQueues, err := c.CallQueues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sharedLineGroups, err := c.SharedLineGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	autoReceptionists, err := c.AutoReceptionists(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The extension ID replaces the object ID, so that an extension ID matches whatever the type of its object.
+	type extension struct {
+		candidate   ImportCandidate
+		naturalKeys []string
+	}
+	var extensions []extension
+	for _, item := range users {
+		candidate, naturalKeys := phoneUserCandidate(item)
+		candidate.ID = item.ExtensionID.Value
+		extensions = append(extensions, extension{candidate, naturalKeys})
+	}
+	for _, item := range callQueues {
+		candidate, _ := callQueueCandidate(item)
+		candidate.ID = item.ExtensionID.Value
+		extensions = append(extensions, extension{candidate, []string{extensionKey(item.ExtensionNumber)}})
+	}
+	for _, item := range sharedLineGroups {
+		candidate, _ := sharedLineGroupCandidate(item)
+		candidate.ID = item.ExtensionID.Value
+		extensions = append(extensions, extension{candidate, []string{extensionKey(item.ExtensionNumber)}})
+	}
+	for _, item := range autoReceptionists {
+		candidate, _ := autoReceptionistCandidate(item)
+		candidate.ID = item.ExtensionID.Value
+		extensions = append(extensions, extension{candidate, []string{extensionKey(item.ExtensionNumber)}})
+	}
+	return MatchImportKey(extensions, key, func(item extension) (ImportCandidate, []string) {
+		return item.candidate, item.naturalKeys
+	}), nil
+}
+
+// siteScopedName is the `<site name>/<name>` key of an object of a site, which tells apart objects of the same name.
+func siteScopedName(siteName, name string) string {
+	if siteName == "" {
+		return name
+	}
+	return siteName + "/" + name
+}
+
+func extensionKey(extensionNumber zoomphone.OptInt64) string {
+	if !extensionNumber.IsSet() {
+		return ""
+	}
+	return strconv.FormatInt(extensionNumber.Value, 10)
+}
+
+func extensionLabel(extensionNumber zoomphone.OptInt64) string {
+	if !extensionNumber.IsSet() {
+		return ""
+	}
+	return fmt.Sprintf(" (extension %d)", extensionNumber.Value)
+}
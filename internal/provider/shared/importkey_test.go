@@ -0,0 +1,188 @@
+package shared_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance/fakezoom"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/httpclient"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestImportStateByKey(t *testing.T) {
+	ctx := context.Background()
+
+	const subAccountID = "lq6r8TDXSW6ZNn0XnUXgbw"
+	resolve := func(ctx context.Context, key string) ([]shared.ImportCandidate, error) {
+		if httpclient.SubAccountIDFromContext(ctx) == subAccountID && key == "sales@example.com" {
+			return []shared.ImportCandidate{{ID: "user-3", Label: "Sales"}}, nil
+		}
+		switch key {
+		case "alice@example.com":
+			return []shared.ImportCandidate{{ID: "user-1", Label: "Alice"}}, nil
+		case "Support: Tokyo":
+			return []shared.ImportCandidate{{ID: "cq-3", Label: "Tokyo/Support: Tokyo"}}, nil
+		case "Support":
+			return []shared.ImportCandidate{{ID: "cq-1", Label: "Tokyo/Support"}, {ID: "cq-2", Label: "Osaka/Support"}}, nil
+		case "broken":
+			return nil, errors.New("unable to read phone users")
+		}
+		return nil, nil
+	}
+
+	for _, tc := range []struct {
+		name             string
+		req              resource.ImportStateRequest
+		wantID           types.String
+		wantSubAccountID types.String
+		wantError        string
+	}{
+		{
+			name:             "natural key",
+			req:              resource.ImportStateRequest{ID: "alice@example.com"},
+			wantID:           types.StringValue("user-1"),
+			wantSubAccountID: types.StringNull(),
+		},
+		{
+			name:             "natural key of a sub-account",
+			req:              resource.ImportStateRequest{ID: subAccountID + ":sales@example.com"},
+			wantID:           types.StringValue("user-3"),
+			wantSubAccountID: types.StringValue(subAccountID),
+		},
+		{
+			name:             "natural key with a colon",
+			req:              resource.ImportStateRequest{ID: "Support: Tokyo"},
+			wantID:           types.StringValue("cq-3"),
+			wantSubAccountID: types.StringNull(),
+		},
+		{
+			name:             "unmatched key shaped like an id",
+			req:              resource.ImportStateRequest{ID: "KDcuGIm1QgePTO8WbOqwIQ"},
+			wantID:           types.StringValue("KDcuGIm1QgePTO8WbOqwIQ"),
+			wantSubAccountID: types.StringNull(),
+		},
+		{
+			name:      "unmatched natural key",
+			req:       resource.ImportStateRequest{ID: "bob@example.com"},
+			wantError: `No phone user matches "bob@example.com"`,
+		},
+		{
+			name:             "by identity",
+			req:              resource.ImportStateRequest{Identity: newIdentity(t, ctx, types.StringValue("user-1"), types.StringNull())},
+			wantID:           types.StringValue("user-1"),
+			wantSubAccountID: types.StringNull(),
+		},
+		{
+			name:      "ambiguous key",
+			req:       resource.ImportStateRequest{ID: "Support"},
+			wantError: "cq-1: Tokyo/Support",
+		},
+		{
+			name:      "resolve error",
+			req:       resource.ImportStateRequest{ID: "broken"},
+			wantError: "unable to read phone users",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := newImportResponse(ctx)
+			shared.ImportStateByKey(ctx, path.Root("id"), tc.req, resp, "phone user", resolve)
+			if tc.wantError != "" {
+				if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), tc.wantError) {
+					t.Fatalf("expected an error containing %q, got %v", tc.wantError, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var id, subAccountID types.String
+			resp.State.GetAttribute(ctx, path.Root("id"), &id)
+			resp.State.GetAttribute(ctx, path.Root("sub_account_id"), &subAccountID)
+			if !id.Equal(tc.wantID) || !subAccountID.Equal(tc.wantSubAccountID) {
+				t.Fatalf("expected id %s and sub_account_id %s, got %s and %s", tc.wantID, tc.wantSubAccountID, id, subAccountID)
+			}
+		})
+	}
+}
+
+func TestCacheImportKeys(t *testing.T) {
+	ctx := context.Background()
+	server := fakezoom.NewServer()
+	defer server.Close()
+	aliceID := server.AddUser(fakezoom.User{Email: "alice@example.com", FirstName: "Alice", Phone: true})
+
+	client, err := zoomphone.NewClient(server.APIURL(), staticToken{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var callQueueIDs []string
+	for range 2 {
+		res, err := client.CreateCallQueue(ctx, zoomphone.NewOptCreateCallQueueReq(zoomphone.CreateCallQueueReq{Name: "Support"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		callQueueIDs = append(callQueueIDs, res.ID.Value)
+	}
+	sharedLineGroup, err := client.CreateASharedLineGroup(ctx, zoomphone.NewOptCreateASharedLineGroupReq(zoomphone.CreateASharedLineGroupReq{DisplayName: "Reception"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := shared.NewCache(client)
+
+	users, err := cache.PhoneUsers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	callQueues, err := cache.CallQueues(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	autoReceptionists, err := cache.AutoReceptionists(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || len(callQueues) != 2 || len(autoReceptionists) != 1 {
+		t.Fatalf("unexpected lists: %+v, %+v, %+v", users, callQueues, autoReceptionists)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		resolve shared.ImportKeyResolver
+		key     string
+		wantIDs []string
+	}{
+		{"phone user by email", cache.PhoneUserImportKey, "Alice@Example.com", []string{aliceID}},
+		{"phone user by extension number", cache.PhoneUserImportKey, strconv.FormatInt(users[0].ExtensionNumber.Value, 10), []string{aliceID}},
+		{"site by name", cache.SiteImportKey, "main site", []string{server.MainSiteID()}},
+		{"call queue by name", cache.CallQueueImportKey, "Support", callQueueIDs},
+		{"call queue by extension number", cache.CallQueueImportKey, strconv.FormatInt(callQueues[1].ExtensionNumber.Value, 10), []string{callQueues[1].ID.Value}},
+		{"call queue by id", cache.CallQueueImportKey, callQueueIDs[0], callQueueIDs[:1]},
+		{"shared line group by display name", cache.SharedLineGroupImportKey, "Reception", []string{sharedLineGroup.ID.Value}},
+		{"auto receptionist by site and name", cache.AutoReceptionistImportKey, "Main Site/" + autoReceptionists[0].Name.Value, []string{autoReceptionists[0].ID.Value}},
+		{"extension by email", cache.ExtensionImportKey, "alice@example.com", []string{users[0].ExtensionID.Value}},
+		{"extension by number", cache.ExtensionImportKey, strconv.FormatInt(callQueues[0].ExtensionNumber.Value, 10), []string{callQueues[0].ExtensionID.Value}},
+		{"unknown", cache.SiteImportKey, "Osaka", nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			candidates, err := tc.resolve(ctx, tc.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, candidate := range candidates {
+				ids = append(ids, candidate.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tc.wantIDs, ",") {
+				t.Fatalf("expected %v, got %+v", tc.wantIDs, candidates)
+			}
+		})
+	}
+}
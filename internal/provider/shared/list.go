@@ -0,0 +1,57 @@
+package shared
+
+import (
+	"context"
+	"iter"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// SubAccountIDListAttribute is the sub_account_id attribute of every list resource.
+func SubAccountIDListAttribute() listschema.StringAttribute {
+	return listschema.StringAttribute{
+		MarkdownDescription: "The ID of the sub-account to list, when the provider authenticates as a master account. Overrides the `sub_account_id` of the provider.",
+		Optional:            true,
+	}
+}
+
+// ListResults streams the objects of items as the results of a list resource, up to the limit of req.
+// fill sets the display name and identity of a result, and its resource when req.IncludeResource is set.
+// It returns false to skip the object, e.g. when it has been deleted since it was listed.
+//
+// The objects are fetched while Terraform consumes the results, so the span of the list starts here rather than
+// in the List method. An error fetching the objects is pushed as the last result.
+func ListResults[T any](ctx context.Context, req list.ListRequest, typeName, errorSummary string, items func(ctx context.Context) iter.Seq2[T, error], fill func(ctx context.Context, item T, result *list.ListResult) bool) iter.Seq[list.ListResult] {
+	return func(push func(list.ListResult) bool) {
+		var diags diag.Diagnostics
+		ctx, span := StartSpan(ctx, "list."+typeName, "List")
+		defer EndSpan(span, &diags)
+
+		var count int64
+		for item, err := range items(ctx) {
+			if err != nil {
+				// the errors of the results pushed so far are not pushed again
+				fetchErr := util.ErrorDiagnostic(path.Empty(), errorSummary, err)
+				diags.Append(fetchErr)
+				push(list.ListResult{Diagnostics: diag.Diagnostics{fetchErr}})
+				return
+			}
+			result := req.NewListResult(ctx)
+			if !fill(ctx, item, &result) {
+				continue
+			}
+			diags.Append(result.Diagnostics.Errors()...)
+			if !push(result) {
+				return
+			}
+			count++
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package shared_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+func TestListResults(t *testing.T) {
+	ctx := context.Background()
+	req := list.ListRequest{
+		ResourceSchema: schema.Schema{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{Computed: true},
+			},
+		},
+		ResourceIdentitySchema: shared.IdentitySchema("id"),
+	}
+	items := func(ctx context.Context) iter.Seq2[int, error] {
+		return func(yield func(int, error) bool) {
+			for _, item := range []int{1, 2, 3} {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			yield(0, errors.New("unable to list the next page"))
+		}
+	}
+	fill := func(ctx context.Context, item int, result *list.ListResult) bool {
+		result.DisplayName = strconv.Itoa(item)
+		switch item {
+		case 1:
+			result.Diagnostics.AddAttributeError(path.Root("id"), "Error reading object", "unable to read 1")
+		case 2:
+			return false // deleted while listing
+		}
+		return true
+	}
+
+	var displayNames []string
+	var errorCounts []int
+	for result := range shared.ListResults(ctx, req, "zoom_test", "Error listing objects", items, fill) {
+		displayNames = append(displayNames, result.DisplayName)
+		errorCounts = append(errorCounts, result.Diagnostics.ErrorsCount())
+	}
+	// The skipped object is not pushed, and the fetch error is pushed without the errors of the earlier results.
+	if want := []string{"1", "3", ""}; !slices.Equal(displayNames, want) {
+		t.Fatalf("expected the results %q, got %q", want, displayNames)
+	}
+	if want := []int{1, 0, 1}; !slices.Equal(errorCounts, want) {
+		t.Fatalf("expected %v errors in the results, got %v", want, errorCounts)
+	}
+}
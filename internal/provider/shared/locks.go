@@ -0,0 +1,63 @@
+package shared
+
+import (
+	"context"
+	"sync"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/httpclient"
+)
+
+// ObjectLocks serializes the writes to the same Zoom object from different resources, e.g. the members and the
+// phone numbers of a call queue, which Zoom may reject with a conflict while another change to it is in progress.
+// Writes to different objects still run in parallel.
+type ObjectLocks struct {
+	mu    sync.Mutex
+	locks map[string]*objectLock
+}
+
+type objectLock struct {
+	ch      chan struct{}
+	waiters int
+}
+
+func NewObjectLocks() *ObjectLocks {
+	return &ObjectLocks{locks: map[string]*objectLock{}}
+}
+
+// Lock waits until no other write to the object of the kind and ID is in progress, or ctx is done,
+// e.g. by the timeout of the operation. The returned function releases the lock.
+func (l *ObjectLocks) Lock(ctx context.Context, kind, id string) (func(), error) {
+	// IDs are unique in an account, so the sub-account is part of the key
+	key := httpclient.SubAccountIDFromContext(ctx) + "/" + kind + "/" + id
+
+	l.mu.Lock()
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = &objectLock{ch: make(chan struct{}, 1)}
+		l.locks[key] = lock
+	}
+	lock.waiters++
+	l.mu.Unlock()
+
+	select {
+	case lock.ch <- struct{}{}:
+		return func() {
+			<-lock.ch
+			l.release(key, lock)
+		}, nil
+	case <-ctx.Done():
+		l.release(key, lock)
+		return nil, ctx.Err()
+	}
+}
+
+// release forgets the lock when nobody holds or waits for it, so that the map does not grow with every object.
+func (l *ObjectLocks) release(key string, lock *objectLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock.waiters--
+	if lock.waiters == 0 {
+		delete(l.locks, key)
+	}
+}
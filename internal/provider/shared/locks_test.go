@@ -0,0 +1,70 @@
+package shared_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/httpclient"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+)
+
+func TestObjectLocks(t *testing.T) {
+	ctx := context.Background()
+	locks := shared.NewObjectLocks()
+
+	var inProgress, maxInProgress atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := locks.Lock(ctx, "call_queue", "cq1")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer unlock()
+			n := inProgress.Add(1)
+			if n > maxInProgress.Load() {
+				maxInProgress.Store(n)
+			}
+			time.Sleep(time.Millisecond)
+			inProgress.Add(-1)
+		}()
+	}
+	wg.Wait()
+	if maxInProgress.Load() != 1 {
+		t.Fatalf("expected the writes to the same object to be serialized, got %d at once", maxInProgress.Load())
+	}
+
+	unlock, err := locks.Lock(ctx, "call_queue", "cq1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+	for _, other := range []struct {
+		ctx  context.Context
+		kind string
+		id   string
+	}{
+		{ctx, "call_queue", "cq2"},
+		{ctx, "shared_line_group", "cq1"},
+		{httpclient.WithSubAccountID(ctx, "sub"), "call_queue", "cq1"},
+	} {
+		unlockOther, err := locks.Lock(other.ctx, other.kind, other.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		unlockOther()
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := locks.Lock(timeoutCtx, "call_queue", "cq1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to end with the context, got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/samber/lo"
+)
+
+// ReconcileCreate sends a create request with create, which returns the ID of the created object. When the request fails
+// without telling whether Zoom applied it (see util.IsAmbiguous), the object it created is adopted instead of failing,
+// so that the apply does not leave an object behind that Terraform does not know about.
+//
+// matching returns the IDs of the objects like the requested one, e.g. of the same name. It is called before the request
+// as well, so that only an object that did not exist before is adopted, never one created by someone else. create must
+// make matching see the current objects afterwards, e.g. by invalidating the Cache. When matching fails before the
+// request, the create is sent without reconciliation.
+func ReconcileCreate(ctx context.Context, matching func(ctx context.Context) ([]string, error), create func() (string, error)) (string, error) {
+	before, beforeErr := matching(ctx)
+	id, err := create()
+	if err == nil || !util.IsAmbiguous(err) || beforeErr != nil {
+		return id, err
+	}
+
+	after, lookupErr := matching(ctx)
+	if lookupErr != nil {
+		return "", err
+	}
+	created := lo.Without(after, before...)
+	switch len(created) {
+	case 0:
+		return "", err
+	case 1:
+		return created[0], nil
+	default:
+		return "", fmt.Errorf("%w; %d objects like the requested one have been created since, so none of them was adopted: %s",
+			err, len(created), strings.Join(created, ", "))
+	}
+}
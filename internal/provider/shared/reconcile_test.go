@@ -0,0 +1,85 @@
+package shared_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+)
+
+func TestReconcileCreate(t *testing.T) {
+	ctx := context.Background()
+	ambiguous := &util.AmbiguousRequestError{Method: "POST", URL: "/phone/call_queues", Err: errors.New("connection reset")}
+
+	for _, tc := range []struct {
+		name      string
+		existing  []string
+		created   []string
+		createErr error
+		wantID    string
+		wantError string
+	}{
+		{
+			name:     "created",
+			existing: []string{"cq-1"},
+			created:  []string{"cq-2"},
+			wantID:   "cq-2",
+		},
+		{
+			name:      "ambiguous and created",
+			existing:  []string{"cq-1"},
+			created:   []string{"cq-2"},
+			createErr: ambiguous,
+			wantID:    "cq-2",
+		},
+		{
+			name:      "ambiguous and not created",
+			existing:  []string{"cq-1"},
+			createErr: ambiguous,
+			wantError: "connection reset",
+		},
+		{
+			name:      "ambiguous and created along with another",
+			existing:  []string{"cq-1"},
+			created:   []string{"cq-2", "cq-3"},
+			createErr: ambiguous,
+			wantError: "none of them was adopted: cq-2, cq-3",
+		},
+		{
+			name:      "not ambiguous",
+			created:   []string{"cq-2"},
+			createErr: errors.New("bad request"),
+			wantError: "bad request",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ids := slices.Clone(tc.existing)
+			matching := func(context.Context) ([]string, error) {
+				return ids, nil
+			}
+			id, err := shared.ReconcileCreate(ctx, matching, func() (string, error) {
+				ids = append(ids, tc.created...)
+				if tc.createErr != nil {
+					return "", tc.createErr
+				}
+				return tc.created[0], nil
+			})
+			if tc.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantError) {
+					t.Fatalf("expected an error containing %q, got %v", tc.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != tc.wantID {
+				t.Fatalf("expected %s, got %s", tc.wantID, id)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package shared
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// Scopes are the OAuth scopes a resource or data source requires. They are listed in its documentation and
+// checked against the scopes granted to the access token before planning, so that a missing scope fails early
+// instead of with an opaque 401 or 403 in the middle of an apply.
+type Scopes []string
+
+// Markdown lists the scopes for the API Permissions section of a schema description.
+func (s Scopes) Markdown() string {
+	quoted := make([]string, 0, len(s))
+	for _, scope := range s {
+		quoted = append(quoted, "`"+scope+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// Missing returns the scopes not covered by the granted ones.
+func (s Scopes) Missing(granted []string) Scopes {
+	var missing Scopes
+	for _, required := range s {
+		if !slices.ContainsFunc(granted, func(scope string) bool {
+			return scopeCovers(scope, required)
+		}) {
+			missing = append(missing, required)
+		}
+	}
+	return missing
+}
+
+// scopeCovers reports whether the granted scope allows what the required granular scope does,
+// e.g. the classic scope phone:write:admin covers phone:write:site:admin, phone:update:site:admin and phone:delete:site:admin,
+// and phone:read:site:admin too, as the classic write scopes include reading.
+func scopeCovers(granted, required string) bool {
+	if granted == required {
+		return true
+	}
+	g := strings.Split(granted, ":")
+	r := strings.Split(required, ":")
+	if len(g) != 3 || len(r) != 4 || g[0] != r[0] || g[2] != r[3] {
+		return false
+	}
+	switch g[1] {
+	case r[1]:
+		return true
+	case "write":
+		return r[1] == "read" || r[1] == "update" || r[1] == "delete"
+	}
+	return false
+}
+
+// ValidateScopes fails when the access token lacks any of the required scopes.
+// It does nothing before the provider is configured, or when Zoom did not tell the granted scopes.
+func (d *ProviderData) ValidateScopes(required Scopes) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if d == nil || len(d.GrantedScopes) == 0 {
+		return diags
+	}
+	if missing := required.Missing(d.GrantedScopes); len(missing) > 0 {
+		diags.AddError(
+			"Missing OAuth scopes",
+			fmt.Sprintf("The access token is not granted the scopes %s. "+
+				"Add them to the OAuth app in the Zoom App Marketplace, then reactivate a Server-to-Server OAuth app, or authorize a General OAuth app again, so that new access tokens are granted them.", missing.Markdown()),
+		)
+	}
+	return diags
+}
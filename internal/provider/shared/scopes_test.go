@@ -0,0 +1,54 @@
+package shared_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+)
+
+func TestScopesMissing(t *testing.T) {
+	required := shared.Scopes{
+		"phone:read:site:admin",
+		"phone:write:site:admin",
+		"phone:update:site:admin",
+		"phone:delete:site:admin",
+		"user:read:list_users:admin",
+	}
+
+	for _, tc := range []struct {
+		granted []string
+		missing shared.Scopes
+	}{
+		{[]string{"phone:read:admin", "phone:write:admin", "user:read:admin"}, nil},
+		{[]string{"phone:read:site:admin", "phone:write:site:admin", "user:read:list_users:admin"}, shared.Scopes{"phone:update:site:admin", "phone:delete:site:admin"}},
+		{[]string{"phone:write:admin", "user:read:admin"}, nil},
+		{[]string{"phone:read:admin", "user:write:admin"}, shared.Scopes{"phone:write:site:admin", "phone:update:site:admin", "phone:delete:site:admin"}},
+		{[]string{"phone:read:admin"}, shared.Scopes{"phone:write:site:admin", "phone:update:site:admin", "phone:delete:site:admin", "user:read:list_users:admin"}},
+	} {
+		if missing := required.Missing(tc.granted); !slices.Equal(missing, tc.missing) {
+			t.Errorf("granted %v: expected missing %v, got %v", tc.granted, tc.missing, missing)
+		}
+	}
+}
+
+func TestValidateScopes(t *testing.T) {
+	required := shared.Scopes{"phone:read:site:admin", "phone:write:site:admin"}
+
+	var unconfigured *shared.ProviderData
+	if diags := unconfigured.ValidateScopes(required); diags.HasError() {
+		t.Fatalf("expected no error before the provider is configured: %v", diags)
+	}
+	if diags := (&shared.ProviderData{}).ValidateScopes(required); diags.HasError() {
+		t.Fatalf("expected no error when the granted scopes are unknown: %v", diags)
+	}
+
+	diags := (&shared.ProviderData{GrantedScopes: []string{"phone:read:admin"}}).ValidateScopes(required)
+	if !diags.HasError() {
+		t.Fatal("expected an error for the missing scope")
+	}
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "`phone:write:site:admin`") || strings.Contains(detail, "phone:read:site:admin") {
+		t.Fatalf("expected only the missing scope to be listed, got: %s", detail)
+	}
+}
@@ -0,0 +1,80 @@
+package shared
+
+import (
+	"context"
+	"strings"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/httpclient"
+	actionschema "github.com/hashicorp/terraform-plugin-framework/action/schema"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const subAccountIDDescription = "The ID of the sub-account to manage, when the provider authenticates as a master account. Overrides the `sub_account_id` of the provider."
+
+// SubAccountIDResourceAttribute is the sub_account_id attribute of every resource.
+// Moving an object to another account is not possible, so a change replaces the resource.
+func SubAccountIDResourceAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: subAccountIDDescription,
+		Optional:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// SubAccountIDDataSourceAttribute is the sub_account_id attribute of every data source.
+func SubAccountIDDataSourceAttribute() datasourceschema.StringAttribute {
+	return datasourceschema.StringAttribute{
+		MarkdownDescription: subAccountIDDescription,
+		Optional:            true,
+	}
+}
+
+// SubAccountIDActionAttribute is the sub_account_id attribute of every action.
+func SubAccountIDActionAttribute() actionschema.StringAttribute {
+	return actionschema.StringAttribute{
+		MarkdownDescription: "The ID of the sub-account to act on, when the provider authenticates as a master account. Overrides the `sub_account_id` of the provider.",
+		Optional:            true,
+	}
+}
+
+// WithSubAccountID returns a context whose Zoom API requests are sent to the sub-account of a resource or data source.
+// When it is not set, ctx is returned as is, so the provider's sub_account_id applies.
+func WithSubAccountID(ctx context.Context, subAccountID types.String) context.Context {
+	if subAccountID.IsNull() || subAccountID.IsUnknown() || subAccountID.ValueString() == "" {
+		return ctx
+	}
+	return httpclient.WithSubAccountID(ctx, subAccountID.ValueString())
+}
+
+// ImportStatePassthroughID is resource.ImportStatePassthroughID that also accepts `<sub_account_id>:<id>`,
+// to import an object of a sub-account other than the provider's one. An import by the identity of IdentitySchema
+// copies its attributes instead.
+func ImportStatePassthroughID(ctx context.Context, attrPath path.Path, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" && req.Identity != nil {
+		var id, subAccountID types.String
+		resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, attrPath, &id)...)
+		resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root("sub_account_id"), &subAccountID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, attrPath, id)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("sub_account_id"), subAccountID)...)
+		return
+	}
+
+	subAccountID, id, ok := strings.Cut(req.ID, ":")
+	if !ok {
+		resource.ImportStatePassthroughID(ctx, attrPath, req, resp)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, attrPath, id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("sub_account_id"), subAccountID)...)
+}
@@ -0,0 +1,54 @@
+package shared
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The default timeouts of every resource. Zoom provisions some objects asynchronously, e.g. phone users,
+// which can take minutes on large accounts.
+const (
+	DefaultCreateTimeout = 10 * time.Minute
+	DefaultReadTimeout   = 5 * time.Minute
+	DefaultUpdateTimeout = 10 * time.Minute
+	DefaultDeleteTimeout = 10 * time.Minute
+)
+
+// TimeoutsBlock is the timeouts block of every resource.
+func TimeoutsBlock(ctx context.Context) schema.Block {
+	block := timeouts.Block(ctx, timeouts.Opts{
+		Create:            true,
+		Read:              true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: "How long to wait for the object to be created, including waiting for Zoom to provision it. Defaults to `" + durationString(DefaultCreateTimeout) + "`.",
+		ReadDescription:   "How long to wait for the object to be read. Defaults to `" + durationString(DefaultReadTimeout) + "`.",
+		UpdateDescription: "How long to wait for the object to be updated. Defaults to `" + durationString(DefaultUpdateTimeout) + "`.",
+		DeleteDescription: "How long to wait for the object to be deleted, including waiting for Zoom to remove it. Defaults to `" + durationString(DefaultDeleteTimeout) + "`.",
+	}).(schema.SingleNestedBlock)
+	block.MarkdownDescription = "The timeouts of the operations, given as durations such as `30s` or `15m`, including the retries of failed requests."
+	return block
+}
+
+// NullTimeouts is the timeouts of a state not built from a plan or another state, e.g. on import.
+func NullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+}
+
+// durationString formats d the way it is written in a configuration, e.g. 10m instead of 10m0s.
+func durationString(d time.Duration) string {
+	return strings.TrimSuffix(d.String(), "0s")
+}
@@ -0,0 +1,25 @@
+package shared_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+)
+
+func TestNullTimeouts(t *testing.T) {
+	ctx := context.Background()
+	value := shared.NullTimeouts()
+
+	if !value.Type(ctx).Equal(shared.TimeoutsBlock(ctx).Type()) {
+		t.Fatalf("expected the type of the timeouts block, got %s", value.Type(ctx))
+	}
+	createTimeout, diags := value.Create(ctx, shared.DefaultCreateTimeout)
+	if diags.HasError() || createTimeout != shared.DefaultCreateTimeout {
+		t.Fatalf("expected the default create timeout, got %v: %v", createTimeout, diags)
+	}
+	deleteTimeout, diags := value.Delete(ctx, shared.DefaultDeleteTimeout)
+	if diags.HasError() || deleteTimeout != shared.DefaultDeleteTimeout {
+		t.Fatalf("expected the default delete timeout, got %v: %v", deleteTimeout, diags)
+	}
+}
@@ -0,0 +1,42 @@
+package shared
+
+import (
+	"context"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/httpclient"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/folio-sec/terraform-provider-zoom"
+
+// StartSpan starts the span of a resource or data source method, under which the Zoom API operations are traced.
+// Data sources and list resources are named as in a Terraform address, e.g. data.zoom_phone_site.
+func StartSpan(ctx context.Context, typeName, method string) (context.Context, trace.Span) {
+	ctx = httpclient.WithTerraformMethod(ctx, method)
+	return otel.Tracer(tracerName).Start(ctx, typeName+"."+method,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			attribute.String("terraform.type_name", typeName),
+			attribute.String("terraform.method", method),
+		),
+	)
+}
+
+// EndSpan ends the span started by StartSpan with the error of the diagnostics if any.
+// It is to be deferred with a pointer to the response diagnostics, so that the final ones are recorded.
+func EndSpan(span trace.Span, diags *diag.Diagnostics) {
+	defer span.End()
+	for _, d := range diags.Errors() {
+		span.AddEvent("error", trace.WithAttributes(
+			attribute.String("summary", d.Summary()),
+			attribute.String("detail", d.Detail()),
+		))
+	}
+	if diags.HasError() {
+		span.SetStatus(codes.Error, diags.Errors()[0].Summary())
+	}
+}
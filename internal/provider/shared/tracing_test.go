@@ -0,0 +1,44 @@
+package shared_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEndSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	func() {
+		var diags diag.Diagnostics
+		_, span := shared.StartSpan(context.Background(), "zoom_phone_site", "Read")
+		defer shared.EndSpan(span, &diags)
+	}()
+	func() {
+		var diags diag.Diagnostics
+		_, span := shared.StartSpan(context.Background(), "zoom_phone_site", "Create")
+		defer shared.EndSpan(span, &diags)
+		diags.AddError("Error creating phone site", "unexpected status")
+	}()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].Name() != "zoom_phone_site.Read" || spans[0].Status().Code != codes.Unset {
+		t.Errorf("unexpected span: %s %v", spans[0].Name(), spans[0].Status())
+	}
+	if spans[1].Name() != "zoom_phone_site.Create" || spans[1].Status().Code != codes.Error {
+		t.Errorf("unexpected span: %s %v", spans[1].Name(), spans[1].Status())
+	}
+	if spans[1].Status().Description != "Error creating phone site" {
+		t.Errorf("unexpected status description: %s", spans[1].Status().Description)
+	}
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/httpclient"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// resolveTransportConfig reads the proxy and TLS settings. Each of them can be set in the provider config or by an
+// environment variable, and the PEM values can also be given as the path of a file holding them.
+func resolveTransportConfig(config zoomProviderModel) (httpclient.TransportConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var transportConfig httpclient.TransportConfig
+
+	if proxyURL := stringOrEnv(config.ProxyURL, "ZOOM_PROXY_URL"); proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") {
+			// the URL may hold the proxy credentials, so it is not shown
+			diags.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid proxy URL",
+				"The proxy URL must be an absolute http, https or socks5 URL. Please check the value in provider configuration or the ZOOM_PROXY_URL environment variable.",
+			)
+		} else {
+			transportConfig.ProxyURL = u
+		}
+	}
+
+	for _, pem := range []struct {
+		attr   string
+		env    string
+		config types.String
+		value  *[]byte
+	}{
+		{"ca_bundle", "ZOOM_CA_BUNDLE", config.CABundle, &transportConfig.CABundle},
+		{"client_certificate", "ZOOM_CLIENT_CERTIFICATE", config.ClientCertificate, &transportConfig.ClientCertificate},
+		{"client_key", "ZOOM_CLIENT_KEY", config.ClientKey, &transportConfig.ClientKey},
+	} {
+		b, err := readPEM(stringOrEnv(pem.config, pem.env))
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root(pem.attr),
+				"Unable to read PEM file",
+				fmt.Sprintf("The provider cannot read the file of %s. Please check the value in provider configuration or the %s environment variable. Error: %s", pem.attr, pem.env, err.Error()),
+			)
+			continue
+		}
+		*pem.value = b
+	}
+
+	if (len(transportConfig.ClientCertificate) == 0) != (len(transportConfig.ClientKey) == 0) {
+		diags.AddAttributeError(
+			path.Root("client_certificate"),
+			"Incomplete client certificate",
+			"Both client_certificate and client_key must be set for mutual TLS.",
+		)
+	}
+	return transportConfig, diags
+}
+
+// readPEM returns the value as is when it is PEM encoded, or otherwise the content of the file at the path.
+func readPEM(value string) ([]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
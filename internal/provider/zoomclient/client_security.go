@@ -5,26 +5,35 @@ import (
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomuser"
+	"github.com/folio-sec/terraform-provider-zoom/internal/zoomoauth"
 )
 
 type ZoomPhoneClientSecurity struct {
-	AccessToken string
+	TokenSource *zoomoauth.TokenSource
 }
 
 func (c ZoomPhoneClientSecurity) OpenapiAuthorization(_ context.Context, _ string) (zoomphone.OpenapiAuthorization, error) {
 	return zoomphone.OpenapiAuthorization{}, nil
 }
-func (c ZoomPhoneClientSecurity) OpenapiOAuth(_ context.Context, _ string) (zoomphone.OpenapiOAuth, error) {
-	return zoomphone.OpenapiOAuth{Token: c.AccessToken}, nil
+func (c ZoomPhoneClientSecurity) OpenapiOAuth(ctx context.Context, _ string) (zoomphone.OpenapiOAuth, error) {
+	token, err := c.TokenSource.Token(ctx)
+	if err != nil {
+		return zoomphone.OpenapiOAuth{}, err
+	}
+	return zoomphone.OpenapiOAuth{Token: token}, nil
 }
 
 type ZoomUserClientSecurity struct {
-	AccessToken string
+	TokenSource *zoomoauth.TokenSource
 }
 
 func (c ZoomUserClientSecurity) OpenapiAuthorization(_ context.Context, _ string) (zoomuser.OpenapiAuthorization, error) {
 	return zoomuser.OpenapiAuthorization{}, nil
 }
-func (c ZoomUserClientSecurity) OpenapiOAuth(_ context.Context, _ string) (zoomuser.OpenapiOAuth, error) {
-	return zoomuser.OpenapiOAuth{Token: c.AccessToken}, nil
+func (c ZoomUserClientSecurity) OpenapiOAuth(ctx context.Context, _ string) (zoomuser.OpenapiOAuth, error) {
+	token, err := c.TokenSource.Token(ctx)
+	if err != nil {
+		return zoomuser.OpenapiOAuth{}, err
+	}
+	return zoomuser.OpenapiOAuth{Token: token}, nil
 }
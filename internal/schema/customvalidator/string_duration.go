@@ -0,0 +1,37 @@
+package customvalidator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+func StringDuration() stringDuration {
+	return stringDuration{}
+}
+
+type stringDuration struct{}
+
+func (v stringDuration) Description(ctx context.Context) string {
+	return "Value must be a positive duration such as 30s or 1m30s"
+}
+func (v stringDuration) MarkdownDescription(ctx context.Context) string {
+	return "Value must be a positive duration such as `30s` or `1m30s`"
+}
+
+func (v stringDuration) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if d, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid value provided",
+			fmt.Sprintf("Value must be a positive duration such as 30s or 1m30s, got: %q.", req.ConfigValue.ValueString()),
+		)
+		return
+	}
+}
@@ -0,0 +1,150 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &tfEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &tfEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithRenew     = &tfEphemeralResource{}
+)
+
+// renewBefore is how long before the expiry Terraform is asked to renew the token, the same margin the provider
+// itself keeps before it fetches a new token.
+const renewBefore = 5 * time.Minute
+
+// privateExpiresAtKey is the private data key of the expiry of the opened token.
+const privateExpiresAtKey = "expires_at"
+
+func NewOAuthTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &tfEphemeralResource{}
+}
+
+type tfEphemeralResource struct {
+	providerData *shared.ProviderData
+}
+
+type ephemeralModel struct {
+	AccessToken types.String      `tfsdk:"access_token"`
+	TokenType   types.String      `tfsdk:"token_type"`
+	ExpiresAt   timetypes.RFC3339 `tfsdk:"expires_at"`
+	Scopes      []types.String    `tfsdk:"scopes"`
+}
+
+func (r *tfEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*shared.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.providerData = data
+}
+
+func (r *tfEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oauth_token"
+}
+
+func (r *tfEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `An OAuth access token of the provider's credentials, e.g. to call the Zoom API endpoints the provider does not cover with the ` + "`http`" + ` provider.
+Being ephemeral, the token is never written to the plan or the state.
+
+The token is the one the provider itself uses, so it is not revoked when Terraform closes it, and it lapses at ` + "`expires_at`" + `.
+Renewal is not supported: Zoom access tokens cannot be extended, and Terraform cannot pass a new token to the consumers of an opened one.
+When Terraform still uses the token shortly before it expires, the provider only warns that the consumers of it may get 401 responses.
+Split a run longer than the token lifetime, which is one hour, into several ones.`,
+		Attributes: map[string]schema.Attribute{
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "The access token to be sent as `Authorization: Bearer <access_token>`.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"token_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the token, which is `bearer`.",
+				Computed:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "When the token expires in RFC3339 format, or null when Zoom did not tell it.",
+				Computed:            true,
+				CustomType:          timetypes.RFC3339Type{},
+			},
+			"scopes": schema.ListAttribute{
+				MarkdownDescription: "The OAuth scopes granted to the token, or null when Zoom did not tell them, e.g. for a pre-issued `access_token`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (r *tfEphemeralResource) Open(ctx context.Context, _ ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_oauth_token", "Open")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
+	info, err := r.providerData.TokenSource.TokenInfo(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to get Zoom API access token",
+			fmt.Sprintf("Unable to get access token, got error: %s", err),
+		)
+		return
+	}
+
+	result := ephemeralModel{
+		AccessToken: types.StringValue(info.AccessToken),
+		TokenType:   types.StringValue(info.TokenType),
+		ExpiresAt:   timetypes.NewRFC3339Null(),
+	}
+	if info.Scopes != nil {
+		result.Scopes = make([]types.String, 0, len(info.Scopes))
+		for _, scope := range info.Scopes {
+			result.Scopes = append(result.Scopes, types.StringValue(scope))
+		}
+	}
+	if !info.Expiry.IsZero() {
+		result.ExpiresAt = timetypes.NewRFC3339TimeValue(info.Expiry.UTC())
+		resp.RenewAt = info.Expiry.Add(-renewBefore)
+		// the private data must be JSON
+		expiresAt, _ := json.Marshal(info.Expiry.UTC().Format(time.RFC3339))
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateExpiresAtKey, expiresAt)...)
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &result)...)
+
+	tflog.Info(ctx, "opened zoom oauth token", map[string]interface{}{
+		"expires_at": result.ExpiresAt.ValueString(),
+	})
+}
+
+// Renew does not renew the token but warns that it is about to expire, as Zoom access tokens cannot be extended
+// and the value passed to the consumers cannot be replaced. RenewAt is set by Open only to get this warning.
+func (r *tfEphemeralResource) Renew(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse) {
+	value, diags := req.Private.GetKey(ctx, privateExpiresAtKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var expiresAt string
+	_ = json.Unmarshal(value, &expiresAt)
+	resp.Diagnostics.AddWarning(
+		"Zoom access token is about to expire",
+		fmt.Sprintf("The access token of zoom_oauth_token expires at %s and cannot be extended. "+
+			"Requests made with it afterwards, e.g. by the http provider, are rejected by Zoom, so split a run longer than the token lifetime into several ones.", expiresAt),
+	)
+}
@@ -0,0 +1,83 @@
+package token_test
+
+import (
+	"context"
+	"maps"
+	"regexp"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/services/oauth/token"
+	"github.com/folio-sec/terraform-provider-zoom/internal/zoomoauth"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccEphemeralResourceOAuthToken(t *testing.T) {
+	providerFactories := maps.Clone(acceptance.ProviderFactories(t))
+	providerFactories["echo"] = echoprovider.NewProviderServer()
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acceptance.ProviderConfig + `
+ephemeral "zoom_oauth_token" "test" {}
+
+provider "echo" {
+  data = {
+    token_type = ephemeral.zoom_oauth_token.test.token_type
+    has_token  = ephemeral.zoom_oauth_token.test.access_token != ""
+    expires_at = ephemeral.zoom_oauth_token.test.expires_at
+  }
+}
+
+resource "echo" "test" {}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("token_type"), knownvalue.StringRegexp(regexp.MustCompile(`(?i)^bearer$`))),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("has_token"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("expires_at"), knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
+
+func TestEphemeralResourceOAuthTokenWithoutScopes(t *testing.T) {
+	ctx := context.Background()
+	r := token.NewOAuthTokenEphemeralResource()
+	r.(ephemeral.EphemeralResourceWithConfigure).Configure(ctx, ephemeral.ConfigureRequest{ProviderData: &shared.ProviderData{
+		TokenSource: zoomoauth.NewStaticTokenSource("token"),
+	}}, &ephemeral.ConfigureResponse{})
+	schemaResp := &ephemeral.SchemaResponse{}
+	r.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+
+	resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		Schema: schemaResp.Schema,
+	}}
+	r.Open(ctx, ephemeral.OpenRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var scopes types.List
+	resp.Result.GetAttribute(ctx, path.Root("scopes"), &scopes)
+	if !scopes.IsNull() {
+		t.Fatalf("expected null scopes for a token Zoom told no scopes of, got %s", scopes)
+	}
+}
@@ -2,22 +2,42 @@ package autoreceptionist
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"iter"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
 )
 
-func newCrud(client *zoomphone.Client) *crud {
+func newCrud(client *zoomphone.Client, cache *shared.Cache, locks *shared.ObjectLocks) *crud {
 	return &crud{
 		client: client,
+		cache:  cache,
+		locks:  locks,
 	}
 }
 
 type crud struct {
 	client *zoomphone.Client
+	cache  *shared.Cache
+	locks  *shared.ObjectLocks
+}
+
+// list iterates over the auto receptionists of the account, for the list resource.
+func (c *crud) list(ctx context.Context) iter.Seq2[zoomphone.ListAutoReceptionistsOKAutoReceptionistsItem, error] {
+	return util.Paginate(ctx, 100, func(ctx context.Context, pageSize int, nextPageToken string) ([]zoomphone.ListAutoReceptionistsOKAutoReceptionistsItem, string, error) {
+		res, err := c.client.ListAutoReceptionists(ctx, zoomphone.ListAutoReceptionistsParams{
+			NextPageToken: util.ToPhoneOptPageToken(nextPageToken),
+			PageSize:      zoomphone.NewOptInt(pageSize), // max 100
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return res.AutoReceptionists, res.NextPageToken.Value, nil
+	})
 }
 
 func (c *crud) read(ctx context.Context, autoReceptionistID types.String) (*readDto, error) {
@@ -25,13 +45,10 @@ func (c *crud) read(ctx context.Context, autoReceptionistID types.String) (*read
 		AutoReceptionistId: autoReceptionistID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 300 {
-				return nil, nil // already deleted
-			}
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
-		return nil, fmt.Errorf("unable to read phone auto receptionist: %v", err)
+		return nil, fmt.Errorf("unable to read phone auto receptionist: %w", err)
 	}
 
 	var site *readDtoSite
@@ -56,26 +73,54 @@ func (c *crud) read(ctx context.Context, autoReceptionistID types.String) (*read
 }
 
 func (c *crud) create(ctx context.Context, dto *createDto) (*createdDto, error) {
-	res, err := c.client.AddAutoReceptionist(ctx, zoomphone.OptAddAutoReceptionistReq{
-		Value: zoomphone.AddAutoReceptionistReq{
-			Name:   dto.name.ValueString(),
-			SiteID: util.ToPhoneOptString(dto.siteID),
-		},
-		Set: true,
+	autoReceptionistID, err := shared.ReconcileCreate(ctx, func(ctx context.Context) ([]string, error) {
+		return c.matching(ctx, dto)
+	}, func() (string, error) {
+		defer c.cache.InvalidateAutoReceptionists()
+
+		res, err := c.client.AddAutoReceptionist(ctx, zoomphone.OptAddAutoReceptionistReq{
+			Value: zoomphone.AddAutoReceptionistReq{
+				Name:   dto.name.ValueString(),
+				SiteID: util.ToPhoneOptString(dto.siteID),
+			},
+			Set: true,
+		})
+		if err != nil {
+			return "", err
+		}
+		return res.ID.Value, nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error creating phone auto receptionist: %v", err)
+		return nil, fmt.Errorf("error creating phone auto receptionist: %w", err)
 	}
 
 	return &createdDto{
-		autoReceptionistID: util.FromOptString(res.ID),
-		name:               util.FromOptString(res.Name),
-		extensionNumber:    util.FromOptInt64(res.ExtensionNumber),
+		autoReceptionistID: types.StringValue(autoReceptionistID),
 	}, nil
 }
 
+// matching returns the IDs of the auto receptionists of the name and site of a create request.
+func (c *crud) matching(ctx context.Context, dto *createDto) ([]string, error) {
+	autoReceptionists, err := c.cache.AutoReceptionists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return lo.FilterMap(autoReceptionists, func(item zoomphone.ListAutoReceptionistsOKAutoReceptionistsItem, _ int) (string, bool) {
+		return item.ID.Value, item.Name.Value == dto.name.ValueString() &&
+			(dto.siteID.IsNull() || item.Site.Value.ID.Value == dto.siteID.ValueString())
+	}), nil
+}
+
 func (c *crud) update(ctx context.Context, dto *updateDto) error {
-	err := c.client.UpdateAutoReceptionist(ctx, zoomphone.OptUpdateAutoReceptionistReq{
+	unlock, err := c.locks.Lock(ctx, "auto_receptionist", dto.autoReceptionistID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidateAutoReceptionists()
+
+	err = c.client.UpdateAutoReceptionist(ctx, zoomphone.OptUpdateAutoReceptionistReq{
 		Value: zoomphone.UpdateAutoReceptionistReq{
 			// CostCenter/Department: to remove it, need to pass empty string. not null.
 			CostCenter:          zoomphone.NewOptString(util.ToPhoneOptString(dto.costCenter).Or("")),
@@ -90,24 +135,29 @@ func (c *crud) update(ctx context.Context, dto *updateDto) error {
 		AutoReceptionistId: dto.autoReceptionistID.ValueString(),
 	})
 	if err != nil {
-		return fmt.Errorf("error updating phone auto receptionist: %v", err)
+		return fmt.Errorf("error updating phone auto receptionist: %w", err)
 	}
 
 	return nil
 }
 
 func (c *crud) delete(ctx context.Context, autoReceptionistId types.String) error {
-	err := c.client.DeleteAutoReceptionist(ctx, zoomphone.DeleteAutoReceptionistParams{
+	unlock, err := c.locks.Lock(ctx, "auto_receptionist", autoReceptionistId.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidateAutoReceptionists()
+
+	err = c.client.DeleteAutoReceptionist(ctx, zoomphone.DeleteAutoReceptionistParams{
 		AutoReceptionistId: autoReceptionistId.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 404 {
-				return nil
-			}
+		if util.IsNotFound(err) {
+			return nil
 		}
-		return fmt.Errorf("error deleting phone auto receptionist: %v", err)
+		return fmt.Errorf("error deleting phone auto receptionist: %w", err)
 	}
 
 	return nil
@@ -3,27 +3,35 @@ package autoreceptionist
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/samber/lo"
 )
 
 var (
-	_ datasource.DataSource              = &tfDataSource{}
-	_ datasource.DataSourceWithConfigure = &tfDataSource{}
+	_ datasource.DataSource                   = &tfDataSource{}
+	_ datasource.DataSourceWithConfigure      = &tfDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &tfDataSource{}
 )
 
+// dataSourceScopes are the OAuth scopes this data source requires.
+var dataSourceScopes = shared.Scopes{
+	"phone:read:auto_receptionist:admin",
+}
+
 func NewPhoneAutoReceptionistDataSource() datasource.DataSource {
 	return &tfDataSource{}
 }
 
 type tfDataSource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (d *tfDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -38,7 +46,12 @@ func (d *tfDataSource) Configure(_ context.Context, req datasource.ConfigureRequ
 		)
 		return
 	}
-	d.crud = newCrud(data.PhoneClient)
+	d.crud = newCrud(data.PhoneClient, data.Cache, data.Locks)
+	d.providerData = data
+}
+
+func (d *tfDataSource) ValidateConfig(_ context.Context, _ datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(d.providerData.ValidateScopes(dataSourceScopes)...)
 }
 
 func (d *tfDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -52,9 +65,7 @@ func (d *tfDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, res
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:auto_receptionist:admin`",
-		}, ", "),
+This resource requires the ` + dataSourceScopes.Markdown(),
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Required:            true,
@@ -101,6 +112,7 @@ This resource requires the ` + strings.Join([]string{
 					},
 				},
 			},
+			"sub_account_id": shared.SubAccountIDDataSourceAttribute(),
 		},
 	}
 }
@@ -115,6 +127,7 @@ type dataSourceModel struct {
 	Timezone            types.String         `tfsdk:"timezone"`
 	AudioPromptLanguage types.String         `tfsdk:"audio_prompt_language"`
 	Site                *dataSourceModelSite `tfsdk:"site"`
+	SubAccountID        types.String         `tfsdk:"sub_account_id"`
 }
 
 type dataSourceModelSite struct {
@@ -123,15 +136,19 @@ type dataSourceModelSite struct {
 }
 
 func (d *tfDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "data.zoom_phone_auto_receptionist", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var data dataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, data.SubAccountID)
 
 	dto, err := d.crud.read(ctx, data.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone auto receptionist", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error reading phone auto receptionist", err))
 		return
 	}
 
@@ -154,6 +171,7 @@ func (d *tfDataSource) Read(ctx context.Context, req datasource.ReadRequest, res
 				Name: dto.site.name,
 			}
 		}, lo.Nil),
+		SubAccountID: data.SubAccountID,
 	}
 	diags := resp.State.Set(ctx, &output)
 	resp.Diagnostics.Append(diags...)
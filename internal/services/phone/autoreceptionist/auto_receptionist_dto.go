@@ -26,8 +26,6 @@ type createDto struct {
 
 type createdDto struct {
 	autoReceptionistID types.String
-	name               types.String
-	extensionNumber    types.Int64
 }
 
 type updateDto struct {
@@ -3,9 +3,10 @@ package autoreceptionist
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -17,17 +18,28 @@ import (
 )
 
 var (
-	_ resource.Resource                = &tfResource{}
-	_ resource.ResourceWithConfigure   = &tfResource{}
-	_ resource.ResourceWithImportState = &tfResource{}
+	_ resource.Resource                   = &tfResource{}
+	_ resource.ResourceWithConfigure      = &tfResource{}
+	_ resource.ResourceWithImportState    = &tfResource{}
+	_ resource.ResourceWithIdentity       = &tfResource{}
+	_ resource.ResourceWithValidateConfig = &tfResource{}
 )
 
+// resourceScopes are the OAuth scopes this resource requires.
+var resourceScopes = shared.Scopes{
+	"phone:read:auto_receptionist:admin",
+	"phone:write:auto_receptionist:admin",
+	"phone:update:auto_receptionist:admin",
+	"phone:delete:auto_receptionist:admin",
+}
+
 func NewPhoneAutoReceptionistResource() resource.Resource {
 	return &tfResource{}
 }
 
 type tfResource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -42,29 +54,30 @@ func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest,
 		)
 		return
 	}
-	r.crud = newCrud(data.PhoneClient)
+	r.crud = newCrud(data.PhoneClient, data.Cache, data.Locks)
+	r.providerData = data
+}
+
+func (r *tfResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(resourceScopes)...)
 }
 
 func (r *tfResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_phone_auto_receptionist"
 }
 
-func (r *tfResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tfResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Auto receptionists answer calls with a personalized recording and routes calls to a phone user, call queue, common area, voicemail or an IVR system.
 
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:auto_receptionist:admin`",
-			"`phone:write:auto_receptionist:admin`",
-			"`phone:update:auto_receptionist:admin`",
-			"`phone:delete:auto_receptionist:admin`",
-		}, ", ") + ".",
+This resource requires the ` + resourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 				MarkdownDescription: "Auto receptionist ID. The unique identifier of the auto receptionist.",
 			},
 			"cost_center": schema.StringAttribute{
@@ -77,6 +90,7 @@ This resource requires the ` + strings.Join([]string{
 			},
 			"extension_id": schema.StringAttribute{
 				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 				MarkdownDescription: "Extension ID.",
 			},
 			"extension_number": schema.Int64Attribute{
@@ -109,33 +123,57 @@ This resource requires the ` + strings.Join([]string{
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
 				MarkdownDescription: "Unique identifier of the site where the auto receptionist is to be assigned. This field is required only if you have [multiple sites](https://support.zoom.us/hc/en-us/articles/360020809672-Managing-Multiple-Sites) enabled.",
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
 
 type resourceModel struct {
-	ID                  types.String `tfsdk:"id"`
-	CostCenter          types.String `tfsdk:"cost_center"`
-	Department          types.String `tfsdk:"department"`
-	ExtensionID         types.String `tfsdk:"extension_id"`
-	ExtensionNumber     types.Int64  `tfsdk:"extension_number"`
-	Name                types.String `tfsdk:"name"`
-	Timezone            types.String `tfsdk:"timezone"`
-	AudioPromptLanguage types.String `tfsdk:"audio_prompt_language"`
-	SiteID              types.String `tfsdk:"site_id"`
+	ID                  types.String   `tfsdk:"id"`
+	CostCenter          types.String   `tfsdk:"cost_center"`
+	Department          types.String   `tfsdk:"department"`
+	ExtensionID         types.String   `tfsdk:"extension_id"`
+	ExtensionNumber     types.Int64    `tfsdk:"extension_number"`
+	Name                types.String   `tfsdk:"name"`
+	Timezone            types.String   `tfsdk:"timezone"`
+	AudioPromptLanguage types.String   `tfsdk:"audio_prompt_language"`
+	SiteID              types.String   `tfsdk:"site_id"`
+	SubAccountID        types.String   `tfsdk:"sub_account_id"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *tfResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = shared.IdentitySchema("id")
 }
 
 func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_auto_receptionist", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	// The identity is set even when the object has been deleted outside of Terraform, as it does not change.
+	resp.Diagnostics.Append(shared.SetIdentity(ctx, resp.Identity, "id", state.ID, state.SubAccountID)...)
 
-	output, err := r.read(ctx, state.ID)
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	output, err := r.read(ctx, state.ID, state.SubAccountID, state.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone auto receptionist", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error reading phone auto receptionist", err))
 		return
 	}
 
@@ -146,10 +184,10 @@ func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 	}
 }
 
-func (r *tfResource) read(ctx context.Context, autoReceptionistId types.String) (*resourceModel, error) {
+func (r *tfResource) read(ctx context.Context, autoReceptionistId types.String, subAccountID types.String, timeouts timeouts.Value) (*resourceModel, error) {
 	dto, err := r.crud.read(ctx, autoReceptionistId)
 	if err != nil {
-		return nil, fmt.Errorf("error read: %v", err)
+		return nil, fmt.Errorf("error read: %w", err)
 	}
 	if dto == nil {
 		return nil, nil // already deleted
@@ -170,26 +208,38 @@ func (r *tfResource) read(ctx context.Context, autoReceptionistId types.String)
 		Timezone:            dto.timezone,
 		AudioPromptLanguage: dto.audioPromptLanguage,
 		SiteID:              siteID,
+		SubAccountID:        subAccountID,
+		Timeouts:            timeouts,
 	}, nil
 }
 
 func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_auto_receptionist", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_auto_receptionist")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	ret, err := r.crud.create(ctx, &createDto{
 		name:   plan.Name,
 		siteID: plan.SiteID,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone auto receptionist",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone auto receptionist", err))
 		return
 	}
 	err = r.crud.update(ctx, &updateDto{
@@ -204,16 +254,13 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 	if err != nil {
 		// TODO change delete logic with marking resource as taint
 		_ = r.crud.delete(ctx, ret.autoReceptionistID)
-		resp.Diagnostics.AddError(
-			"Error creating phone auto receptionist on updating",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone auto receptionist on updating", err))
 		return
 	}
 
-	output, err := r.read(ctx, ret.autoReceptionistID)
+	output, err := r.read(ctx, ret.autoReceptionistID, plan.SubAccountID, plan.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating phone auto receptionist on reading", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone auto receptionist on reading", err))
 		return
 	}
 
@@ -222,9 +269,13 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(shared.SetIdentityFromState(ctx, resp.State, resp.Identity, "id")...)
 }
 
 func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_auto_receptionist", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -235,6 +286,16 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		)
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_auto_receptionist")
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, shared.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	if err := r.crud.update(ctx, &updateDto{
 		autoReceptionistID:  plan.ID,
@@ -245,20 +306,13 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		timezone:            plan.Timezone,
 		audioPromptLanguage: plan.AudioPromptLanguage,
 	}); err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating phone auto receptionist",
-			fmt.Sprintf(
-				"Could not update phone auto receptionist %s, unexpected error: %s",
-				plan.ID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error updating phone auto receptionist", err))
 		return
 	}
 
-	output, err := r.read(ctx, plan.ID)
+	output, err := r.read(ctx, plan.ID, plan.SubAccountID, plan.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating phone auto receptionist", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error updating phone auto receptionist", err))
 		return
 	}
 
@@ -267,25 +321,32 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(shared.SetIdentityFromState(ctx, resp.State, resp.Identity, "id")...)
 }
 
 func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_auto_receptionist", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_auto_receptionist")
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	if err := r.crud.delete(ctx, state.ID); err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone auto receptionist",
-			fmt.Sprintf(
-				"Could not delete phone auto receptionist %s, unexpected error: %s",
-				state.ID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error deleting phone auto receptionist", err))
 		return
 	}
 
@@ -295,5 +356,5 @@ func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 }
 
 func (r *tfResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	shared.ImportStateByKey(ctx, path.Root("id"), req, resp, "auto receptionist", r.providerData.Cache.AutoReceptionistImportKey)
 }
@@ -0,0 +1,84 @@
+package autoreceptionist_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccPhoneAutoReceptionist(t *testing.T) {
+	td := acceptance.NewTestData(t, "zoom_phone_auto_receptionist", "test")
+	siteID := acceptance.SiteID(t)
+	name := fmt.Sprintf("acctest-%s", td.RandomStringOfLength(5))
+	updatedName := fmt.Sprintf("acctest-%s", td.RandomStringOfLength(5))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactories(t),
+		CheckDestroy: acceptance.CheckDestroy(td.TerraformResourceType, func(ctx context.Context, rs *terraform.ResourceState) error {
+			_, err := acceptance.ProviderData(t).PhoneClient.GetAutoReceptionistDetail(ctx, zoomphone.GetAutoReceptionistDetailParams{
+				AutoReceptionistId: rs.Primary.ID,
+			})
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: acceptance.ProviderConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  name             = %[3]q
+  extension_number = 81041
+  site_id          = %[4]q
+}
+`, td.TerraformResourceType, td.ResourceLabel, name, siteID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "name", name),
+					resource.TestCheckResourceAttr(td.ResourceName, "extension_number", "81041"),
+					resource.TestCheckResourceAttr(td.ResourceName, "site_id", siteID),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "id"),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "extension_id"),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "timezone"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      td.ResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState testing by extension number
+			{
+				ResourceName:      td.ResourceName,
+				ImportState:       true,
+				ImportStateIdFunc: acceptance.ImportStateIDFromAttribute(td.ResourceName, "extension_number"),
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: acceptance.ProviderConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  name                  = %[3]q
+  extension_number      = 81042
+  site_id               = %[4]q
+  cost_center           = "acctest-cost-center"
+  department            = "acctest-department"
+  timezone              = "Asia/Tokyo"
+  audio_prompt_language = "ja"
+}
+`, td.TerraformResourceType, td.ResourceLabel, updatedName, siteID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "name", updatedName),
+					resource.TestCheckResourceAttr(td.ResourceName, "extension_number", "81042"),
+					resource.TestCheckResourceAttr(td.ResourceName, "cost_center", "acctest-cost-center"),
+					resource.TestCheckResourceAttr(td.ResourceName, "department", "acctest-department"),
+					resource.TestCheckResourceAttr(td.ResourceName, "timezone", "Asia/Tokyo"),
+					resource.TestCheckResourceAttr(td.ResourceName, "audio_prompt_language", "ja"),
+				),
+			},
+		},
+	})
+}
@@ -2,23 +2,25 @@ package autoreceptionistivr
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func newCrud(client *zoomphone.Client) *crud {
+func newCrud(client *zoomphone.Client, locks *shared.ObjectLocks) *crud {
 	return &crud{
 		client: client,
+		locks:  locks,
 	}
 }
 
 type crud struct {
 	client *zoomphone.Client
+	locks  *shared.ObjectLocks
 }
 
 func (c *crud) read(ctx context.Context, autoReceptionistID, hoursType, holidayID types.String) (*readDto, error) {
@@ -28,11 +30,8 @@ func (c *crud) read(ctx context.Context, autoReceptionistID, hoursType, holidayI
 		HolidayID:          util.ToPhoneOptString(holidayID),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 300 {
-				return nil, nil // already deleted
-			}
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
 		return nil, fmt.Errorf("unable to read phone auto receptionist ivr: %w", err)
 	}
@@ -98,6 +97,12 @@ func (c *crud) read(ctx context.Context, autoReceptionistID, hoursType, holidayI
 }
 
 func (c *crud) update(ctx context.Context, dto *updateDto) error {
+	unlock, err := c.locks.Lock(ctx, "auto_receptionist", dto.autoReceptionistID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// zoom update api allows only one keyAction, so update process do followings
 	// 1. update fields except keyActions by (auto_reception_id x holiday_id x hours_type)
 	// 2. update keyActions by (auto_reception_id x holiday_id x hours_type)
@@ -110,7 +115,7 @@ func (c *crud) update(ctx context.Context, dto *updateDto) error {
 			ForwardToExtensionID: util.ToPhoneOptString(dto.callerEntersNoAction.forwardToExtensionID),
 		})
 	}
-	err := c.client.UpdateAutoReceptionistIVR(ctx, zoomphone.OptUpdateAutoReceptionistIVRReq{
+	err = c.client.UpdateAutoReceptionistIVR(ctx, zoomphone.OptUpdateAutoReceptionistIVRReq{
 		Value: zoomphone.UpdateAutoReceptionistIVRReq{
 			HolidayID:            util.ToPhoneOptString(dto.holidayID),
 			HoursType:            util.ToPhoneOptString(dto.hoursType),
@@ -122,7 +127,7 @@ func (c *crud) update(ctx context.Context, dto *updateDto) error {
 		AutoReceptionistId: dto.autoReceptionistID.ValueString(),
 	})
 	if err != nil {
-		return fmt.Errorf("error updating phone auto receptionist ivr: %v", err)
+		return fmt.Errorf("error updating phone auto receptionist ivr: %w", err)
 	}
 
 	for _, keyAction := range dto.keyActions {
@@ -149,7 +154,7 @@ func (c *crud) update(ctx context.Context, dto *updateDto) error {
 			AutoReceptionistId: dto.autoReceptionistID.ValueString(),
 		})
 		if err != nil {
-			return fmt.Errorf("error updating phone auto receptionist ivr on key=%s: %v", keyAction.key, err)
+			return fmt.Errorf("error updating phone auto receptionist ivr on key=%s: %w", keyAction.key, err)
 		}
 	}
 	return nil
@@ -203,7 +208,7 @@ func (c *crud) delete(ctx context.Context, autoReceptionistID, hoursType, holida
 		keyActions: keyActions,
 	})
 	if err != nil {
-		return fmt.Errorf("error deleting phone auto receptionist ivr: %v", err)
+		return fmt.Errorf("error deleting phone auto receptionist ivr: %w", err)
 	}
 	return nil
 }
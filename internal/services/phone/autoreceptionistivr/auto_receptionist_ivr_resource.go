@@ -3,9 +3,10 @@ package autoreceptionistivr
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -20,19 +21,27 @@ import (
 )
 
 var (
-	_                 resource.Resource                = &tfResource{}
-	_                 resource.ResourceWithConfigure   = &tfResource{}
-	_                 resource.ResourceWithImportState = &tfResource{}
-	allKeys                                            = []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "*", "#"}
-	keyActionDisabled                                  = int32(-1)
+	_                 resource.Resource                   = &tfResource{}
+	_                 resource.ResourceWithConfigure      = &tfResource{}
+	_                 resource.ResourceWithImportState    = &tfResource{}
+	_                 resource.ResourceWithValidateConfig = &tfResource{}
+	allKeys                                               = []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "*", "#"}
+	keyActionDisabled                                     = int32(-1)
 )
 
+// resourceScopes are the OAuth scopes this resource requires.
+var resourceScopes = shared.Scopes{
+	"phone:read:auto_receptionist_ivr:admin",
+	"phone:update:auto_receptionist_ivr:admin",
+}
+
 func NewPhoneAutoReceptionistIvrResource() resource.Resource {
 	return &tfResource{}
 }
 
 type tfResource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -47,24 +56,26 @@ func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest,
 		)
 		return
 	}
-	r.crud = newCrud(data.PhoneClient)
+	r.crud = newCrud(data.PhoneClient, data.Locks)
+	r.providerData = data
+}
+
+func (r *tfResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(resourceScopes)...)
 }
 
 func (r *tfResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_phone_auto_receptionist_ivr"
 }
 
-func (r *tfResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tfResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `[interactive voice response (IVR) system](https://support.zoom.us/hc/en-us/articles/360038601971) of the specified auto receptionist.
 
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:auto_receptionist_ivr:admin`",
-			"`phone:update:auto_receptionist_ivr:admin`",
-		}, ", ") + ".",
+This resource requires the ` + resourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"auto_receptionist_id": schema.StringAttribute{
 				Required:            true,
@@ -235,6 +246,10 @@ This resource requires the ` + strings.Join([]string{
 					},
 				},
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -246,6 +261,8 @@ type resourceModel struct {
 	AudioPrompt          *resourceModelAudioPrompt          `tfsdk:"audio_prompt"`
 	CallerEntersNoAction *resourceModelCallerEntersNoAction `tfsdk:"caller_enters_no_action"`
 	KeyActions           map[string]*resourceModelKeyAction `tfsdk:"key_actions"`
+	SubAccountID         types.String                       `tfsdk:"sub_account_id"`
+	Timeouts             timeouts.Value                     `tfsdk:"timeouts"`
 }
 
 type resourceModelAudioPrompt struct {
@@ -286,16 +303,28 @@ type resourceModelKeyActionVoicemailGreeting struct {
 }
 
 func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_auto_receptionist_ivr", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	output, err := r.read(ctx, state)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone auto receptionist ivr", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("auto_receptionist_id"), "Error reading phone auto receptionist ivr", err))
 		return
 	}
 
@@ -309,7 +338,7 @@ func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 func (r *tfResource) read(ctx context.Context, model resourceModel) (*resourceModel, error) {
 	dto, err := r.crud.read(ctx, model.AutoReceptionistID, model.HoursType, model.HolidayID)
 	if err != nil {
-		return nil, fmt.Errorf("error read: %v", err)
+		return nil, fmt.Errorf("error read: %w", err)
 	}
 	if dto == nil {
 		return nil, nil // already deleted
@@ -394,16 +423,31 @@ func (r *tfResource) read(ctx context.Context, model resourceModel) (*resourceMo
 		AudioPrompt:          audioPrompt,
 		CallerEntersNoAction: callerEntersNoAction,
 		KeyActions:           keyActions,
+		SubAccountID:         model.SubAccountID,
+		Timeouts:             model.Timeouts,
 	}, nil
 }
 
 func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_auto_receptionist_ivr", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_auto_receptionist_ivr")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	// auto receptionist ivr is created when creating auto receptionist
 	// so in create, we only update the auto receptionist ivr
@@ -413,16 +457,13 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 		"req":  updateRequest,
 	})
 	if err := r.crud.update(ctx, updateRequest); err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone auto receptionist ivr",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("auto_receptionist_id"), "Error creating phone auto receptionist ivr", err))
 		return
 	}
 
 	output, err := r.read(ctx, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating phone auto receptionist ivr on reading", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("auto_receptionist_id"), "Error creating phone auto receptionist ivr on reading", err))
 		return
 	}
 
@@ -434,6 +475,9 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 }
 
 func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_auto_receptionist_ivr", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -444,6 +488,16 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		)
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_auto_receptionist_ivr")
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, shared.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	updateRequest := r.buildUpdateDto(plan)
 	tflog.Debug(ctx, "phone auto receptionist ivr update request", map[string]interface{}{
@@ -451,20 +505,13 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		"req":  updateRequest,
 	})
 	if err := r.crud.update(ctx, updateRequest); err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating phone auto receptionist ivr",
-			fmt.Sprintf(
-				"Could not update phone auto receptionist ivr %s, unexpected error: %s",
-				plan.AutoReceptionistID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("auto_receptionist_id"), "Error updating phone auto receptionist ivr", err))
 		return
 	}
 
 	output, err := r.read(ctx, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating phone auto receptionist ivr", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("auto_receptionist_id"), "Error updating phone auto receptionist ivr", err))
 		return
 	}
 
@@ -530,22 +577,28 @@ func (r *tfResource) buildUpdateDto(tobe resourceModel) *updateDto {
 }
 
 func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_auto_receptionist_ivr", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_auto_receptionist_ivr")
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	if err := r.crud.delete(ctx, state.AutoReceptionistID, state.HoursType, state.HolidayID); err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone auto receptionist ivr",
-			fmt.Sprintf(
-				"Could not delete phone auto receptionist ivr %s, unexpected error: %s",
-				state.AutoReceptionistID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("auto_receptionist_id"), "Error deleting phone auto receptionist ivr", err))
 		return
 	}
 
@@ -557,5 +610,5 @@ func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 }
 
 func (r *tfResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("auto_receptionist_id"), req, resp)
+	shared.ImportStateByKey(ctx, path.Root("auto_receptionist_id"), req, resp, "auto receptionist", r.providerData.Cache.AutoReceptionistImportKey)
 }
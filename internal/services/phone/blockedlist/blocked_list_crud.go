@@ -2,10 +2,11 @@ package blockedlist
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"iter"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -20,18 +21,43 @@ type crud struct {
 	client *zoomphone.Client
 }
 
+// list iterates over the blocked lists of the account, for the list resource.
+func (c *crud) list(ctx context.Context) iter.Seq2[zoomphone.ListBlockedListOKBlockedListItem, error] {
+	return util.Paginate(ctx, 100, func(ctx context.Context, pageSize int, nextPageToken string) ([]zoomphone.ListBlockedListOKBlockedListItem, string, error) {
+		res, err := c.client.ListBlockedList(ctx, zoomphone.ListBlockedListParams{
+			NextPageToken: util.ToPhoneOptPageToken(nextPageToken),
+			PageSize:      zoomphone.NewOptInt(pageSize), // max 100
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return res.BlockedList, res.NextPageToken.Value, nil
+	})
+}
+
+// importKey resolves a blocked list by its phone number or prefix, as Zoom returns it, e.g. `+12058945752`.
+func (c *crud) importKey(ctx context.Context, key string) ([]shared.ImportCandidate, error) {
+	blockedLists, err := util.CollectAll(c.list(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read phone blocked lists: %w", err)
+	}
+	return shared.MatchImportKey(blockedLists, key, func(item zoomphone.ListBlockedListOKBlockedListItem) (shared.ImportCandidate, []string) {
+		return shared.ImportCandidate{
+			ID:    item.ID.Value,
+			Label: fmt.Sprintf("blocked list %s (%s, %s)", item.PhoneNumber.Value, item.BlockType.Value, item.MatchType.Value),
+		}, []string{item.PhoneNumber.Value}
+	}), nil
+}
+
 func (c *crud) read(ctx context.Context, blockedListID types.String) (*readDto, error) {
 	detail, err := c.client.GetABlockedList(ctx, zoomphone.GetABlockedListParams{
 		BlockedListId: blockedListID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 300 {
-				return nil, nil // already deleted
-			}
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
-		return nil, fmt.Errorf("unable to read phone blocked list: %v", err)
+		return nil, fmt.Errorf("unable to read phone blocked list: %w", err)
 	}
 
 	return &readDto{
@@ -45,37 +71,58 @@ func (c *crud) read(ctx context.Context, blockedListID types.String) (*readDto,
 }
 
 func (c *crud) create(ctx context.Context, dto *createDto) (*createdDto, error) {
-	res, err := c.client.AddAnumberToBlockedList(ctx, zoomphone.OptAddAnumberToBlockedListReq{
-		Value: zoomphone.AddAnumberToBlockedListReq{
-			BlockType:   util.ToPhoneOptString(dto.blockType),
-			Comment:     util.ToPhoneOptString(dto.comment),
-			MatchType:   util.ToPhoneOptString(dto.matchType),
-			PhoneNumber: util.ToPhoneOptString(dto.phoneNumber),
-			Status:      util.ToPhoneOptString(dto.status),
-		},
-		Set: true,
+	blockedListID, err := shared.ReconcileCreate(ctx, func(ctx context.Context) ([]string, error) {
+		return c.matching(ctx, dto)
+	}, func() (string, error) {
+		res, err := c.client.AddAnumberToBlockedList(ctx, zoomphone.OptAddAnumberToBlockedListReq{
+			Value: zoomphone.AddAnumberToBlockedListReq{
+				BlockType:   util.ToPhoneOptString(dto.blockType),
+				Comment:     util.ToPhoneOptString(dto.comment),
+				MatchType:   util.ToPhoneOptString(dto.matchType),
+				PhoneNumber: util.ToPhoneOptString(dto.phoneNumber),
+				Status:      util.ToPhoneOptString(dto.status),
+			},
+			Set: true,
+		})
+		if err != nil {
+			return "", err
+		}
+		return res.ID.Value, nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error creating phone blocked list: %v", err)
+		return nil, fmt.Errorf("error creating phone blocked list: %w", err)
 	}
 
 	return &createdDto{
-		blockedListID: util.FromOptString(res.ID),
+		blockedListID: types.StringValue(blockedListID),
 	}, nil
 }
 
+// matching returns the IDs of the blocked lists of the phone number, block type and match type of a create request.
+func (c *crud) matching(ctx context.Context, dto *createDto) ([]string, error) {
+	var ids []string
+	for item, err := range c.list(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		if item.PhoneNumber.Value == dto.phoneNumber.ValueString() &&
+			(dto.blockType.IsNull() || item.BlockType.Value == dto.blockType.ValueString()) &&
+			(dto.matchType.IsNull() || item.MatchType.Value == dto.matchType.ValueString()) {
+			ids = append(ids, item.ID.Value)
+		}
+	}
+	return ids, nil
+}
+
 func (c *crud) delete(ctx context.Context, blockedListId types.String) error {
 	err := c.client.DeleteABlockedList(ctx, zoomphone.DeleteABlockedListParams{
 		BlockedListId: blockedListId.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 404 {
-				return nil
-			}
+		if util.IsNotFound(err) {
+			return nil
 		}
-		return fmt.Errorf("error deleting phone blocked list: %v", err)
+		return fmt.Errorf("error deleting phone blocked list: %w", err)
 	}
 
 	return nil
@@ -3,26 +3,34 @@ package blockedlist
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
-	_ datasource.DataSource              = &tfDataSource{}
-	_ datasource.DataSourceWithConfigure = &tfDataSource{}
+	_ datasource.DataSource                   = &tfDataSource{}
+	_ datasource.DataSourceWithConfigure      = &tfDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &tfDataSource{}
 )
 
+// dataSourceScopes are the OAuth scopes this data source requires.
+var dataSourceScopes = shared.Scopes{
+	"phone:read:blocked_list:admin",
+}
+
 func NewPhoneBlockedListDataSource() datasource.DataSource {
 	return &tfDataSource{}
 }
 
 type tfDataSource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (d *tfDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -38,6 +46,11 @@ func (d *tfDataSource) Configure(_ context.Context, req datasource.ConfigureRequ
 		return
 	}
 	d.crud = newCrud(data.PhoneClient)
+	d.providerData = data
+}
+
+func (d *tfDataSource) ValidateConfig(_ context.Context, _ datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(d.providerData.ValidateScopes(dataSourceScopes)...)
 }
 
 func (d *tfDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -54,9 +67,7 @@ Blocked callers will hear a generic message stating that the person they are cal
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:blocked_list:admin`",
-		}, ", ") + ".",
+This resource requires the ` + dataSourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Required:            true,
@@ -90,29 +101,35 @@ This resource requires the ` + strings.Join([]string{
   - active: The blocked list is active.
   - inactive: The blocked list is inactive.`,
 			},
+			"sub_account_id": shared.SubAccountIDDataSourceAttribute(),
 		},
 	}
 }
 
 type dataSourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	BlockType   types.String `tfsdk:"block_type"`
-	Comment     types.String `tfsdk:"comment"`
-	MatchType   types.String `tfsdk:"match_type"`
-	PhoneNumber types.String `tfsdk:"phone_number"`
-	Status      types.String `tfsdk:"status"`
+	ID           types.String `tfsdk:"id"`
+	BlockType    types.String `tfsdk:"block_type"`
+	Comment      types.String `tfsdk:"comment"`
+	MatchType    types.String `tfsdk:"match_type"`
+	PhoneNumber  types.String `tfsdk:"phone_number"`
+	Status       types.String `tfsdk:"status"`
+	SubAccountID types.String `tfsdk:"sub_account_id"`
 }
 
 func (d *tfDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "data.zoom_phone_blocked_list", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var data dataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, data.SubAccountID)
 
 	dto, err := d.crud.read(ctx, data.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone blocked list", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error reading phone blocked list", err))
 		return
 	}
 
@@ -121,12 +138,13 @@ func (d *tfDataSource) Read(ctx context.Context, req datasource.ReadRequest, res
 	})
 
 	output := dataSourceModel{
-		ID:          dto.blockedListID,
-		BlockType:   dto.blockType,
-		Comment:     dto.comment,
-		MatchType:   dto.matchType,
-		PhoneNumber: dto.phoneNumber,
-		Status:      dto.status,
+		ID:           dto.blockedListID,
+		BlockType:    dto.blockType,
+		Comment:      dto.comment,
+		MatchType:    dto.matchType,
+		PhoneNumber:  dto.phoneNumber,
+		Status:       dto.status,
+		SubAccountID: data.SubAccountID,
 	}
 	diags := resp.State.Set(ctx, &output)
 	resp.Diagnostics.Append(diags...)
@@ -3,9 +3,10 @@ package blockedlist
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -19,17 +20,28 @@ import (
 )
 
 var (
-	_ resource.Resource                = &tfResource{}
-	_ resource.ResourceWithConfigure   = &tfResource{}
-	_ resource.ResourceWithImportState = &tfResource{}
+	_ resource.Resource                   = &tfResource{}
+	_ resource.ResourceWithConfigure      = &tfResource{}
+	_ resource.ResourceWithImportState    = &tfResource{}
+	_ resource.ResourceWithIdentity       = &tfResource{}
+	_ resource.ResourceWithValidateConfig = &tfResource{}
 )
 
+// resourceScopes are the OAuth scopes this resource requires.
+var resourceScopes = shared.Scopes{
+	"phone:read:blocked_list:admin",
+	"phone:write:blocked_list:admin",
+	// "phone:update:blocked_list:admin", // PATCH api hasn't be provided yet on openapi spec
+	"phone:delete:blocked_list:admin",
+}
+
 func NewPhoneBlockedListResource() resource.Resource {
 	return &tfResource{}
 }
 
 type tfResource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -45,13 +57,18 @@ func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest,
 		return
 	}
 	r.crud = newCrud(data.PhoneClient)
+	r.providerData = data
+}
+
+func (r *tfResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(resourceScopes)...)
 }
 
 func (r *tfResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_phone_blocked_list"
 }
 
-func (r *tfResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tfResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `A Zoom account owner or a user with the admin privilege can block phone numbers for phone users in an account.
 Blocked numbers can be inbound (numbers will be blocked from calling in) and outbound (phone users in your account won't be able to dial those numbers).
@@ -60,12 +77,7 @@ Blocked callers will hear a generic message stating that the person they are cal
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:blocked_list:admin`",
-			"`phone:write:blocked_list:admin`",
-			// "`phone:update:blocked_list:admin`", // PATCH api hasn't be provided yet on openapi spec
-			"`phone:delete:blocked_list:admin`",
-		}, ", ") + ".",
+This resource requires the ` + resourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -124,30 +136,54 @@ This resource requires the ` + strings.Join([]string{
 					stringvalidator.OneOf("active", "inactive"),
 				},
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
 
 type resourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	BlockType   types.String `tfsdk:"block_type"`
-	Comment     types.String `tfsdk:"comment"`
-	MatchType   types.String `tfsdk:"match_type"`
-	PhoneNumber types.String `tfsdk:"phone_number"`
-	Status      types.String `tfsdk:"status"`
+	ID           types.String   `tfsdk:"id"`
+	BlockType    types.String   `tfsdk:"block_type"`
+	Comment      types.String   `tfsdk:"comment"`
+	MatchType    types.String   `tfsdk:"match_type"`
+	PhoneNumber  types.String   `tfsdk:"phone_number"`
+	Status       types.String   `tfsdk:"status"`
+	SubAccountID types.String   `tfsdk:"sub_account_id"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *tfResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = shared.IdentitySchema("id")
 }
 
 func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_blocked_list", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	// The identity is set even when the object has been deleted outside of Terraform, as it does not change.
+	resp.Diagnostics.Append(shared.SetIdentity(ctx, resp.Identity, "id", state.ID, state.SubAccountID)...)
 
-	output, err := r.read(ctx, state.ID)
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	output, err := r.read(ctx, state.ID, state.SubAccountID, state.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone blocked list", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error reading phone blocked list", err))
 		return
 	}
 
@@ -158,32 +194,47 @@ func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 	}
 }
 
-func (r *tfResource) read(ctx context.Context, blockedListId types.String) (*resourceModel, error) {
+func (r *tfResource) read(ctx context.Context, blockedListId types.String, subAccountID types.String, timeouts timeouts.Value) (*resourceModel, error) {
 	dto, err := r.crud.read(ctx, blockedListId)
 	if err != nil {
-		return nil, fmt.Errorf("error read: %v", err)
+		return nil, fmt.Errorf("error read: %w", err)
 	}
 	if dto == nil {
 		return nil, nil // already deleted
 	}
 
 	return &resourceModel{
-		ID:          dto.blockedListID,
-		BlockType:   dto.blockType,
-		Comment:     dto.comment,
-		MatchType:   dto.matchType,
-		PhoneNumber: dto.phoneNumber,
-		Status:      dto.status,
+		ID:           dto.blockedListID,
+		BlockType:    dto.blockType,
+		Comment:      dto.comment,
+		MatchType:    dto.matchType,
+		PhoneNumber:  dto.phoneNumber,
+		Status:       dto.status,
+		SubAccountID: subAccountID,
+		Timeouts:     timeouts,
 	}, nil
 }
 
 func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_blocked_list", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_blocked_list")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	ret, err := r.crud.create(ctx, &createDto{
 		blockType:   plan.BlockType,
@@ -193,16 +244,13 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 		status:      plan.Status,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone blocked list",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone blocked list", err))
 		return
 	}
 
-	output, err := r.read(ctx, ret.blockedListID)
+	output, err := r.read(ctx, ret.blockedListID, plan.SubAccountID, plan.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating phone blocked list on reading", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone blocked list on reading", err))
 		return
 	}
 
@@ -211,9 +259,13 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(shared.SetIdentityFromState(ctx, resp.State, resp.Identity, "id")...)
 }
 
 func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_blocked_list", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	// PATCH blocked_list hasn't be provided yet, so we just do delete/create on update.
 	resp.Diagnostics.AddError(
 		"Error updating phone blocked list",
@@ -223,22 +275,28 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 }
 
 func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_blocked_list", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_blocked_list")
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	if err := r.crud.delete(ctx, state.ID); err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone blocked list",
-			fmt.Sprintf(
-				"Could not delete phone blocked list %s, unexpected error: %s",
-				state.ID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error deleting phone blocked list", err))
 		return
 	}
 
@@ -248,5 +306,5 @@ func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 }
 
 func (r *tfResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	shared.ImportStateByKey(ctx, path.Root("id"), req, resp, "blocked list", r.crud.importKey)
 }
@@ -0,0 +1,79 @@
+package blockedlist_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccPhoneBlockedList(t *testing.T) {
+	td := acceptance.NewTestData(t, "zoom_phone_blocked_list", "test")
+	// 555-0100 through 555-0199 are reserved for fictional use, so that no real caller is blocked
+	phoneNumber := "+12025550143"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactories(t),
+		CheckDestroy: acceptance.CheckDestroy(td.TerraformResourceType, func(ctx context.Context, rs *terraform.ResourceState) error {
+			_, err := acceptance.ProviderData(t).PhoneClient.GetABlockedList(ctx, zoomphone.GetABlockedListParams{
+				BlockedListId: rs.Primary.ID,
+			})
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: acceptance.ProviderConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  block_type   = "inbound"
+  match_type   = "phoneNumber"
+  phone_number = %[3]q
+  comment      = "created by acctest"
+}
+`, td.TerraformResourceType, td.ResourceLabel, phoneNumber),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "block_type", "inbound"),
+					resource.TestCheckResourceAttr(td.ResourceName, "match_type", "phoneNumber"),
+					resource.TestCheckResourceAttr(td.ResourceName, "phone_number", phoneNumber),
+					resource.TestCheckResourceAttr(td.ResourceName, "comment", "created by acctest"),
+					resource.TestCheckResourceAttr(td.ResourceName, "status", "active"),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      td.ResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState testing by phone number
+			{
+				ResourceName:      td.ResourceName,
+				ImportState:       true,
+				ImportStateId:     phoneNumber,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing, which replaces the blocked list
+			{
+				Config: acceptance.ProviderConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  block_type   = "outbound"
+  match_type   = "phoneNumber"
+  phone_number = %[3]q
+  comment      = "updated by acctest"
+  status       = "inactive"
+}
+`, td.TerraformResourceType, td.ResourceLabel, phoneNumber),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "block_type", "outbound"),
+					resource.TestCheckResourceAttr(td.ResourceName, "comment", "updated by acctest"),
+					resource.TestCheckResourceAttr(td.ResourceName, "status", "inactive"),
+				),
+			},
+		},
+	})
+}
@@ -4,9 +4,10 @@ import (
 	"context"
 	"fmt"
 	"regexp"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -22,17 +23,27 @@ import (
 )
 
 var (
-	_ resource.Resource                = &tfBusinessHoursResource{}
-	_ resource.ResourceWithConfigure   = &tfBusinessHoursResource{}
-	_ resource.ResourceWithImportState = &tfBusinessHoursResource{}
+	_ resource.Resource                   = &tfBusinessHoursResource{}
+	_ resource.ResourceWithConfigure      = &tfBusinessHoursResource{}
+	_ resource.ResourceWithImportState    = &tfBusinessHoursResource{}
+	_ resource.ResourceWithValidateConfig = &tfBusinessHoursResource{}
 )
 
+// businessHoursResourceScopes are the OAuth scopes this resource requires.
+var businessHoursResourceScopes = shared.Scopes{
+	"phone:read:call_handling_setting:admin",
+	"phone:write:call_handling_setting:admin",
+	"phone:update:call_handling_setting:admin",
+	"phone:delete:call_handling_setting:admin",
+}
+
 func NewPhoneCallHandlingBusinessHoursResource() resource.Resource {
 	return &tfBusinessHoursResource{}
 }
 
 type tfBusinessHoursResource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (r *tfBusinessHoursResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -47,14 +58,19 @@ func (r *tfBusinessHoursResource) Configure(_ context.Context, req resource.Conf
 		)
 		return
 	}
-	r.crud = newCrud(data.PhoneClient)
+	r.crud = newCrud(data.PhoneClient, data.Locks)
+	r.providerData = data
+}
+
+func (r *tfBusinessHoursResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(businessHoursResourceScopes)...)
 }
 
 func (r *tfBusinessHoursResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_phone_call_handling_business_hours"
 }
 
-func (r *tfBusinessHoursResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tfBusinessHoursResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Call handling settings allow you to control how your system routes calls during business hours.
 For more information, read our [Call Handling API guide](https://developers.zoom.us/docs/zoom-phone/call-handling/) or Zoom support article [Customizing call handling settings](https://support.zoom.us/hc/en-us/articles/360059966372-Customizing-call-handling-settings).
@@ -62,12 +78,7 @@ For more information, read our [Call Handling API guide](https://developers.zoom
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:call_handling_setting:admin`",
-			"`phone:write:call_handling_setting:admin`",
-			"`phone:update:call_handling_setting:admin`",
-			"`phone:delete:call_handling_setting:admin`",
-		}, ", ") + ".",
+This resource requires the ` + businessHoursResourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"extension_id": schema.StringAttribute{
 				Required:            true,
@@ -427,6 +438,10 @@ This resource requires the ` + strings.Join([]string{
 					},
 				},
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -436,6 +451,8 @@ type businessHoursResourceModel struct {
 	CustomHours    *businessHoursResourceModelCustomHours    `tfsdk:"custom_hours"`
 	CallHandling   *businessHoursResourceModelCallHandling   `tfsdk:"call_handling"`
 	CallForwarding *businessHoursResourceModelCallForwarding `tfsdk:"call_forwarding"`
+	SubAccountID   types.String                              `tfsdk:"sub_account_id"`
+	Timeouts       timeouts.Value                            `tfsdk:"timeouts"`
 }
 
 type businessHoursResourceModelCustomHours struct {
@@ -504,16 +521,28 @@ type businessHoursResourceModelCallForwardingSetting struct {
 }
 
 func (r *tfBusinessHoursResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_handling_business_hours", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state businessHoursResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	output, err := r.read(ctx, &state)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone call handling", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("extension_id"), "Error reading phone call handling", err))
 		return
 	}
 
@@ -527,7 +556,7 @@ func (r *tfBusinessHoursResource) Read(ctx context.Context, req resource.ReadReq
 func (r *tfBusinessHoursResource) read(ctx context.Context, plan *businessHoursResourceModel) (*businessHoursResourceModel, error) {
 	dto, err := r.crud.readBusinessHours(ctx, plan.ExtensionID)
 	if err != nil {
-		return nil, fmt.Errorf("error read: %v", err)
+		return nil, fmt.Errorf("error read: %w", err)
 	}
 	if dto == nil {
 		return nil, nil // already deleted
@@ -610,6 +639,8 @@ func (r *tfBusinessHoursResource) read(ctx context.Context, plan *businessHoursR
 		CustomHours:    customHours,
 		CallHandling:   callHandling,
 		CallForwarding: callForwarding,
+		SubAccountID:   plan.SubAccountID,
+		Timeouts:       plan.Timeouts,
 	}, nil
 }
 
@@ -805,18 +836,28 @@ func (r *tfBusinessHoursResource) sync(ctx context.Context, plan *businessHoursR
 }
 
 func (r *tfBusinessHoursResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_handling_business_hours", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan businessHoursResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_handling_business_hours")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, &plan, false); err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone call handling",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("extension_id"), "Error creating phone call handling", err))
 		return
 	}
 
@@ -829,6 +870,9 @@ func (r *tfBusinessHoursResource) Create(ctx context.Context, req resource.Creat
 }
 
 func (r *tfBusinessHoursResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_handling_business_hours", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan businessHoursResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -839,16 +883,19 @@ func (r *tfBusinessHoursResource) Update(ctx context.Context, req resource.Updat
 		)
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_handling_business_hours")
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, shared.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, &plan, false); err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating phone call handling",
-			fmt.Sprintf(
-				"Could not update phone call handling %s, unexpected error: %s",
-				plan.ExtensionID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("extension_id"), "Error updating phone call handling", err))
 		return
 	}
 
@@ -861,12 +908,25 @@ func (r *tfBusinessHoursResource) Update(ctx context.Context, req resource.Updat
 }
 
 func (r *tfBusinessHoursResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_handling_business_hours", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state businessHoursResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_handling_business_hours")
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	defaultModel := businessHoursResourceModel{
 		ExtensionID: state.ExtensionID,
@@ -879,14 +939,7 @@ func (r *tfBusinessHoursResource) Delete(ctx context.Context, req resource.Delet
 	}
 	err := r.sync(ctx, &defaultModel, true)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone call handling",
-			fmt.Sprintf(
-				"Could not delete phone call handling %s, unexpected error: %s",
-				state.ExtensionID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("extension_id"), "Error deleting phone call handling", err))
 		return
 	}
 
@@ -896,5 +949,5 @@ func (r *tfBusinessHoursResource) Delete(ctx context.Context, req resource.Delet
 }
 
 func (r *tfBusinessHoursResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("extension_id"), req, resp)
+	shared.ImportStateByKey(ctx, path.Root("extension_id"), req, resp, "extension", r.providerData.Cache.ExtensionImportKey)
 }
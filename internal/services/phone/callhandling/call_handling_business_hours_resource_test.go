@@ -0,0 +1,196 @@
+package callhandling_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/samber/lo"
+)
+
+func TestAccPhoneCallHandlingBusinessHours(t *testing.T) {
+	td := acceptance.NewTestData(t, "zoom_phone_call_handling_business_hours", "test")
+	callQueueConfig := testAccCallQueueConfig(t, td, 81051)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactories(t),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: acceptance.ProviderConfig + callQueueConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  extension_id = zoom_phone_call_queue.test.extension_id
+
+  custom_hours = {
+    type                   = 2 # Custom hours
+    allow_members_to_reset = false
+    settings = [
+      {
+        weekday = 1 # Sunday
+        type    = 0 # Disabled
+      },
+      {
+        weekday = 2 # Monday
+        type    = 2 # Customized hours
+        from    = "09:00"
+        to      = "18:00"
+      },
+      {
+        weekday = 3 # Tuesday
+        type    = 2 # Customized hours
+        from    = "09:00"
+        to      = "18:00"
+      },
+      {
+        weekday = 4 # Wednesday
+        type    = 2 # Customized hours
+        from    = "09:00"
+        to      = "18:00"
+      },
+      {
+        weekday = 5 # Thursday
+        type    = 2 # Customized hours
+        from    = "09:00"
+        to      = "18:00"
+      },
+      {
+        weekday = 6 # Friday
+        type    = 2 # Customized hours
+        from    = "09:00"
+        to      = "18:00"
+      },
+      {
+        weekday = 7 # Saturday
+        type    = 0 # Disabled
+      },
+    ]
+  }
+
+  call_handling = {
+    call_not_answer_action        = 1 # Forward to voicemail
+    allow_callers_check_voicemail = true
+    connect_to_operator           = false
+    max_wait_time                 = 30
+    ring_mode                     = "simultaneous"
+  }
+}
+`, td.TerraformResourceType, td.ResourceLabel),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(td.ResourceName, "extension_id", "zoom_phone_call_queue.test", "extension_id"),
+					resource.TestCheckResourceAttr(td.ResourceName, "custom_hours.type", "2"),
+					resource.TestCheckResourceAttr(td.ResourceName, "custom_hours.settings.#", "7"),
+					resource.TestCheckTypeSetElemNestedAttrs(td.ResourceName, "custom_hours.settings.*", map[string]string{
+						"weekday": "2",
+						"type":    "2",
+						"from":    "09:00",
+						"to":      "18:00",
+					}),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_handling.max_wait_time", "30"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         td.ResourceName,
+				ImportState:                          true,
+				ImportStateIdFunc:                    acceptance.ImportStateIDFromAttribute(td.ResourceName, "extension_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "extension_id",
+			},
+			// ImportState testing by extension number
+			{
+				ResourceName:                         td.ResourceName,
+				ImportState:                          true,
+				ImportStateIdFunc:                    acceptance.ImportStateIDFromAttribute("zoom_phone_call_queue.test", "extension_number"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "extension_id",
+			},
+			// Update and Read testing
+			{
+				Config: acceptance.ProviderConfig + callQueueConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  extension_id = zoom_phone_call_queue.test.extension_id
+
+  custom_hours = {
+    type                   = 2 # Custom hours
+    allow_members_to_reset = true
+    settings = [
+      {
+        weekday = 1 # Sunday
+        type    = 0 # Disabled
+      },
+      {
+        weekday = 2 # Monday
+        type    = 1 # 24 hours
+      },
+      {
+        weekday = 3 # Tuesday
+        type    = 1 # 24 hours
+      },
+      {
+        weekday = 4 # Wednesday
+        type    = 1 # 24 hours
+      },
+      {
+        weekday = 5 # Thursday
+        type    = 1 # 24 hours
+      },
+      {
+        weekday = 6 # Friday
+        type    = 1 # 24 hours
+      },
+      {
+        weekday = 7 # Saturday
+        type    = 0 # Disabled
+      },
+    ]
+  }
+
+  call_handling = {
+    call_not_answer_action        = 1 # Forward to voicemail
+    allow_callers_check_voicemail = false
+    connect_to_operator           = false
+    max_wait_time                 = 60
+    ring_mode                     = "sequential"
+  }
+}
+`, td.TerraformResourceType, td.ResourceLabel),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "custom_hours.allow_members_to_reset", "true"),
+					resource.TestCheckResourceAttr(td.ResourceName, "custom_hours.settings.#", "7"),
+					resource.TestCheckTypeSetElemNestedAttrs(td.ResourceName, "custom_hours.settings.*", map[string]string{
+						"weekday": "2",
+						"type":    "1",
+					}),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_handling.allow_callers_check_voicemail", "false"),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_handling.max_wait_time", "60"),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_handling.ring_mode", "sequential"),
+				),
+			},
+			// Destroy testing, which resets the business hours to 24 hours as the extension remains
+			{
+				Config: acceptance.ProviderConfig + callQueueConfig,
+				Check:  testAccCheckBusinessHoursReset(t, "zoom_phone_call_queue.test"),
+			},
+		},
+	})
+}
+
+// testAccCheckBusinessHoursReset checks that the business hours of the extension are back to 24 hours.
+func testAccCheckBusinessHoursReset(t *testing.T, callQueueResourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		detail, err := testAccGetCallHandling(t, s, callQueueResourceName)
+		if err != nil {
+			return err
+		}
+		customHours, _ := lo.Find(detail.BusinessHours, func(item zoomphone.GetCallHandlingOKBusinessHoursItem) bool {
+			return item.SubSettingType.Value == "custom_hours"
+		})
+		if typ := customHours.Settings.Value.Type.Value; typ != 1 {
+			return fmt.Errorf("business hours of %q are still of type %d, want 1 (24 hours)", callQueueResourceName, typ)
+		}
+		return nil
+	}
+}
@@ -3,9 +3,10 @@ package callhandling
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -20,17 +21,27 @@ import (
 )
 
 var (
-	_ resource.Resource                = &tfClosedHoursResource{}
-	_ resource.ResourceWithConfigure   = &tfClosedHoursResource{}
-	_ resource.ResourceWithImportState = &tfClosedHoursResource{}
+	_ resource.Resource                   = &tfClosedHoursResource{}
+	_ resource.ResourceWithConfigure      = &tfClosedHoursResource{}
+	_ resource.ResourceWithImportState    = &tfClosedHoursResource{}
+	_ resource.ResourceWithValidateConfig = &tfClosedHoursResource{}
 )
 
+// closedHoursResourceScopes are the OAuth scopes this resource requires.
+var closedHoursResourceScopes = shared.Scopes{
+	"phone:read:call_handling_setting:admin",
+	"phone:write:call_handling_setting:admin",
+	"phone:update:call_handling_setting:admin",
+	"phone:delete:call_handling_setting:admin",
+}
+
 func NewPhoneCallHandlingClosedHoursResource() resource.Resource {
 	return &tfClosedHoursResource{}
 }
 
 type tfClosedHoursResource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (r *tfClosedHoursResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -45,14 +56,19 @@ func (r *tfClosedHoursResource) Configure(_ context.Context, req resource.Config
 		)
 		return
 	}
-	r.crud = newCrud(data.PhoneClient)
+	r.crud = newCrud(data.PhoneClient, data.Locks)
+	r.providerData = data
+}
+
+func (r *tfClosedHoursResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(closedHoursResourceScopes)...)
 }
 
 func (r *tfClosedHoursResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_phone_call_handling_closed_hours"
 }
 
-func (r *tfClosedHoursResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tfClosedHoursResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Call handling settings allow you to control how your system routes calls during closed hours.
 For more information, read our [Call Handling API guide](https://developers.zoom.us/docs/zoom-phone/call-handling/) or Zoom support article [Customizing call handling settings](https://support.zoom.us/hc/en-us/articles/360059966372-Customizing-call-handling-settings).
@@ -63,12 +79,7 @@ This resource is depends on ` + "`zoom_phone_call_handling_business_hours`" + `.
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:call_handling_setting:admin`",
-			"`phone:write:call_handling_setting:admin`",
-			"`phone:update:call_handling_setting:admin`",
-			"`phone:delete:call_handling_setting:admin`",
-		}, ", ") + ".",
+This resource requires the ` + closedHoursResourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"extension_id": schema.StringAttribute{
 				Required:            true,
@@ -274,6 +285,10 @@ This resource requires the ` + strings.Join([]string{
 					},
 				},
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -282,6 +297,8 @@ type closedHoursResourceModel struct {
 	ExtensionID    types.String                            `tfsdk:"extension_id"`
 	CallHandling   *closedHoursResourceModelCallHandling   `tfsdk:"call_handling"`
 	CallForwarding *closedHoursResourceModelCallForwarding `tfsdk:"call_forwarding"`
+	SubAccountID   types.String                            `tfsdk:"sub_account_id"`
+	Timeouts       timeouts.Value                          `tfsdk:"timeouts"`
 }
 
 type closedHoursResourceModelCallHandling struct {
@@ -322,16 +339,28 @@ type closedHoursResourceModelCallForwardingSetting struct {
 }
 
 func (r *tfClosedHoursResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_handling_closed_hours", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state closedHoursResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	output, err := r.read(ctx, &state)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone call handling", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("extension_id"), "Error reading phone call handling", err))
 		return
 	}
 
@@ -345,7 +374,7 @@ func (r *tfClosedHoursResource) Read(ctx context.Context, req resource.ReadReque
 func (r *tfClosedHoursResource) read(ctx context.Context, plan *closedHoursResourceModel) (*closedHoursResourceModel, error) {
 	dto, err := r.crud.readClosedHours(ctx, plan.ExtensionID)
 	if err != nil {
-		return nil, fmt.Errorf("error read: %v", err)
+		return nil, fmt.Errorf("error read: %w", err)
 	}
 	if dto == nil {
 		return nil, nil // already deleted
@@ -394,6 +423,8 @@ func (r *tfClosedHoursResource) read(ctx context.Context, plan *closedHoursResou
 		ExtensionID:    dto.extensionID,
 		CallHandling:   callHandling,
 		CallForwarding: callForwarding,
+		SubAccountID:   plan.SubAccountID,
+		Timeouts:       plan.Timeouts,
 	}, nil
 }
 
@@ -547,18 +578,28 @@ func (r *tfClosedHoursResource) sync(ctx context.Context, plan *closedHoursResou
 }
 
 func (r *tfClosedHoursResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_handling_closed_hours", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan closedHoursResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_handling_closed_hours")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, &plan, false); err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone call handling",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("extension_id"), "Error creating phone call handling", err))
 		return
 	}
 
@@ -571,6 +612,9 @@ func (r *tfClosedHoursResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (r *tfClosedHoursResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_handling_closed_hours", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan closedHoursResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -581,16 +625,19 @@ func (r *tfClosedHoursResource) Update(ctx context.Context, req resource.UpdateR
 		)
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_handling_closed_hours")
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, shared.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, &plan, false); err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating phone call handling",
-			fmt.Sprintf(
-				"Could not update phone call handling %s, unexpected error: %s",
-				plan.ExtensionID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("extension_id"), "Error updating phone call handling", err))
 		return
 	}
 
@@ -603,12 +650,25 @@ func (r *tfClosedHoursResource) Update(ctx context.Context, req resource.UpdateR
 }
 
 func (r *tfClosedHoursResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_handling_closed_hours", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state closedHoursResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_handling_closed_hours")
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	defaultModel := closedHoursResourceModel{
 		ExtensionID:    state.ExtensionID,
@@ -617,14 +677,7 @@ func (r *tfClosedHoursResource) Delete(ctx context.Context, req resource.DeleteR
 	}
 	err := r.sync(ctx, &defaultModel, true)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone call handling",
-			fmt.Sprintf(
-				"Could not delete phone call handling %s, unexpected error: %s",
-				state.ExtensionID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("extension_id"), "Error deleting phone call handling", err))
 		return
 	}
 
@@ -634,5 +687,5 @@ func (r *tfClosedHoursResource) Delete(ctx context.Context, req resource.DeleteR
 }
 
 func (r *tfClosedHoursResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("extension_id"), req, resp)
+	shared.ImportStateByKey(ctx, path.Root("extension_id"), req, resp, "extension", r.providerData.Cache.ExtensionImportKey)
 }
@@ -0,0 +1,162 @@
+package callhandling_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccPhoneCallHandlingClosedHours(t *testing.T) {
+	td := acceptance.NewTestData(t, "zoom_phone_call_handling_closed_hours", "test")
+	extensionConfig := testAccPhoneUserConfig(t) + testAccCustomHoursConfig("zoom_phone_user.test.extension_id")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactories(t),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: acceptance.ProviderConfig + extensionConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  extension_id = zoom_phone_user.test.extension_id
+
+  call_handling = {
+    call_not_answer_action        = 1 # Forward to voicemail
+    allow_callers_check_voicemail = true
+    connect_to_operator           = false
+    max_wait_time                 = 30
+    ring_mode                     = "simultaneous"
+  }
+
+  call_forwarding = {
+    require_press_1_before_connecting = true
+    enable_zoom_mobile_apps           = true
+    enable_zoom_desktop_apps          = true
+    enable_zoom_phone_appliance_apps  = false
+    settings = [
+      {
+        description  = "acctest"
+        enable       = true
+        phone_number = "+12025550146"
+      },
+    ]
+  }
+
+  depends_on = [
+    zoom_phone_call_handling_business_hours.custom_hours
+  ]
+}
+`, td.TerraformResourceType, td.ResourceLabel),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(td.ResourceName, "extension_id", "zoom_phone_user.test", "extension_id"),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_handling.max_wait_time", "30"),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_forwarding.require_press_1_before_connecting", "true"),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_forwarding.enable_zoom_phone_appliance_apps", "false"),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_forwarding.settings.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(td.ResourceName, "call_forwarding.settings.*", map[string]string{
+						"description":  "acctest",
+						"enable":       "true",
+						"phone_number": "+12025550146",
+					}),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "call_forwarding.settings.0.id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         td.ResourceName,
+				ImportState:                          true,
+				ImportStateIdFunc:                    acceptance.ImportStateIDFromAttribute(td.ResourceName, "extension_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "extension_id",
+				// call_forwarding is read only when it is configured
+				ImportStateVerifyIgnore: []string{"call_forwarding"},
+			},
+			// ImportState testing by extension number
+			{
+				ResourceName:                         td.ResourceName,
+				ImportState:                          true,
+				ImportStateIdFunc:                    acceptance.ImportStateIDFromAttribute("zoom_phone_user.test", "extension_number"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "extension_id",
+				// call_forwarding is read only when it is configured
+				ImportStateVerifyIgnore: []string{"call_forwarding"},
+			},
+			// Update and Read testing
+			{
+				Config: acceptance.ProviderConfig + extensionConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  extension_id = zoom_phone_user.test.extension_id
+
+  call_handling = {
+    call_not_answer_action        = 1 # Forward to voicemail
+    allow_callers_check_voicemail = false
+    connect_to_operator           = false
+    max_wait_time                 = 60
+    ring_mode                     = "simultaneous"
+  }
+
+  call_forwarding = {
+    require_press_1_before_connecting = false
+    enable_zoom_mobile_apps           = true
+    enable_zoom_desktop_apps          = true
+    enable_zoom_phone_appliance_apps  = true
+    settings = [
+      {
+        description  = "acctest"
+        enable       = true
+        phone_number = "+12025550146"
+      },
+      {
+        description  = "acctest"
+        enable       = true
+        phone_number = "+12025550147"
+      },
+    ]
+  }
+
+  depends_on = [
+    zoom_phone_call_handling_business_hours.custom_hours
+  ]
+}
+`, td.TerraformResourceType, td.ResourceLabel),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "call_handling.allow_callers_check_voicemail", "false"),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_handling.max_wait_time", "60"),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_forwarding.require_press_1_before_connecting", "false"),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_forwarding.settings.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(td.ResourceName, "call_forwarding.settings.*", map[string]string{
+						"phone_number": "+12025550146",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(td.ResourceName, "call_forwarding.settings.*", map[string]string{
+						"phone_number": "+12025550147",
+					}),
+				),
+			},
+			// Destroy testing, which removes the call forwarding to the phone numbers as the extension remains
+			{
+				Config: acceptance.ProviderConfig + extensionConfig,
+				Check:  testAccCheckClosedHoursCallForwardingRemoved(t, "zoom_phone_user.test"),
+			},
+		},
+	})
+}
+
+// testAccCheckClosedHoursCallForwardingRemoved checks that the closed hours of the extension forward calls to no phone number.
+func testAccCheckClosedHoursCallForwardingRemoved(t *testing.T, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		detail, err := testAccGetCallHandling(t, s, resourceName)
+		if err != nil {
+			return err
+		}
+		for _, item := range detail.ClosedHours {
+			for _, setting := range item.Settings.Value.CallForwardingSettings {
+				if setting.PhoneNumber.Value != "" {
+					return fmt.Errorf("closed hours of %q still forward calls to %s", resourceName, setting.PhoneNumber.Value)
+				}
+			}
+		}
+		return nil
+	}
+}
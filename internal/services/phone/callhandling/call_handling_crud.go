@@ -2,24 +2,26 @@ package callhandling
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/samber/lo"
 )
 
-func newCrud(client *zoomphone.Client) *crud {
+func newCrud(client *zoomphone.Client, locks *shared.ObjectLocks) *crud {
 	return &crud{
 		client: client,
+		locks:  locks,
 	}
 }
 
 type crud struct {
 	client *zoomphone.Client
+	locks  *shared.ObjectLocks
 }
 
 // Zoom phone provides default call forwarding ids for zoom phone mobile apps, zoom phone desktop apps and zoom phone appliance client
@@ -33,13 +35,10 @@ func (c *crud) readBusinessHours(ctx context.Context, extensionID types.String)
 		ExtensionId: extensionID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 300 {
-				return nil, nil // already deleted
-			}
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
-		return nil, fmt.Errorf("unable to read phone call handling: %v", err)
+		return nil, fmt.Errorf("unable to read phone call handling: %w", err)
 	}
 
 	// BusinessHours should contain upper to one custom_hours
@@ -170,13 +169,10 @@ func (c *crud) readClosedHours(ctx context.Context, extensionID types.String) (*
 		ExtensionId: extensionID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 300 {
-				return nil, nil // already deleted
-			}
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
-		return nil, fmt.Errorf("unable to read phone call handling: %v", err)
+		return nil, fmt.Errorf("unable to read phone call handling: %w", err)
 	}
 
 	// ClosedHours should contain upper to one call_handling.
@@ -268,13 +264,10 @@ func (c *crud) readHolidayHours(ctx context.Context, extensionID, holidayID type
 		ExtensionId: extensionID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 300 {
-				return nil, nil // already deleted
-			}
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
-		return nil, fmt.Errorf("unable to read phone call handling: %v", err)
+		return nil, fmt.Errorf("unable to read phone call handling: %w", err)
 	}
 
 	// holiday may contain multiple settings, so filtered by holiday id
@@ -376,6 +369,12 @@ func (c *crud) readHolidayHours(ctx context.Context, extensionID, holidayID type
 }
 
 func (c *crud) createHoliday(ctx context.Context, dto *createHolidayDto) (*createdHolidayDto, error) {
+	unlock, err := c.locks.Lock(ctx, "extension", dto.extensionID.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	res, err := c.client.AddCallHandling(ctx, zoomphone.OptAddCallHandlingReq{
 		Value: zoomphone.AddCallHandlingReq{
 			Type: zoomphone.PostCallHandlingSettingsHolidayAddCallHandlingReq,
@@ -397,7 +396,7 @@ func (c *crud) createHoliday(ctx context.Context, dto *createHolidayDto) (*creat
 		SettingType: string(dto.settingType),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error creating phone call handling on holiday: %v", err)
+		return nil, fmt.Errorf("error creating phone call handling on holiday: %w", err)
 	}
 
 	return &createdHolidayDto{
@@ -406,6 +405,12 @@ func (c *crud) createHoliday(ctx context.Context, dto *createHolidayDto) (*creat
 }
 
 func (c *crud) createCallForwarding(ctx context.Context, dto *createCallForwardingDto) (*createdCallForwardingDto, error) {
+	unlock, err := c.locks.Lock(ctx, "extension", dto.extensionID.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	res, err := c.client.AddCallHandling(ctx, zoomphone.OptAddCallHandlingReq{
 		Value: zoomphone.AddCallHandlingReq{
 			Type: zoomphone.PostCallHandlingSettingsCallForwardingAddCallHandlingReq,
@@ -427,7 +432,7 @@ func (c *crud) createCallForwarding(ctx context.Context, dto *createCallForwardi
 		SettingType: string(dto.settingType),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error creating phone call handling on call forwarding: %v", err)
+		return nil, fmt.Errorf("error creating phone call handling on call forwarding: %w", err)
 	}
 
 	return &createdCallForwardingDto{
@@ -436,7 +441,13 @@ func (c *crud) createCallForwarding(ctx context.Context, dto *createCallForwardi
 }
 
 func (c *crud) patchCustomHours(ctx context.Context, dto *patchCustomHoursDto) error {
-	err := c.client.UpdateCallHandling(ctx, zoomphone.OptUpdateCallHandlingReq{
+	unlock, err := c.locks.Lock(ctx, "extension", dto.extensionID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = c.client.UpdateCallHandling(ctx, zoomphone.OptUpdateCallHandlingReq{
 		Value: zoomphone.UpdateCallHandlingReq{
 			Type: zoomphone.PatchCallHandlingSettingsCustomHoursUpdateCallHandlingReq,
 			PatchCallHandlingSettingsCustomHours: zoomphone.PatchCallHandlingSettingsCustomHours{
@@ -464,14 +475,20 @@ func (c *crud) patchCustomHours(ctx context.Context, dto *patchCustomHoursDto) e
 		SettingType: string(dto.settingType),
 	})
 	if err != nil {
-		return fmt.Errorf("error patching phone call handling on custom hour: %v", err)
+		return fmt.Errorf("error patching phone call handling on custom hour: %w", err)
 	}
 
 	return nil
 }
 
 func (c *crud) patchCallHandling(ctx context.Context, dto *patchCallHandlingDto) error {
-	err := c.client.UpdateCallHandling(ctx, zoomphone.OptUpdateCallHandlingReq{
+	unlock, err := c.locks.Lock(ctx, "extension", dto.extensionID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = c.client.UpdateCallHandling(ctx, zoomphone.OptUpdateCallHandlingReq{
 		Value: zoomphone.UpdateCallHandlingReq{
 			Type: zoomphone.PatchCallHandlingSettingsCallHandlingUpdateCallHandlingReq,
 			PatchCallHandlingSettingsCallHandling: zoomphone.PatchCallHandlingSettingsCallHandling{
@@ -529,14 +546,20 @@ func (c *crud) patchCallHandling(ctx context.Context, dto *patchCallHandlingDto)
 		SettingType: string(dto.settingType),
 	})
 	if err != nil {
-		return fmt.Errorf("error patching phone call handling on call handling: %v", err)
+		return fmt.Errorf("error patching phone call handling on call handling: %w", err)
 	}
 
 	return nil
 }
 
 func (c *crud) patchHoliday(ctx context.Context, dto *patchHolidayDto) error {
-	err := c.client.UpdateCallHandling(ctx, zoomphone.OptUpdateCallHandlingReq{
+	unlock, err := c.locks.Lock(ctx, "extension", dto.extensionID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = c.client.UpdateCallHandling(ctx, zoomphone.OptUpdateCallHandlingReq{
 		Value: zoomphone.UpdateCallHandlingReq{
 			Type: zoomphone.PatchCallHandlingSettingsHolidayUpdateCallHandlingReq,
 			PatchCallHandlingSettingsHoliday: zoomphone.PatchCallHandlingSettingsHoliday{
@@ -558,13 +581,19 @@ func (c *crud) patchHoliday(ctx context.Context, dto *patchHolidayDto) error {
 		SettingType: string(dto.settingType),
 	})
 	if err != nil {
-		return fmt.Errorf("error patching phone call handling custom hour: %v", err)
+		return fmt.Errorf("error patching phone call handling custom hour: %w", err)
 	}
 
 	return nil
 }
 
 func (c *crud) patchCallForwarding(ctx context.Context, dto *patchCallForwardingDto, onDelete bool) error {
+	unlock, err := c.locks.Lock(ctx, "extension", dto.extensionID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// to patch call forwarding, collect zoom predefined call forwarding settings
 	// such as Zoom Mobile Apps, Zoom Desktop Apps, Zoom Phone Appliance Apps
 	detail, err := c.client.GetCallHandling(ctx, zoomphone.GetCallHandlingParams{
@@ -713,45 +742,51 @@ func (c *crud) patchCallForwarding(ctx context.Context, dto *patchCallForwarding
 		SettingType: string(dto.settingType),
 	})
 	if err != nil {
-		return fmt.Errorf("error patching phone call handling on call forwarding: %v", err)
+		return fmt.Errorf("error patching phone call handling on call forwarding: %w", err)
 	}
 
 	return nil
 }
 
 func (c *crud) deleteCallForwarding(ctx context.Context, dto *deleteCallForwardingDto) error {
-	err := c.client.DeleteCallHandling(ctx, zoomphone.DeleteCallHandlingParams{
+	unlock, err := c.locks.Lock(ctx, "extension", dto.extensionID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = c.client.DeleteCallHandling(ctx, zoomphone.DeleteCallHandlingParams{
 		ExtensionId:      dto.extensionID.ValueString(),
 		SettingType:      string(dto.settingType),
 		CallForwardingID: util.ToPhoneOptString(dto.callForwardingID),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 404 && status.Response.Code.Value == 404 {
-				return nil
-			}
+		if util.IsNotFound(err) {
+			return nil
 		}
-		return fmt.Errorf("error deleting phone call handling on call forwarding: %v", err)
+		return fmt.Errorf("error deleting phone call handling on call forwarding: %w", err)
 	}
 
 	return nil
 }
 
 func (c *crud) deleteHoliday(ctx context.Context, dto *deleteHolidayDto) error {
-	err := c.client.DeleteCallHandling(ctx, zoomphone.DeleteCallHandlingParams{
+	unlock, err := c.locks.Lock(ctx, "extension", dto.extensionID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = c.client.DeleteCallHandling(ctx, zoomphone.DeleteCallHandlingParams{
 		ExtensionId: dto.extensionID.ValueString(),
 		SettingType: string(dto.settingType),
 		HolidayID:   util.ToPhoneOptString(dto.holidayID),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 404 && status.Response.Code.Value == 404 {
-				return nil
-			}
+		if util.IsNotFound(err) {
+			return nil
 		}
-		return fmt.Errorf("error deleting phone call handling on holiday: %v", err)
+		return fmt.Errorf("error deleting phone call handling on holiday: %w", err)
 	}
 
 	return nil
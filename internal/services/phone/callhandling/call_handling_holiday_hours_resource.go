@@ -6,9 +6,12 @@ import (
 	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -20,17 +23,27 @@ import (
 )
 
 var (
-	_ resource.Resource                = &tfHolidayHoursResource{}
-	_ resource.ResourceWithConfigure   = &tfHolidayHoursResource{}
-	_ resource.ResourceWithImportState = &tfHolidayHoursResource{}
+	_ resource.Resource                   = &tfHolidayHoursResource{}
+	_ resource.ResourceWithConfigure      = &tfHolidayHoursResource{}
+	_ resource.ResourceWithImportState    = &tfHolidayHoursResource{}
+	_ resource.ResourceWithValidateConfig = &tfHolidayHoursResource{}
 )
 
+// holidayHoursResourceScopes are the OAuth scopes this resource requires.
+var holidayHoursResourceScopes = shared.Scopes{
+	"phone:read:call_handling_setting:admin",
+	"phone:write:call_handling_setting:admin",
+	"phone:update:call_handling_setting:admin",
+	"phone:delete:call_handling_setting:admin",
+}
+
 func NewPhoneCallHandlingHolidayHoursResource() resource.Resource {
 	return &tfHolidayHoursResource{}
 }
 
 type tfHolidayHoursResource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (r *tfHolidayHoursResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -45,14 +58,19 @@ func (r *tfHolidayHoursResource) Configure(_ context.Context, req resource.Confi
 		)
 		return
 	}
-	r.crud = newCrud(data.PhoneClient)
+	r.crud = newCrud(data.PhoneClient, data.Locks)
+	r.providerData = data
+}
+
+func (r *tfHolidayHoursResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(holidayHoursResourceScopes)...)
 }
 
 func (r *tfHolidayHoursResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_phone_call_handling_holiday_hours"
 }
 
-func (r *tfHolidayHoursResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tfHolidayHoursResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Call handling settings allow you to control how your system routes calls during holiday hours.
 For more information, read our [Call Handling API guide](https://developers.zoom.us/docs/zoom-phone/call-handling/) or Zoom support article [Customizing call handling settings](https://support.zoom.us/hc/en-us/articles/360059966372-Customizing-call-handling-settings).
@@ -60,12 +78,7 @@ For more information, read our [Call Handling API guide](https://developers.zoom
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:call_handling_setting:admin`",
-			"`phone:write:call_handling_setting:admin`",
-			"`phone:update:call_handling_setting:admin`",
-			"`phone:delete:call_handling_setting:admin`",
-		}, ", ") + ".",
+This resource requires the ` + holidayHoursResourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"extension_id": schema.StringAttribute{
 				Required:            true,
@@ -75,6 +88,7 @@ This resource requires the ` + strings.Join([]string{
 			"holiday_id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The holiday's ID. It's required for the `holiday` sub-setting.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
 			"holiday": schema.SingleNestedAttribute{
 				Required:            true,
@@ -241,6 +255,10 @@ This resource requires the ` + strings.Join([]string{
 					},
 				},
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -251,6 +269,8 @@ type holidayHoursResourceModel struct {
 	Holiday        *holidayHoursResourceModelHoliday        `tfsdk:"holiday"`
 	CallHandling   *holidayHoursResourceModelCallHandling   `tfsdk:"call_handling"`
 	CallForwarding *holidayHoursResourceModelCallForwarding `tfsdk:"call_forwarding"`
+	SubAccountID   types.String                             `tfsdk:"sub_account_id"`
+	Timeouts       timeouts.Value                           `tfsdk:"timeouts"`
 }
 
 type holidayHoursResourceModelHoliday struct {
@@ -290,16 +310,28 @@ type holidayHoursResourceModelCallForwardingSetting struct {
 }
 
 func (r *tfHolidayHoursResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_handling_holiday_hours", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state holidayHoursResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	output, err := r.read(ctx, &state)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone call handling", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("extension_id"), "Error reading phone call handling", err))
 		return
 	}
 
@@ -316,7 +348,7 @@ func (r *tfHolidayHoursResource) read(ctx context.Context, plan *holidayHoursRes
 	}
 	dto, err := r.crud.readHolidayHours(ctx, plan.ExtensionID, plan.HolidayID)
 	if err != nil {
-		return nil, fmt.Errorf("error read: %v", err)
+		return nil, fmt.Errorf("error read: %w", err)
 	}
 	if dto == nil {
 		return nil, nil // already deleted
@@ -341,7 +373,7 @@ func (r *tfHolidayHoursResource) read(ctx context.Context, plan *holidayHoursRes
 		OperatorExtensionID:                     dto.callHandling.operatorExtensionID,
 		RingMode:                                dto.callHandling.ringMode,
 	}
-	callForwarding := lo.TernaryF(dto.callForwarding != nil, func() *holidayHoursResourceModelCallForwarding {
+	callForwarding := lo.TernaryF(plan.CallForwarding != nil && dto.callForwarding != nil, func() *holidayHoursResourceModelCallForwarding {
 		return &holidayHoursResourceModelCallForwarding{
 			RequirePress1BeforeConnecting: dto.callForwarding.requirePress1BeforeConnecting,
 			EnableZoomMobileApps:          dto.callForwarding.enableZoomMobileApps,
@@ -365,6 +397,8 @@ func (r *tfHolidayHoursResource) read(ctx context.Context, plan *holidayHoursRes
 		Holiday:        holiday,
 		CallHandling:   callHandling,
 		CallForwarding: callForwarding,
+		SubAccountID:   plan.SubAccountID,
+		Timeouts:       plan.Timeouts,
 	}, nil
 }
 
@@ -462,7 +496,7 @@ func (r *tfHolidayHoursResource) sync(ctx context.Context, plan *holidayHoursRes
 		if err = r.crud.patchCallForwarding(ctx, patchCallForwarding, onDelete); err != nil {
 			return err
 		}
-	} else if asis.CallForwarding != nil {
+	} else if asis != nil && asis.CallForwarding != nil {
 		patchCallForwarding := &patchCallForwardingDto{
 			extensionID:                   plan.ExtensionID,
 			holidayID:                     plan.HolidayID,
@@ -528,18 +562,28 @@ func (r *tfHolidayHoursResource) sync(ctx context.Context, plan *holidayHoursRes
 }
 
 func (r *tfHolidayHoursResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_handling_holiday_hours", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan holidayHoursResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_handling_holiday_hours")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, &plan, false); err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone call handling",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("extension_id"), "Error creating phone call handling", err))
 		return
 	}
 
@@ -552,6 +596,9 @@ func (r *tfHolidayHoursResource) Create(ctx context.Context, req resource.Create
 }
 
 func (r *tfHolidayHoursResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_handling_holiday_hours", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan holidayHoursResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -562,16 +609,19 @@ func (r *tfHolidayHoursResource) Update(ctx context.Context, req resource.Update
 		)
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_handling_holiday_hours")
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, shared.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, &plan, false); err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating phone call handling",
-			fmt.Sprintf(
-				"Could not update phone call handling %s, unexpected error: %s",
-				plan.ExtensionID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("extension_id"), "Error updating phone call handling", err))
 		return
 	}
 
@@ -584,12 +634,25 @@ func (r *tfHolidayHoursResource) Update(ctx context.Context, req resource.Update
 }
 
 func (r *tfHolidayHoursResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_handling_holiday_hours", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state holidayHoursResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_handling_holiday_hours")
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	deleteHoliday := &deleteHolidayDto{
 		extensionID: state.ExtensionID,
@@ -598,14 +661,7 @@ func (r *tfHolidayHoursResource) Delete(ctx context.Context, req resource.Delete
 	}
 	err := r.crud.deleteHoliday(ctx, deleteHoliday)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone call handling",
-			fmt.Sprintf(
-				"Could not delete phone call handling %s, unexpected error: %s",
-				state.ExtensionID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("extension_id"), "Error deleting phone call handling", err))
 		return
 	}
 
@@ -615,23 +671,34 @@ func (r *tfHolidayHoursResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *tfHolidayHoursResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// id = ${extension_id/holiday_id}
-	ids := strings.Split(req.ID, "/")
+	// id = ${extension_id/holiday_id} or ${sub_account_id:extension_id/holiday_id}
+	subAccountID, id := shared.CutSubAccountID(req.ID)
+	ids := strings.Split(id, "/")
 	if len(ids) != 2 {
-		resp.Diagnostics.AddError("Invalid import ID", "Import ID must be in the format `extension_id/holiday_id`.")
+		resp.Diagnostics.AddError("Invalid import ID", "Import ID must be in the format `extension_id/holiday_id` or `sub_account_id:extension_id/holiday_id`.")
+		return
+	}
+	ctx = shared.WithSubAccountID(ctx, subAccountID)
+
+	// The extension can also be given by its number, or the email of its phone user.
+	extensionID, diags := shared.ResolveImportKey(ctx, path.Root("extension_id"), ids[0], "extension", r.providerData.Cache.ExtensionImportKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	state, err := r.read(ctx, &holidayHoursResourceModel{
-		ExtensionID: types.StringValue(ids[0]),
-		HolidayID:   types.StringValue(ids[1]),
+		ExtensionID:  types.StringValue(extensionID),
+		HolidayID:    types.StringValue(ids[1]),
+		SubAccountID: subAccountID,
+		Timeouts:     shared.NullTimeouts(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Import failed", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("extension_id"), "Import failed", err))
 		return
 	}
 
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
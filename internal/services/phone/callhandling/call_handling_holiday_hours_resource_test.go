@@ -0,0 +1,151 @@
+package callhandling_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccPhoneCallHandlingHolidayHours(t *testing.T) {
+	td := acceptance.NewTestData(t, "zoom_phone_call_handling_holiday_hours", "test")
+	extensionConfig := testAccPhoneUserConfig(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactories(t),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: acceptance.ProviderConfig + extensionConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  extension_id = zoom_phone_user.test.extension_id
+
+  holiday = {
+    name = "acctest"
+    from = "2030-12-24T00:00:00Z"
+    to   = "2030-12-26T00:00:00Z"
+  }
+
+  call_handling = {
+    call_not_answer_action        = 1 # Forward to voicemail
+    allow_callers_check_voicemail = true
+    connect_to_operator           = false
+    max_wait_time                 = 30
+    ring_mode                     = "simultaneous"
+  }
+
+  call_forwarding = {
+    require_press_1_before_connecting = true
+    enable_zoom_mobile_apps           = true
+    enable_zoom_desktop_apps          = true
+    enable_zoom_phone_appliance_apps  = true
+    settings = [
+      {
+        description  = "acctest"
+        enable       = true
+        phone_number = "+12025550148"
+      },
+    ]
+  }
+}
+`, td.TerraformResourceType, td.ResourceLabel),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(td.ResourceName, "extension_id", "zoom_phone_user.test", "extension_id"),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "holiday_id"),
+					resource.TestCheckResourceAttr(td.ResourceName, "holiday.name", "acctest"),
+					resource.TestCheckResourceAttr(td.ResourceName, "holiday.from", "2030-12-24T00:00:00Z"),
+					resource.TestCheckResourceAttr(td.ResourceName, "holiday.to", "2030-12-26T00:00:00Z"),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_handling.max_wait_time", "30"),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_forwarding.settings.#", "1"),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_forwarding.settings.0.phone_number", "+12025550148"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         td.ResourceName,
+				ImportState:                          true,
+				ImportStateIdFunc:                    importIDByExtension(td.ResourceName, "zoom_phone_user.test", "extension_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "holiday_id",
+				// call_forwarding is read only when it is configured
+				ImportStateVerifyIgnore: []string{"call_forwarding"},
+			},
+			// ImportState testing by extension number
+			{
+				ResourceName:                         td.ResourceName,
+				ImportState:                          true,
+				ImportStateIdFunc:                    importIDByExtension(td.ResourceName, "zoom_phone_user.test", "extension_number"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "holiday_id",
+				ImportStateVerifyIgnore:              []string{"call_forwarding"},
+			},
+			// Update and Read testing
+			{
+				Config: acceptance.ProviderConfig + extensionConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  extension_id = zoom_phone_user.test.extension_id
+
+  holiday = {
+    name = "acctest-updated"
+    from = "2030-12-31T00:00:00Z"
+    to   = "2031-01-02T00:00:00Z"
+  }
+
+  call_handling = {
+    call_not_answer_action        = 1 # Forward to voicemail
+    allow_callers_check_voicemail = false
+    connect_to_operator           = false
+    max_wait_time                 = 60
+    ring_mode                     = "simultaneous"
+  }
+}
+`, td.TerraformResourceType, td.ResourceLabel),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "holiday.name", "acctest-updated"),
+					resource.TestCheckResourceAttr(td.ResourceName, "holiday.from", "2030-12-31T00:00:00Z"),
+					resource.TestCheckResourceAttr(td.ResourceName, "holiday.to", "2031-01-02T00:00:00Z"),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_handling.allow_callers_check_voicemail", "false"),
+					resource.TestCheckResourceAttr(td.ResourceName, "call_handling.max_wait_time", "60"),
+					resource.TestCheckNoResourceAttr(td.ResourceName, "call_forwarding.settings.#"),
+				),
+			},
+			// Destroy testing, which deletes the holiday as the extension remains
+			{
+				Config: acceptance.ProviderConfig + extensionConfig,
+				Check:  testAccCheckHolidayHoursEmpty(t, "zoom_phone_user.test"),
+			},
+		},
+	})
+}
+
+// importIDByExtension returns the import ID of the holiday hours in the form of ${extension}/${holiday_id},
+// where the extension is given by the attribute of the resource of the extension.
+func importIDByExtension(resourceName, extensionResourceName, extensionAttribute string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		extension, err := acceptance.ImportStateIDFromAttribute(extensionResourceName, extensionAttribute)(s)
+		if err != nil {
+			return "", err
+		}
+		holidayID, err := acceptance.ImportStateIDFromAttribute(resourceName, "holiday_id")(s)
+		if err != nil {
+			return "", err
+		}
+		return extension + "/" + holidayID, nil
+	}
+}
+
+// testAccCheckHolidayHoursEmpty checks that the extension has no holiday hours.
+func testAccCheckHolidayHoursEmpty(t *testing.T, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		detail, err := testAccGetCallHandling(t, s, resourceName)
+		if err != nil {
+			return err
+		}
+		if len(detail.HolidayHours) > 0 {
+			return fmt.Errorf("%q still has %d holiday hours", resourceName, len(detail.HolidayHours))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+package callhandling_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testAccCallQueueConfig returns the config of a call queue, whose extension the call handling resources configure.
+func testAccCallQueueConfig(t *testing.T, td acceptance.TestData, extensionNumber int) string {
+	t.Helper()
+	return fmt.Sprintf(`
+resource "zoom_phone_call_queue" "test" {
+  name             = "acctest-%[1]s"
+  extension_number = %[2]d
+  site_id          = %[3]q
+}
+`, td.RandomStringOfLength(5), extensionNumber, acceptance.SiteID(t))
+}
+
+// testAccPhoneUserConfig returns the config of a phone user, whose extension the call handling resources configure
+// when they need call forwarding, which only users have.
+func testAccPhoneUserConfig(t *testing.T) string {
+	t.Helper()
+	return fmt.Sprintf(`
+resource "zoom_phone_user" "test" {
+  user_id = %[1]q
+  site_id = %[2]q
+}
+`, acceptance.UserID(t), acceptance.SiteID(t))
+}
+
+// testAccCustomHoursConfig returns the config of the business hours of the extension with custom hours on weekdays,
+// which the closed hours need.
+func testAccCustomHoursConfig(extensionID string) string {
+	var settings []string
+	for weekday := 1; weekday <= 7; weekday++ {
+		if weekday == 1 || weekday == 7 {
+			settings = append(settings, fmt.Sprintf(`{ weekday = %d, type = 0 }`, weekday))
+			continue
+		}
+		settings = append(settings, fmt.Sprintf(`{ weekday = %d, type = 2, from = "09:00", to = "18:00" }`, weekday))
+	}
+	return fmt.Sprintf(`
+resource "zoom_phone_call_handling_business_hours" "custom_hours" {
+  extension_id = %[1]s
+
+  custom_hours = {
+    type                   = 2 # Custom hours
+    allow_members_to_reset = false
+    settings = [
+      %[2]s,
+    ]
+  }
+
+  call_handling = {
+    call_not_answer_action        = 1 # Forward to voicemail
+    allow_callers_check_voicemail = true
+    connect_to_operator           = false
+    max_wait_time                 = 30
+    ring_mode                     = "simultaneous"
+  }
+}
+`, extensionID, strings.Join(settings, ",\n      "))
+}
+
+// testAccGetCallHandling reads the call handling settings of the extension of the resource in the state.
+func testAccGetCallHandling(t *testing.T, s *terraform.State, resourceName string) (*zoomphone.GetCallHandlingOK, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("%q not found in the state", resourceName)
+	}
+	return acceptance.ProviderData(t).PhoneClient.GetCallHandling(context.Background(), zoomphone.GetCallHandlingParams{
+		ExtensionId: rs.Primary.Attributes["extension_id"],
+	})
+}
@@ -2,22 +2,43 @@ package callqueue
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"iter"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
 )
 
-func newCrud(client *zoomphone.Client) *crud {
+func newCrud(client *zoomphone.Client, cache *shared.Cache, locks *shared.ObjectLocks) *crud {
 	return &crud{
 		client: client,
+		cache:  cache,
+		locks:  locks,
 	}
 }
 
 type crud struct {
 	client *zoomphone.Client
+	cache  *shared.Cache
+	locks  *shared.ObjectLocks
+}
+
+// list iterates over the call queues of the account, of a site when siteID is set, for the list resource.
+func (c *crud) list(ctx context.Context, siteID types.String) iter.Seq2[zoomphone.ListCallQueuesOKCallQueuesItem, error] {
+	return util.Paginate(ctx, 100, func(ctx context.Context, pageSize int, nextPageToken string) ([]zoomphone.ListCallQueuesOKCallQueuesItem, string, error) {
+		res, err := c.client.ListCallQueues(ctx, zoomphone.ListCallQueuesParams{
+			NextPageToken: util.ToPhoneOptPageToken(nextPageToken),
+			PageSize:      zoomphone.NewOptInt(pageSize), // max 100
+			SiteID:        util.ToPhoneOptString(siteID),
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return res.CallQueues, res.NextPageToken.Value, nil
+	})
 }
 
 func (c *crud) read(ctx context.Context, callQueueID types.String) (*readDto, error) {
@@ -25,13 +46,10 @@ func (c *crud) read(ctx context.Context, callQueueID types.String) (*readDto, er
 		CallQueueId: callQueueID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 300 {
-				return nil, nil // already deleted
-			}
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
-		return nil, fmt.Errorf("unable to read phone call queue: %v", err)
+		return nil, fmt.Errorf("unable to read phone call queue: %w", err)
 	}
 
 	var site *readDtoSite
@@ -55,29 +73,60 @@ func (c *crud) read(ctx context.Context, callQueueID types.String) (*readDto, er
 }
 
 func (c *crud) create(ctx context.Context, dto *createDto) (*createdDto, error) {
-	res, err := c.client.CreateCallQueue(ctx, zoomphone.OptCreateCallQueueReq{
-		Value: zoomphone.CreateCallQueueReq{
-			Name: dto.name.ValueString(),
-			// CostCenter/Department: to remove it, need to pass empty string. not null.
-			CostCenter:      zoomphone.NewOptString(util.ToPhoneOptString(dto.costCenter).Or("")),
-			Department:      zoomphone.NewOptString(util.ToPhoneOptString(dto.department).Or("")),
-			Description:     util.ToPhoneOptString(dto.description),
-			ExtensionNumber: util.ToPhoneOptInt64(dto.extensionNumber),
-			SiteID:          util.ToPhoneOptString(dto.siteID),
-		},
-		Set: true,
+	callQueueID, err := shared.ReconcileCreate(ctx, func(ctx context.Context) ([]string, error) {
+		return c.matching(ctx, dto)
+	}, func() (string, error) {
+		defer c.cache.InvalidateCallQueues()
+
+		res, err := c.client.CreateCallQueue(ctx, zoomphone.OptCreateCallQueueReq{
+			Value: zoomphone.CreateCallQueueReq{
+				Name: dto.name.ValueString(),
+				// CostCenter/Department: to remove it, need to pass empty string. not null.
+				CostCenter:      zoomphone.NewOptString(util.ToPhoneOptString(dto.costCenter).Or("")),
+				Department:      zoomphone.NewOptString(util.ToPhoneOptString(dto.department).Or("")),
+				Description:     util.ToPhoneOptString(dto.description),
+				ExtensionNumber: util.ToPhoneOptInt64(dto.extensionNumber),
+				SiteID:          util.ToPhoneOptString(dto.siteID),
+			},
+			Set: true,
+		})
+		if err != nil {
+			return "", err
+		}
+		return res.ID.Value, nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error creating phone call queue: %v", err)
+		return nil, fmt.Errorf("error creating phone call queue: %w", err)
 	}
 
 	return &createdDto{
-		callQueueID: util.FromOptString(res.ID),
+		callQueueID: types.StringValue(callQueueID),
 	}, nil
 }
 
+// matching returns the IDs of the call queues of the name, site and extension number of a create request.
+func (c *crud) matching(ctx context.Context, dto *createDto) ([]string, error) {
+	callQueues, err := c.cache.CallQueues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return lo.FilterMap(callQueues, func(item zoomphone.ListCallQueuesOKCallQueuesItem, _ int) (string, bool) {
+		return item.ID.Value, item.Name.Value == dto.name.ValueString() &&
+			(dto.siteID.IsNull() || item.Site.Value.ID.Value == dto.siteID.ValueString()) &&
+			(dto.extensionNumber.IsNull() || item.ExtensionNumber.Value == dto.extensionNumber.ValueInt64())
+	}), nil
+}
+
 func (c *crud) update(ctx context.Context, dto *updateDto) error {
-	err := c.client.UpdateCallQueue(ctx, zoomphone.OptUpdateCallQueueReq{
+	unlock, err := c.locks.Lock(ctx, "call_queue", dto.callQueueID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidateCallQueues()
+
+	err = c.client.UpdateCallQueue(ctx, zoomphone.OptUpdateCallQueueReq{
 		Value: zoomphone.UpdateCallQueueReq{
 			// CostCenter/Department: to remove it, need to pass empty string. not null.
 			CostCenter:      zoomphone.NewOptString(util.ToPhoneOptString(dto.costCenter).Or("")),
@@ -93,24 +142,29 @@ func (c *crud) update(ctx context.Context, dto *updateDto) error {
 		CallQueueId: dto.callQueueID.ValueString(),
 	})
 	if err != nil {
-		return fmt.Errorf("error updating phone call queue: %v", err)
+		return fmt.Errorf("error updating phone call queue: %w", err)
 	}
 
 	return nil
 }
 
 func (c *crud) delete(ctx context.Context, callQueueId types.String) error {
-	err := c.client.DeleteACallQueue(ctx, zoomphone.DeleteACallQueueParams{
+	unlock, err := c.locks.Lock(ctx, "call_queue", callQueueId.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidateCallQueues()
+
+	err = c.client.DeleteACallQueue(ctx, zoomphone.DeleteACallQueueParams{
 		CallQueueId: callQueueId.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 404 {
-				return nil
-			}
+		if util.IsNotFound(err) {
+			return nil
 		}
-		return fmt.Errorf("error deleting phone call queue: %v", err)
+		return fmt.Errorf("error deleting phone call queue: %w", err)
 	}
 
 	return nil
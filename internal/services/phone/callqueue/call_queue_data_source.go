@@ -3,26 +3,34 @@ package callqueue
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
-	_ datasource.DataSource              = &tfDataSource{}
-	_ datasource.DataSourceWithConfigure = &tfDataSource{}
+	_ datasource.DataSource                   = &tfDataSource{}
+	_ datasource.DataSourceWithConfigure      = &tfDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &tfDataSource{}
 )
 
+// dataSourceScopes are the OAuth scopes this data source requires.
+var dataSourceScopes = shared.Scopes{
+	"phone:read:call_queue:admin",
+}
+
 func NewPhoneCallQueueDataSource() datasource.DataSource {
 	return &tfDataSource{}
 }
 
 type tfDataSource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (d *tfDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -37,7 +45,12 @@ func (d *tfDataSource) Configure(_ context.Context, req datasource.ConfigureRequ
 		)
 		return
 	}
-	d.crud = newCrud(data.PhoneClient)
+	d.crud = newCrud(data.PhoneClient, data.Cache, data.Locks)
+	d.providerData = data
+}
+
+func (d *tfDataSource) ValidateConfig(_ context.Context, _ datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(d.providerData.ValidateScopes(dataSourceScopes)...)
 }
 
 func (d *tfDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -51,9 +64,7 @@ func (d *tfDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, res
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:call_queue:admin`",
-		}, ", ") + ".",
+This resource requires the ` + dataSourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Required:            true,
@@ -88,6 +99,7 @@ This resource requires the ` + strings.Join([]string{
 				MarkdownDescription: `Status of the Call Queue.
   - Allowed: active┃inactive`,
 			},
+			"sub_account_id": shared.SubAccountIDDataSourceAttribute(),
 		},
 	}
 }
@@ -101,18 +113,23 @@ type dataSourceModel struct {
 	Name            types.String `tfsdk:"name"`
 	SiteID          types.String `tfsdk:"site_id"`
 	Status          types.String `tfsdk:"status"`
+	SubAccountID    types.String `tfsdk:"sub_account_id"`
 }
 
 func (d *tfDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "data.zoom_phone_call_queue", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var data dataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, data.SubAccountID)
 
 	dto, err := d.crud.read(ctx, data.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone call queue", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error reading phone call queue", err))
 		return
 	}
 
@@ -133,6 +150,7 @@ func (d *tfDataSource) Read(ctx context.Context, req datasource.ReadRequest, res
 		Name:            dto.name,
 		SiteID:          siteID,
 		Status:          dto.status,
+		SubAccountID:    data.SubAccountID,
 	}
 	diags := resp.State.Set(ctx, &output)
 	resp.Diagnostics.Append(diags...)
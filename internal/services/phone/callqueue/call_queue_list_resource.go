@@ -0,0 +1,106 @@
+package callqueue
+
+import (
+	"context"
+	"iter"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ list.ListResource                   = &tfListResource{}
+	_ list.ListResourceWithConfigure      = &tfListResource{}
+	_ list.ListResourceWithValidateConfig = &tfListResource{}
+)
+
+// listResourceScopes are the OAuth scopes this list resource requires.
+var listResourceScopes = shared.Scopes{
+	"phone:read:list_call_queues:admin",
+	"phone:read:call_queue:admin",
+}
+
+func NewPhoneCallQueueListResource() list.ListResource {
+	return &tfListResource{resource: &tfResource{}}
+}
+
+// tfListResource lists the objects of the resource, and reads them with it when the resource is requested.
+type tfListResource struct {
+	resource *tfResource
+}
+
+type listResourceModel struct {
+	SiteID       types.String `tfsdk:"site_id"`
+	SubAccountID types.String `tfsdk:"sub_account_id"`
+}
+
+func (l *tfListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	l.resource.Configure(ctx, req, resp)
+}
+
+func (l *tfListResource) ValidateListResourceConfig(_ context.Context, _ list.ValidateConfigRequest, resp *list.ValidateConfigResponse) {
+	resp.Diagnostics.Append(l.resource.providerData.ValidateScopes(listResourceScopes)...)
+}
+
+func (l *tfListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	l.resource.Metadata(ctx, req, resp)
+}
+
+func (l *tfListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: `Lists the call queues of Zoom Phone. Run ` + "`terraform query`" + ` to import them.
+
+## API Permissions
+
+The following API permissions are required in order to use this list resource.
+This list resource requires the ` + listResourceScopes.Markdown() + ".",
+		Attributes: map[string]listschema.Attribute{
+			"site_id": listschema.StringAttribute{
+				MarkdownDescription: "The ID of the site to list the call queues of. Those of the whole account are listed when it is not set.",
+				Optional:            true,
+			},
+			"sub_account_id": shared.SubAccountIDListAttribute(),
+		},
+	}
+}
+
+func (l *tfListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config listResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	ctx = shared.WithSubAccountID(ctx, config.SubAccountID)
+
+	stream.Results = shared.ListResults(ctx, req, "zoom_phone_call_queue", "Error listing phone call queues",
+		func(ctx context.Context) iter.Seq2[zoomphone.ListCallQueuesOKCallQueuesItem, error] {
+			return l.resource.crud.list(ctx, config.SiteID)
+		},
+		func(ctx context.Context, item zoomphone.ListCallQueuesOKCallQueuesItem, result *list.ListResult) bool {
+			id := util.FromOptString(item.ID)
+			result.DisplayName = item.Name.Value
+			result.Diagnostics.Append(shared.SetIdentity(ctx, result.Identity, "id", id, config.SubAccountID)...)
+			if !req.IncludeResource {
+				return true
+			}
+
+			output, err := l.resource.read(ctx, id, types.StringNull(), config.SubAccountID, shared.NullTimeouts())
+			if err != nil {
+				result.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error reading phone call queue", err))
+				return true
+			}
+			if output == nil {
+				return false // deleted while listing
+			}
+			result.Diagnostics.Append(result.Resource.Set(ctx, output)...)
+			return true
+		},
+	)
+}
@@ -3,31 +3,45 @@ package callqueue
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
-	_ resource.Resource                = &tfResource{}
-	_ resource.ResourceWithConfigure   = &tfResource{}
-	_ resource.ResourceWithImportState = &tfResource{}
+	_ resource.Resource                   = &tfResource{}
+	_ resource.ResourceWithConfigure      = &tfResource{}
+	_ resource.ResourceWithImportState    = &tfResource{}
+	_ resource.ResourceWithIdentity       = &tfResource{}
+	_ resource.ResourceWithValidateConfig = &tfResource{}
 )
 
+// resourceScopes are the OAuth scopes this resource requires.
+var resourceScopes = shared.Scopes{
+	"phone:read:call_queue:admin",
+	"phone:write:call_queue:admin",
+	"phone:update:call_queue:admin",
+	"phone:delete:call_queue:admin",
+}
+
 func NewPhoneCallQueueResource() resource.Resource {
 	return &tfResource{}
 }
 
 type tfResource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -42,29 +56,30 @@ func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest,
 		)
 		return
 	}
-	r.crud = newCrud(data.PhoneClient)
+	r.crud = newCrud(data.PhoneClient, data.Cache, data.Locks)
+	r.providerData = data
+}
+
+func (r *tfResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(resourceScopes)...)
 }
 
 func (r *tfResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_phone_call_queue"
 }
 
-func (r *tfResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tfResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Call queues allow you to route incoming calls to a group of users. For instance, you can use call queues to route calls to various departments in your organization such as sales, engineering, billing, customer service etc.
 
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:call_queue:admin`",
-			"`phone:write:call_queue:admin`",
-			"`phone:update:call_queue:admin`",
-			"`phone:delete:call_queue:admin`",
-		}, ", ") + ".",
+This resource requires the ` + resourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 				MarkdownDescription: "Unique identifier of the Call Queue.",
 			},
 			"cost_center": schema.StringAttribute{
@@ -77,6 +92,7 @@ This resource requires the ` + strings.Join([]string{
 			},
 			"extension_id": schema.StringAttribute{
 				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 				MarkdownDescription: "Extension ID.",
 			},
 			"extension_number": schema.Int64Attribute{
@@ -111,33 +127,57 @@ This resource requires the ` + strings.Join([]string{
 				MarkdownDescription: `Status of the Call Queue.
   - Allowed: active┃inactive`,
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
 
 type resourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	CostCenter      types.String `tfsdk:"cost_center"`
-	Department      types.String `tfsdk:"department"`
-	ExtensionID     types.String `tfsdk:"extension_id"`
-	ExtensionNumber types.Int64  `tfsdk:"extension_number"`
-	Name            types.String `tfsdk:"name"`
-	Description     types.String `tfsdk:"description"`
-	SiteID          types.String `tfsdk:"site_id"`
-	Status          types.String `tfsdk:"status"`
+	ID              types.String   `tfsdk:"id"`
+	CostCenter      types.String   `tfsdk:"cost_center"`
+	Department      types.String   `tfsdk:"department"`
+	ExtensionID     types.String   `tfsdk:"extension_id"`
+	ExtensionNumber types.Int64    `tfsdk:"extension_number"`
+	Name            types.String   `tfsdk:"name"`
+	Description     types.String   `tfsdk:"description"`
+	SiteID          types.String   `tfsdk:"site_id"`
+	Status          types.String   `tfsdk:"status"`
+	SubAccountID    types.String   `tfsdk:"sub_account_id"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *tfResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = shared.IdentitySchema("id")
 }
 
 func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	// The identity is set even when the object has been deleted outside of Terraform, as it does not change.
+	resp.Diagnostics.Append(shared.SetIdentity(ctx, resp.Identity, "id", state.ID, state.SubAccountID)...)
+
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
-	output, err := r.read(ctx, state.ID, state.Description)
+	output, err := r.read(ctx, state.ID, state.Description, state.SubAccountID, state.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone call queue", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error reading phone call queue", err))
 		return
 	}
 
@@ -148,10 +188,10 @@ func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 	}
 }
 
-func (r *tfResource) read(ctx context.Context, callQueueId, description types.String) (*resourceModel, error) {
+func (r *tfResource) read(ctx context.Context, callQueueId, description, subAccountID types.String, timeouts timeouts.Value) (*resourceModel, error) {
 	dto, err := r.crud.read(ctx, callQueueId)
 	if err != nil {
-		return nil, fmt.Errorf("error read: %v", err)
+		return nil, fmt.Errorf("error read: %w", err)
 	}
 	if dto == nil {
 		return nil, nil // already deleted
@@ -169,19 +209,34 @@ func (r *tfResource) read(ctx context.Context, callQueueId, description types.St
 		ExtensionNumber: dto.extensionNumber,
 		Name:            dto.name,
 		// Description: dto.description, // get api not supported yet
-		Description: description,
-		SiteID:      siteID,
-		Status:      dto.status,
+		Description:  description,
+		SiteID:       siteID,
+		Status:       dto.status,
+		SubAccountID: subAccountID,
+		Timeouts:     timeouts,
 	}, nil
 }
 
 func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_queue")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	ret, err := r.crud.create(ctx, &createDto{
 		name:            plan.Name,
@@ -192,10 +247,7 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 		description:     plan.Description,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone call queue",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone call queue", err))
 		return
 	}
 
@@ -212,16 +264,13 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 	}); err != nil {
 		// TODO change delete logic with marking resource as taint
 		_ = r.crud.delete(ctx, ret.callQueueID)
-		resp.Diagnostics.AddError(
-			"Error creating phone call queue on updating",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone call queue on updating", err))
 		return
 	}
 
-	output, err := r.read(ctx, ret.callQueueID, plan.Description)
+	output, err := r.read(ctx, ret.callQueueID, plan.Description, plan.SubAccountID, plan.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating phone call queue on reading", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone call queue on reading", err))
 		return
 	}
 
@@ -230,9 +279,13 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(shared.SetIdentityFromState(ctx, resp.State, resp.Identity, "id")...)
 }
 
 func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -243,6 +296,16 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		)
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_queue")
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, shared.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	if err := r.crud.update(ctx, &updateDto{
 		callQueueID:     plan.ID,
@@ -254,20 +317,13 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		description:     plan.Description,
 		status:          plan.Status,
 	}); err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating phone call queue",
-			fmt.Sprintf(
-				"Could not update phone call queue %s, unexpected error: %s",
-				plan.ID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error updating phone call queue", err))
 		return
 	}
 
-	output, err := r.read(ctx, plan.ID, plan.Description)
+	output, err := r.read(ctx, plan.ID, plan.Description, plan.SubAccountID, plan.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating phone call queue", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error updating phone call queue", err))
 		return
 	}
 
@@ -276,25 +332,32 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(shared.SetIdentityFromState(ctx, resp.State, resp.Identity, "id")...)
 }
 
 func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_queue")
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	if err := r.crud.delete(ctx, state.ID); err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone call queue",
-			fmt.Sprintf(
-				"Could not delete phone call queue %s, unexpected error: %s",
-				state.ID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error deleting phone call queue", err))
 		return
 	}
 
@@ -304,5 +367,5 @@ func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 }
 
 func (r *tfResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	shared.ImportStateByKey(ctx, path.Root("id"), req, resp, "call queue", r.providerData.Cache.CallQueueImportKey)
 }
@@ -0,0 +1,119 @@
+package callqueue_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccPhoneCallQueue(t *testing.T) {
+	td := acceptance.NewTestData(t, "zoom_phone_call_queue", "test")
+	siteID := acceptance.SiteID(t)
+	name := fmt.Sprintf("acctest-%s", td.RandomStringOfLength(5))
+	updatedName := fmt.Sprintf("acctest-%s", td.RandomStringOfLength(5))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactories(t),
+		CheckDestroy: acceptance.CheckDestroy(td.TerraformResourceType, func(ctx context.Context, rs *terraform.ResourceState) error {
+			_, err := acceptance.ProviderData(t).PhoneClient.GetACallQueue(ctx, zoomphone.GetACallQueueParams{
+				CallQueueId: rs.Primary.ID,
+			})
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: acceptance.ProviderConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  name             = %[3]q
+  extension_number = 81001
+  site_id          = %[4]q
+  description      = "created by acctest"
+}
+`, td.TerraformResourceType, td.ResourceLabel, name, siteID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "name", name),
+					resource.TestCheckResourceAttr(td.ResourceName, "extension_number", "81001"),
+					resource.TestCheckResourceAttr(td.ResourceName, "site_id", siteID),
+					resource.TestCheckResourceAttr(td.ResourceName, "status", "active"),
+					resource.TestCheckNoResourceAttr(td.ResourceName, "cost_center"),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "id"),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "extension_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      td.ResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// description is not returned by Zoom
+				ImportStateVerifyIgnore: []string{"description"},
+			},
+			// ImportState testing by site name and name
+			{
+				ResourceName:            td.ResourceName,
+				ImportState:             true,
+				ImportStateIdFunc:       importIDBySiteName(t, td.ResourceName),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"description"},
+			},
+			// Update and Read testing
+			{
+				Config: acceptance.ProviderConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  name             = %[3]q
+  extension_number = 81002
+  site_id          = %[4]q
+  cost_center      = "acctest-cost-center"
+  department       = "acctest-department"
+  status           = "inactive"
+}
+`, td.TerraformResourceType, td.ResourceLabel, updatedName, siteID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "name", updatedName),
+					resource.TestCheckResourceAttr(td.ResourceName, "extension_number", "81002"),
+					resource.TestCheckResourceAttr(td.ResourceName, "cost_center", "acctest-cost-center"),
+					resource.TestCheckResourceAttr(td.ResourceName, "department", "acctest-department"),
+					resource.TestCheckResourceAttr(td.ResourceName, "status", "inactive"),
+				),
+			},
+			// Clearing the cost center and department
+			{
+				Config: acceptance.ProviderConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  name             = %[3]q
+  extension_number = 81002
+  site_id          = %[4]q
+}
+`, td.TerraformResourceType, td.ResourceLabel, updatedName, siteID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(td.ResourceName, "cost_center"),
+					resource.TestCheckNoResourceAttr(td.ResourceName, "department"),
+					resource.TestCheckResourceAttr(td.ResourceName, "status", "active"),
+				),
+			},
+		},
+	})
+}
+
+// importIDBySiteName returns the import ID of the call queue in the form of ${site_name}/${name}.
+func importIDBySiteName(t *testing.T, resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("%q not found in the state", resourceName)
+		}
+		site, err := acceptance.ProviderData(t).PhoneClient.GetASite(context.Background(), zoomphone.GetASiteParams{
+			SiteId: rs.Primary.Attributes["site_id"],
+		})
+		if err != nil {
+			return "", err
+		}
+		return site.Name.Value + "/" + rs.Primary.Attributes["name"], nil
+	}
+}
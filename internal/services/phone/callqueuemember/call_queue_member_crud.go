@@ -2,44 +2,52 @@ package callqueuemember
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/samber/lo"
 )
 
-func newCrud(client *zoomphone.Client) *crud {
+func newCrud(client *zoomphone.Client, cache *shared.Cache, locks *shared.ObjectLocks) *crud {
 	return &crud{
 		client: client,
+		cache:  cache,
+		locks:  locks,
 	}
 }
 
 type crud struct {
 	client *zoomphone.Client
+	cache  *shared.Cache
+	locks  *shared.ObjectLocks
 }
 
 func (c *crud) read(ctx context.Context, callQueueID types.String) (*readDto, error) {
-	var callQueueMembers []*readDtoCallQueueMember
-	nextPageToken := zoomphone.OptString{}
-	for {
+	members, err := util.CollectAll(util.Paginate(ctx, 300, func(ctx context.Context, pageSize int, nextPageToken string) ([]zoomphone.ListCallQueueMembersOKCallQueueMembersItem, string, error) {
 		ret, err := c.client.ListCallQueueMembers(ctx, zoomphone.ListCallQueueMembersParams{
 			CallQueueId:   callQueueID.ValueString(),
-			NextPageToken: nextPageToken,
-			PageSize:      zoomphone.NewOptInt(300), // Constraints: Max 300
+			NextPageToken: util.ToPhoneOptPageToken(nextPageToken),
+			PageSize:      zoomphone.NewOptInt(pageSize), // Constraints: Max 300
 		})
 		if err != nil {
-			var status *zoomphone.ErrorResponseStatusCode
-			if errors.As(err, &status) {
-				if status.StatusCode == 400 && status.Response.Code.Value == 300 {
-					return nil, nil // already deleted
-				}
-			}
-			return nil, fmt.Errorf("unable to read phone call queue members: %v", err)
+			return nil, "", err
+		}
+		return ret.CallQueueMembers, ret.NextPageToken.Value, nil
+	}))
+	if err != nil {
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
-		callQueueMembers = append(callQueueMembers, lo.Map(ret.CallQueueMembers, func(member zoomphone.ListCallQueueMembersOKCallQueueMembersItem, _index int) *readDtoCallQueueMember {
+		return nil, fmt.Errorf("unable to read phone call queue members: %w", err)
+	}
+
+	return &readDto{
+		callQueueMembers: lo.Map(members, func(member zoomphone.ListCallQueueMembersOKCallQueueMembersItem, _index int) *readDtoCallQueueMember {
 			return &readDtoCallQueueMember{
 				id:          util.FromOptString(member.ID),
 				name:        util.FromOptString(member.Name),
@@ -47,15 +55,7 @@ func (c *crud) read(ctx context.Context, callQueueID types.String) (*readDto, er
 				receiveCall: util.FromOptBool(member.ReceiveCall),
 				extensionID: util.FromOptString(member.ExtensionID),
 			}
-		})...)
-		if ret.NextPageToken.Value == "" {
-			break
-		}
-		nextPageToken = ret.NextPageToken
-	}
-
-	return &readDto{
-		callQueueMembers: callQueueMembers,
+		}),
 	}, nil
 }
 
@@ -75,30 +75,22 @@ func (c *crud) readUsersByExtensionIDs(ctx context.Context, extensionIDs []types
 	})
 }
 
+// readUsersByCond resolves members through the phone users cached for the run,
+// instead of paging through all phone users on every read of every resource.
 func (c *crud) readUsersByCond(ctx context.Context, cond func(u zoomphone.ListPhoneUsersOKUsersItem) bool) (*readUsersDto, error) {
+	phoneUsers, err := c.cache.PhoneUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
 	var users []*readUsersDtoUser
-	nextPageToken := zoomphone.OptString{}
-	for {
-		res, err := c.client.ListPhoneUsers(ctx, zoomphone.ListPhoneUsersParams{
-			NextPageToken: nextPageToken,
-			PageSize:      zoomphone.NewOptInt(100), // Max 100
-		})
-		if err != nil {
-			return nil, fmt.Errorf("error listing phone users: %v", err)
-		}
-		for _, user := range res.Users {
-			isSearchedUser := cond(user)
-			if isSearchedUser {
-				users = append(users, &readUsersDtoUser{
-					email:       util.FromOptString(user.Email),
-					extensionID: util.FromOptString(user.ExtensionID),
-				})
-			}
-		}
-		if res.NextPageToken.Value == "" {
-			break
+	for _, user := range phoneUsers {
+		isSearchedUser := cond(user)
+		if isSearchedUser {
+			users = append(users, &readUsersDtoUser{
+				email:       util.FromOptString(user.Email),
+				extensionID: util.FromOptString(user.ExtensionID),
+			})
 		}
-		nextPageToken = res.NextPageToken
 	}
 	return &readUsersDto{
 		users: users,
@@ -106,6 +98,12 @@ func (c *crud) readUsersByCond(ctx context.Context, cond func(u zoomphone.ListPh
 }
 
 func (c *crud) assign(ctx context.Context, dto *assignDto) error {
+	unlock, err := c.locks.Lock(ctx, "call_queue", dto.callQueueID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	commonAreaIDs := make([]string, len(dto.commonAreaIDs))
 	for i, id := range dto.commonAreaIDs {
 		commonAreaIDs[i] = id.ValueString()
@@ -120,53 +118,95 @@ func (c *crud) assign(ctx context.Context, dto *assignDto) error {
 
 	// A maximum of 10 members can be added at a time.
 	for _, commonAreaIDChunked := range lo.Chunk(commonAreaIDs, 10) {
-		err := c.client.AddMembersToCallQueue(ctx, zoomphone.OptAddMembersToCallQueueReq{
-			Value: zoomphone.AddMembersToCallQueueReq{
-				Members: zoomphone.NewOptAddMembersToCallQueueReqMembers(zoomphone.AddMembersToCallQueueReqMembers{
-					CommonAreaIds: commonAreaIDChunked,
-				}),
-			},
-			Set: true,
-		}, zoomphone.AddMembersToCallQueueParams{
-			CallQueueId: dto.callQueueID.ValueString(),
+		err := c.addMembers(ctx, dto.callQueueID, zoomphone.AddMembersToCallQueueReqMembers{
+			CommonAreaIds: commonAreaIDChunked,
 		})
 		if err != nil {
-			return fmt.Errorf("error assigning phone call queue members: %v", err)
+			return fmt.Errorf("error assigning phone call queue members: %w", err)
 		}
 	}
 	for _, userChunked := range lo.Chunk(users, 10) {
-		err := c.client.AddMembersToCallQueue(ctx, zoomphone.OptAddMembersToCallQueueReq{
-			Value: zoomphone.AddMembersToCallQueueReq{
-				Members: zoomphone.NewOptAddMembersToCallQueueReqMembers(zoomphone.AddMembersToCallQueueReqMembers{
-					Users: userChunked,
-				}),
-			},
-			Set: true,
-		}, zoomphone.AddMembersToCallQueueParams{
-			CallQueueId: dto.callQueueID.ValueString(),
+		err := c.addMembers(ctx, dto.callQueueID, zoomphone.AddMembersToCallQueueReqMembers{
+			Users: userChunked,
 		})
 		if err != nil {
-			return fmt.Errorf("error assigning phone call queue members: %v", err)
+			return fmt.Errorf("error assigning phone call queue members: %w", err)
 		}
 	}
 
 	return nil
 }
 
+// addMembers adds members to the call queue. When the request fails without telling whether Zoom applied it,
+// it succeeds if the call queue has all the members by now.
+func (c *crud) addMembers(ctx context.Context, callQueueID types.String, members zoomphone.AddMembersToCallQueueReqMembers) error {
+	err := c.client.AddMembersToCallQueue(ctx, zoomphone.OptAddMembersToCallQueueReq{
+		Value: zoomphone.AddMembersToCallQueueReq{
+			Members: zoomphone.NewOptAddMembersToCallQueueReqMembers(members),
+		},
+		Set: true,
+	}, zoomphone.AddMembersToCallQueueParams{
+		CallQueueId: callQueueID.ValueString(),
+	})
+	if err == nil || !util.IsAmbiguous(err) {
+		return err
+	}
+	if added, lookupErr := c.hasMembers(ctx, callQueueID, members); lookupErr != nil || !added {
+		return err
+	}
+	return nil
+}
+
+// hasMembers reports whether the call queue has all the members, where a user given by email is looked up in the phone users.
+func (c *crud) hasMembers(ctx context.Context, callQueueID types.String, members zoomphone.AddMembersToCallQueueReqMembers) (bool, error) {
+	memberIDs := slices.Clone(members.CommonAreaIds)
+	if len(members.Users) > 0 {
+		phoneUsers, err := c.cache.PhoneUsers(ctx)
+		if err != nil {
+			return false, err
+		}
+		for _, user := range members.Users {
+			if user.ID.IsSet() {
+				memberIDs = append(memberIDs, user.ID.Value)
+				continue
+			}
+			phoneUser, ok := lo.Find(phoneUsers, func(item zoomphone.ListPhoneUsersOKUsersItem) bool {
+				return strings.EqualFold(item.Email.Value, user.Email.Value)
+			})
+			if !ok {
+				return false, nil
+			}
+			memberIDs = append(memberIDs, phoneUser.ID.Value)
+		}
+	}
+
+	current, err := c.read(ctx, callQueueID)
+	if err != nil || current == nil {
+		return false, err
+	}
+	currentIDs := lo.Map(current.callQueueMembers, func(member *readDtoCallQueueMember, _ int) string {
+		return member.id.ValueString()
+	})
+	return lo.Every(currentIDs, memberIDs), nil
+}
+
 func (c *crud) unassign(ctx context.Context, dto *unassignDto) error {
+	unlock, err := c.locks.Lock(ctx, "call_queue", dto.callQueueID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	for _, memberID := range dto.memberIDs {
 		err := c.client.UnassignMemberFromCallQueue(ctx, zoomphone.UnassignMemberFromCallQueueParams{
 			CallQueueId: dto.callQueueID.ValueString(),
 			MemberId:    memberID.ValueString(),
 		})
 		if err != nil {
-			var status *zoomphone.ErrorResponseStatusCode
-			if errors.As(err, &status) {
-				if status.StatusCode == 404 {
-					continue
-				}
+			if util.IsNotFound(err) {
+				continue
 			}
-			return fmt.Errorf("error unassigning phone call queue members: %v", err)
+			return fmt.Errorf("error unassigning phone call queue members: %w", err)
 		}
 	}
 
@@ -174,17 +214,20 @@ func (c *crud) unassign(ctx context.Context, dto *unassignDto) error {
 }
 
 func (c *crud) unassignAll(ctx context.Context, callQueueID types.String) error {
-	err := c.client.UnassignAllMembers(ctx, zoomphone.UnassignAllMembersParams{
+	unlock, err := c.locks.Lock(ctx, "call_queue", callQueueID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = c.client.UnassignAllMembers(ctx, zoomphone.UnassignAllMembersParams{
 		CallQueueId: callQueueID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 404 {
-				return nil
-			}
+		if util.IsNotFound(err) {
+			return nil
 		}
-		return fmt.Errorf("error unassigning all phone call queue members: %v", err)
+		return fmt.Errorf("error unassigning all phone call queue members: %w", err)
 	}
 	return nil
 }
@@ -3,9 +3,10 @@ package callqueuemember
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -17,17 +18,27 @@ import (
 )
 
 var (
-	_ resource.Resource                = &tfResource{}
-	_ resource.ResourceWithConfigure   = &tfResource{}
-	_ resource.ResourceWithImportState = &tfResource{}
+	_ resource.Resource                   = &tfResource{}
+	_ resource.ResourceWithConfigure      = &tfResource{}
+	_ resource.ResourceWithImportState    = &tfResource{}
+	_ resource.ResourceWithValidateConfig = &tfResource{}
 )
 
+// resourceScopes are the OAuth scopes this resource requires.
+var resourceScopes = shared.Scopes{
+	"phone:read:list_users:admin",
+	"phone:read:list_call_queue_members:admin",
+	"phone:write:call_queue_member:admin",
+	"phone:delete:call_queue_member:admin",
+}
+
 func NewPhoneCallQueueMembersResource() resource.Resource {
 	return &tfResource{}
 }
 
 type tfResource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -42,26 +53,26 @@ func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest,
 		)
 		return
 	}
-	r.crud = newCrud(data.PhoneClient)
+	r.crud = newCrud(data.PhoneClient, data.Cache, data.Locks)
+	r.providerData = data
+}
+
+func (r *tfResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(resourceScopes)...)
 }
 
 func (r *tfResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_phone_call_queue_members"
 }
 
-func (r *tfResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tfResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Call queues allow you to route incoming calls to a group of users. For instance, you can use call queue members to route calls to various departments in your organization such as sales, engineering, billing, customer service etc.
 
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:list_users:admin`",
-			"`phone:read:list_call_queue_members:admin`",
-			"`phone:write:call_queue_member:admin`",
-			"`phone:delete:call_queue_member:admin`",
-		}, ", ") + ".",
+This resource requires the ` + resourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"call_queue_id": schema.StringAttribute{
 				Required:            true,
@@ -122,14 +133,20 @@ This resource requires the ` + strings.Join([]string{
 					},
 				},
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
 
 type resourceModel struct {
-	CallQueueID types.String               `tfsdk:"call_queue_id"`
-	CommonAreas []*resourceModelCommonArea `tfsdk:"common_areas"`
-	Users       []*resourceModelUser       `tfsdk:"users"`
+	CallQueueID  types.String               `tfsdk:"call_queue_id"`
+	CommonAreas  []*resourceModelCommonArea `tfsdk:"common_areas"`
+	Users        []*resourceModelUser       `tfsdk:"users"`
+	SubAccountID types.String               `tfsdk:"sub_account_id"`
+	Timeouts     timeouts.Value             `tfsdk:"timeouts"`
 }
 
 type resourceModelCommonArea struct {
@@ -148,16 +165,28 @@ type resourceModelUser struct {
 }
 
 func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue_members", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	output, err := r.read(ctx, state)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone call queue members", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error reading phone call queue members", err))
 		return
 	}
 
@@ -218,9 +247,11 @@ func (r *tfResource) read(ctx context.Context, plan resourceModel) (*resourceMod
 	}
 
 	return &resourceModel{
-		CallQueueID: plan.CallQueueID,
-		CommonAreas: commonAreas,
-		Users:       users,
+		CallQueueID:  plan.CallQueueID,
+		CommonAreas:  commonAreas,
+		Users:        users,
+		SubAccountID: plan.SubAccountID,
+		Timeouts:     plan.Timeouts,
 	}, nil
 }
 
@@ -300,24 +331,34 @@ func (r *tfResource) sync(ctx context.Context, plan resourceModel) error {
 }
 
 func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue_members", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_queue_members")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, plan); err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone call queue members",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error creating phone call queue members", err))
 		return
 	}
 
 	output, err := r.read(ctx, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating phone call queue members on reading", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error creating phone call queue members on reading", err))
 		return
 	}
 
@@ -329,6 +370,9 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 }
 
 func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue_members", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -339,18 +383,25 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		)
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_queue_members")
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, shared.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, plan); err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone call queue members",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error creating phone call queue members", err))
 		return
 	}
 
 	output, err := r.read(ctx, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating phone call queue members", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error updating phone call queue members", err))
 		return
 	}
 
@@ -362,22 +413,28 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 }
 
 func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue_members", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_queue_members")
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	if err := r.crud.unassignAll(ctx, state.CallQueueID); err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone call queue members",
-			fmt.Sprintf(
-				"Could not delete phone call queue members %s, unexpected error: %s",
-				state.CallQueueID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error deleting phone call queue members", err))
 		return
 	}
 
@@ -387,5 +444,5 @@ func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 }
 
 func (r *tfResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("call_queue_id"), req, resp)
+	shared.ImportStateByKey(ctx, path.Root("call_queue_id"), req, resp, "call queue", r.providerData.Cache.CallQueueImportKey)
 }
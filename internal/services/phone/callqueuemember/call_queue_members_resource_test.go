@@ -0,0 +1,89 @@
+package callqueuemember_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccPhoneCallQueueMembers(t *testing.T) {
+	td := acceptance.NewTestData(t, "zoom_phone_call_queue_members", "test")
+	siteID := acceptance.SiteID(t)
+	email1, email2 := acceptance.PhoneUserEmails(t)
+	commonAreaID := acceptance.CommonAreaID(t)
+	callQueueConfig := fmt.Sprintf(`
+resource "zoom_phone_call_queue" "test" {
+  name             = "acctest-%[1]s"
+  extension_number = 81011
+  site_id          = %[2]q
+}
+`, td.RandomStringOfLength(5), siteID)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactories(t),
+		// The members are gone along with the call queue.
+		CheckDestroy: acceptance.CheckDestroy(td.TerraformResourceType, func(ctx context.Context, rs *terraform.ResourceState) error {
+			_, err := acceptance.ProviderData(t).PhoneClient.ListCallQueueMembers(ctx, zoomphone.ListCallQueueMembersParams{
+				CallQueueId: rs.Primary.Attributes["call_queue_id"],
+			})
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: acceptance.ProviderConfig + callQueueConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  call_queue_id = zoom_phone_call_queue.test.id
+  users = [
+    { email = %[3]q },
+  ]
+  common_areas = [
+    { id = %[4]q },
+  ]
+}
+`, td.TerraformResourceType, td.ResourceLabel, email1, commonAreaID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(td.ResourceName, "call_queue_id", "zoom_phone_call_queue.test", "id"),
+					resource.TestCheckResourceAttr(td.ResourceName, "users.#", "1"),
+					resource.TestCheckResourceAttr(td.ResourceName, "users.0.email", email1),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "users.0.id"),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "users.0.extension_id"),
+					resource.TestCheckResourceAttr(td.ResourceName, "common_areas.#", "1"),
+					resource.TestCheckResourceAttr(td.ResourceName, "common_areas.0.id", commonAreaID),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "common_areas.0.extension_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         td.ResourceName,
+				ImportState:                          true,
+				ImportStateIdFunc:                    acceptance.ImportStateIDFromAttribute(td.ResourceName, "call_queue_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "call_queue_id",
+			},
+			// Update and Read testing
+			{
+				Config: acceptance.ProviderConfig + callQueueConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  call_queue_id = zoom_phone_call_queue.test.id
+  users = [
+    { email = %[3]q },
+    { email = %[4]q },
+  ]
+}
+`, td.TerraformResourceType, td.ResourceLabel, email1, email2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "users.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(td.ResourceName, "users.*", map[string]string{"email": email1}),
+					resource.TestCheckTypeSetElemNestedAttrs(td.ResourceName, "users.*", map[string]string{"email": email2}),
+					resource.TestCheckNoResourceAttr(td.ResourceName, "common_areas.#"),
+				),
+			},
+		},
+	})
+}
@@ -2,23 +2,27 @@ package callqueuephonenumber
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/samber/lo"
 )
 
-func newCrud(client *zoomphone.Client) *crud {
+func newCrud(client *zoomphone.Client, cache *shared.Cache, locks *shared.ObjectLocks) *crud {
 	return &crud{
 		client: client,
+		cache:  cache,
+		locks:  locks,
 	}
 }
 
 type crud struct {
 	client *zoomphone.Client
+	cache  *shared.Cache
+	locks  *shared.ObjectLocks
 }
 
 func (c *crud) read(ctx context.Context, callQueueID types.String) (*readDto, error) {
@@ -26,13 +30,10 @@ func (c *crud) read(ctx context.Context, callQueueID types.String) (*readDto, er
 		CallQueueId: callQueueID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 300 {
-				return nil, nil // already deleted
-			}
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
-		return nil, fmt.Errorf("unable to read phone call queue phone numbers: %v", err)
+		return nil, fmt.Errorf("unable to read phone call queue phone numbers: %w", err)
 	}
 	phoneNumbers := lo.Map(ret.PhoneNumbers, func(p zoomphone.GetACallQueueOKPhoneNumbersItem, _index int) *readDtoPhoneNumber {
 		return &readDtoPhoneNumber{
@@ -47,6 +48,14 @@ func (c *crud) read(ctx context.Context, callQueueID types.String) (*readDto, er
 }
 
 func (c *crud) assign(ctx context.Context, dto *assignDto) error {
+	unlock, err := c.locks.Lock(ctx, "call_queue", dto.callQueueID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidateCallQueues()
+
 	// Only a max of 5 numbers can be assigned to a call queue at a time.
 	for _, phoneNumberIDs := range lo.Chunk(dto.phoneNumberIDs, 5) {
 		err := c.client.AssignPhoneToCallQueue(ctx, zoomphone.NewOptAssignPhoneToCallQueueReq(
@@ -59,7 +68,7 @@ func (c *crud) assign(ctx context.Context, dto *assignDto) error {
 			},
 		), zoomphone.AssignPhoneToCallQueueParams{CallQueueId: dto.callQueueID.ValueString()})
 		if err != nil {
-			return fmt.Errorf("error assigning phone call queue phone numbers by phone number id: %v", err)
+			return fmt.Errorf("error assigning phone call queue phone numbers by phone number id: %w", err)
 		}
 	}
 	for _, phoneNumbers := range lo.Chunk(dto.phoneNumbers, 5) {
@@ -73,43 +82,53 @@ func (c *crud) assign(ctx context.Context, dto *assignDto) error {
 			},
 		), zoomphone.AssignPhoneToCallQueueParams{CallQueueId: dto.callQueueID.ValueString()})
 		if err != nil {
-			return fmt.Errorf("error assigning phone call queue phone numbers by phone number: %v", err)
+			return fmt.Errorf("error assigning phone call queue phone numbers by phone number: %w", err)
 		}
 	}
 	return nil
 }
 
 func (c *crud) unassign(ctx context.Context, dto *unassignDto) error {
+	unlock, err := c.locks.Lock(ctx, "call_queue", dto.callQueueID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidateCallQueues()
+
 	for _, phoneNumberID := range dto.phoneNumberIDs {
 		err := c.client.UnAssignPhoneNumCallQueue(ctx, zoomphone.UnAssignPhoneNumCallQueueParams{
 			CallQueueId:   dto.callQueueID.ValueString(),
 			PhoneNumberId: phoneNumberID.ValueString(),
 		})
 		if err != nil {
-			var status *zoomphone.ErrorResponseStatusCode
-			if errors.As(err, &status) {
-				if status.StatusCode == 404 {
-					continue
-				}
+			if util.IsNotFound(err) {
+				continue
 			}
-			return fmt.Errorf("error unassigning phone call queue phone numbers: %v", err)
+			return fmt.Errorf("error unassigning phone call queue phone numbers: %w", err)
 		}
 	}
 	return nil
 }
 
 func (c *crud) unassignAll(ctx context.Context, callQueueID types.String) error {
-	err := c.client.UnassignAPhoneNumCallQueue(ctx, zoomphone.UnassignAPhoneNumCallQueueParams{
+	unlock, err := c.locks.Lock(ctx, "call_queue", callQueueID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidateCallQueues()
+
+	err = c.client.UnassignAPhoneNumCallQueue(ctx, zoomphone.UnassignAPhoneNumCallQueueParams{
 		CallQueueId: callQueueID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 404 {
-				return nil
-			}
+		if util.IsNotFound(err) {
+			return nil
 		}
-		return fmt.Errorf("error unassigning all phone call queue phone numbers: %v", err)
+		return fmt.Errorf("error unassigning all phone call queue phone numbers: %w", err)
 	}
 	return nil
 }
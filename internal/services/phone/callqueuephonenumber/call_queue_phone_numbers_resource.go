@@ -3,9 +3,10 @@ package callqueuephonenumber
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -19,17 +20,28 @@ import (
 )
 
 var (
-	_ resource.Resource                = &tfResource{}
-	_ resource.ResourceWithConfigure   = &tfResource{}
-	_ resource.ResourceWithImportState = &tfResource{}
+	_ resource.Resource                   = &tfResource{}
+	_ resource.ResourceWithConfigure      = &tfResource{}
+	_ resource.ResourceWithImportState    = &tfResource{}
+	_ resource.ResourceWithValidateConfig = &tfResource{}
 )
 
+// resourceScopes are the OAuth scopes this resource requires.
+var resourceScopes = shared.Scopes{
+	"phone:read:call_queue:admin",
+	"phone:read:list_call_queues:admin",
+	"phone:read:list_numbers:admin",
+	"phone:write:call_queue_number:admin",
+	"phone:delete:call_queue_number:admin",
+}
+
 func NewPhoneCallQueuePhoneNumbersResource() resource.Resource {
 	return &tfResource{}
 }
 
 type tfResource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -44,27 +56,26 @@ func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest,
 		)
 		return
 	}
-	r.crud = newCrud(data.PhoneClient)
+	r.crud = newCrud(data.PhoneClient, data.Cache, data.Locks)
+	r.providerData = data
+}
+
+func (r *tfResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(resourceScopes)...)
 }
 
 func (r *tfResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_phone_call_queue_phone_numbers"
 }
 
-func (r *tfResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tfResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `After [buying phone number(s)](https://support.zoom.us/hc/en-us/articles/360020808292#h_007ec8c2-0914-4265-8351-96ab23efa3ad), you can assign it, allowing callers to directly dial a number to reach a [call queue](https://support.zoom.us/hc/en-us/articles/360021524831-Managing-Call-Queues).
 
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:call_queue:admin`",
-			"`phone:read:list_call_queues:admin`",
-			"`phone:read:list_numbers:admin`",
-			"`phone:write:call_queue_number:admin`",
-			"`phone:delete:call_queue_number:admin`",
-		}, ", ") + ".",
+This resource requires the ` + resourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"call_queue_id": schema.StringAttribute{
 				Required:            true,
@@ -97,6 +108,10 @@ This resource requires the ` + strings.Join([]string{
 					},
 				},
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -104,6 +119,8 @@ This resource requires the ` + strings.Join([]string{
 type resourceModel struct {
 	CallQueueID  types.String                `tfsdk:"call_queue_id"`
 	PhoneNumbers []*resourceModelPhoneNumber `tfsdk:"phone_numbers"`
+	SubAccountID types.String                `tfsdk:"sub_account_id"`
+	Timeouts     timeouts.Value              `tfsdk:"timeouts"`
 }
 
 type resourceModelPhoneNumber struct {
@@ -113,16 +130,28 @@ type resourceModelPhoneNumber struct {
 }
 
 func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue_phone_numbers", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	output, err := r.read(ctx, state)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone call queue phone numbers", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error reading phone call queue phone numbers", err))
 		return
 	}
 
@@ -152,6 +181,8 @@ func (r *tfResource) read(ctx context.Context, plan resourceModel) (*resourceMod
 	return &resourceModel{
 		CallQueueID:  plan.CallQueueID,
 		PhoneNumbers: phoneNumbers,
+		SubAccountID: plan.SubAccountID,
+		Timeouts:     plan.Timeouts,
 	}, nil
 }
 
@@ -216,24 +247,34 @@ func (r *tfResource) sync(ctx context.Context, plan resourceModel) error {
 }
 
 func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue_phone_numbers", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_queue_phone_numbers")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, plan); err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone call queue phone numbers",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error creating phone call queue phone numbers", err))
 		return
 	}
 
 	output, err := r.read(ctx, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating phone call queue phone numbers on reading", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error creating phone call queue phone numbers on reading", err))
 		return
 	}
 
@@ -245,6 +286,9 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 }
 
 func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue_phone_numbers", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -255,18 +299,25 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		)
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_queue_phone_numbers")
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, shared.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, plan); err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone call queue phone numbers",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error creating phone call queue phone numbers", err))
 		return
 	}
 
 	output, err := r.read(ctx, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating phone call queue phone numbers", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error updating phone call queue phone numbers", err))
 		return
 	}
 
@@ -278,30 +329,36 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 }
 
 func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue_phone_numbers", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_queue_phone_numbers")
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	asis, err := r.crud.read(ctx, state.CallQueueID)
 	if err != nil {
-		resp.Diagnostics.AddError("Error deleting phone call queue phone numbers", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error deleting phone call queue phone numbers", err))
 	}
 	if asis == nil {
 		return
 	}
 
 	if err := r.crud.unassignAll(ctx, state.CallQueueID); err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone call queue phone numbers",
-			fmt.Sprintf(
-				"Could not delete phone call queue phone numbers %s, unexpected error: %s",
-				state.CallQueueID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error deleting phone call queue phone numbers", err))
 		return
 	}
 
@@ -311,5 +368,5 @@ func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 }
 
 func (r *tfResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("call_queue_id"), req, resp)
+	shared.ImportStateByKey(ctx, path.Root("call_queue_id"), req, resp, "call queue", r.providerData.Cache.CallQueueImportKey)
 }
@@ -2,23 +2,25 @@ package callqueuepolicy
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/samber/lo"
 )
 
-func newCrud(client *zoomphone.Client) *crud {
+func newCrud(client *zoomphone.Client, locks *shared.ObjectLocks) *crud {
 	return &crud{
 		client: client,
+		locks:  locks,
 	}
 }
 
 type crud struct {
 	client *zoomphone.Client
+	locks  *shared.ObjectLocks
 }
 
 func (c *crud) read(ctx context.Context, callQueueID types.String) (*readDto, error) {
@@ -26,11 +28,8 @@ func (c *crud) read(ctx context.Context, callQueueID types.String) (*readDto, er
 		CallQueueId: callQueueID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 {
-				return nil, nil // already deleted
-			}
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
 		return nil, fmt.Errorf("unable to read phone call queue policy: %w", err)
 	}
@@ -54,6 +53,12 @@ func (c *crud) read(ctx context.Context, callQueueID types.String) (*readDto, er
 }
 
 func (c *crud) add(ctx context.Context, dto *addDto) error {
+	unlock, err := c.locks.Lock(ctx, "call_queue", dto.callQueueID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	var voicemailAccessMembers []zoomphone.AddCQPolicySubSettingReqVoicemailAccessMembersItem
 	if dto.voicemailAccessMembers != nil {
 		voicemailAccessMembers = lo.Map(dto.voicemailAccessMembers, func(item *addDtoVoicemailAccessMember, index int) zoomphone.AddCQPolicySubSettingReqVoicemailAccessMembersItem {
@@ -65,7 +70,7 @@ func (c *crud) add(ctx context.Context, dto *addDto) error {
 			}
 		})
 	}
-	_, err := c.client.AddCQPolicySubSetting(ctx, zoomphone.OptAddCQPolicySubSettingReq{
+	_, err = c.client.AddCQPolicySubSetting(ctx, zoomphone.OptAddCQPolicySubSettingReq{
 		Value: zoomphone.AddCQPolicySubSettingReq{
 			VoicemailAccessMembers: voicemailAccessMembers,
 		},
@@ -75,12 +80,18 @@ func (c *crud) add(ctx context.Context, dto *addDto) error {
 		PolicyType:  dto.policyType.String(),
 	})
 	if err != nil {
-		return fmt.Errorf("error creating phone call queue policy: %v", err)
+		return fmt.Errorf("error creating phone call queue policy: %w", err)
 	}
 	return nil
 }
 
 func (c *crud) update(ctx context.Context, dto *updateDto) error {
+	unlock, err := c.locks.Lock(ctx, "call_queue", dto.callQueueID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	var voicemailAccessMembers []zoomphone.UpdateCQPolicySubSettingReqVoicemailAccessMembersItem
 	if dto.voicemailAccessMembers != nil {
 		voicemailAccessMembers = lo.Map(dto.voicemailAccessMembers, func(item *updateDtoVoicemailAccessMember, index int) zoomphone.UpdateCQPolicySubSettingReqVoicemailAccessMembersItem {
@@ -99,7 +110,7 @@ func (c *crud) update(ctx context.Context, dto *updateDto) error {
 		return nil
 	}
 
-	err := c.client.UpdateCQPolicySubSetting(ctx, zoomphone.OptUpdateCQPolicySubSettingReq{
+	err = c.client.UpdateCQPolicySubSetting(ctx, zoomphone.OptUpdateCQPolicySubSettingReq{
 		Value: zoomphone.UpdateCQPolicySubSettingReq{
 			VoicemailAccessMembers: voicemailAccessMembers,
 		},
@@ -109,12 +120,18 @@ func (c *crud) update(ctx context.Context, dto *updateDto) error {
 		PolicyType:  dto.policyType.String(),
 	})
 	if err != nil {
-		return fmt.Errorf("error updating phone call queue policy: %v", err)
+		return fmt.Errorf("error updating phone call queue policy: %w", err)
 	}
 	return nil
 }
 
 func (c *crud) remove(ctx context.Context, dto *removeDto) error {
+	unlock, err := c.locks.Lock(ctx, "call_queue", dto.callQueueID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// maxItems: 20
 	for _, chunk := range lo.Chunk(dto.sharedIDs, 20) {
 		err := c.client.RemoveCQPolicySubSetting(ctx, zoomphone.RemoveCQPolicySubSettingParams{
@@ -125,13 +142,10 @@ func (c *crud) remove(ctx context.Context, dto *removeDto) error {
 			}),
 		})
 		if err != nil {
-			var status *zoomphone.ErrorResponseStatusCode
-			if errors.As(err, &status) {
-				if status.StatusCode == 400 && status.Response.Code.Value == 404 {
-					return nil
-				}
+			if util.IsNotFound(err) {
+				return nil
 			}
-			return fmt.Errorf("error removing phone call queue policy: %v", err)
+			return fmt.Errorf("error removing phone call queue policy: %w", err)
 		}
 	}
 	return nil
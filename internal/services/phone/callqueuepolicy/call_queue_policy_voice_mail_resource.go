@@ -3,12 +3,13 @@ package callqueuepolicy
 import (
 	"context"
 	"fmt"
-	"strings"
 
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/samber/lo"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -16,17 +17,27 @@ import (
 )
 
 var (
-	_ resource.Resource                = &tfVoiceMailResource{}
-	_ resource.ResourceWithConfigure   = &tfVoiceMailResource{}
-	_ resource.ResourceWithImportState = &tfVoiceMailResource{}
+	_ resource.Resource                   = &tfVoiceMailResource{}
+	_ resource.ResourceWithConfigure      = &tfVoiceMailResource{}
+	_ resource.ResourceWithImportState    = &tfVoiceMailResource{}
+	_ resource.ResourceWithValidateConfig = &tfVoiceMailResource{}
 )
 
+// voiceMailResourceScopes are the OAuth scopes this resource requires.
+var voiceMailResourceScopes = shared.Scopes{
+	"phone:read:call_queue:admin",
+	"phone:write:call_queue_policy:admin",
+	"phone:update:call_queue_policy:admin",
+	"phone:delete:call_queue_policy:admin",
+}
+
 func NewPhoneCallQueuePolicyVoiceMailResource() resource.Resource {
 	return &tfVoiceMailResource{}
 }
 
 type tfVoiceMailResource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (r *tfVoiceMailResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -41,26 +52,26 @@ func (r *tfVoiceMailResource) Configure(_ context.Context, req resource.Configur
 		)
 		return
 	}
-	r.crud = newCrud(data.PhoneClient)
+	r.crud = newCrud(data.PhoneClient, data.Locks)
+	r.providerData = data
+}
+
+func (r *tfVoiceMailResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(voiceMailResourceScopes)...)
 }
 
 func (r *tfVoiceMailResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_phone_call_queue_policy_voice_mail"
 }
 
-func (r *tfVoiceMailResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tfVoiceMailResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `The policy sub-setting for a specific call queue according to the voice_mail.
 
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:call_queue:admin`",
-			"`phone:write:call_queue_policy:admin`",
-			"`phone:update:call_queue_policy:admin`",
-			"`phone:delete:call_queue_policy:admin`",
-		}, ", ") + ".",
+This resource requires the ` + voiceMailResourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"call_queue_id": schema.StringAttribute{
 				Required:            true,
@@ -94,6 +105,10 @@ This resource requires the ` + strings.Join([]string{
 					},
 				},
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -101,6 +116,8 @@ This resource requires the ` + strings.Join([]string{
 type resourceVoiceMailModel struct {
 	CallQueueID   types.String                         `tfsdk:"call_queue_id"`
 	AccessMembers []resourceVoiceMailModelAccessMember `tfsdk:"access_members"`
+	SubAccountID  types.String                         `tfsdk:"sub_account_id"`
+	Timeouts      timeouts.Value                       `tfsdk:"timeouts"`
 }
 
 type resourceVoiceMailModelAccessMember struct {
@@ -112,16 +129,28 @@ type resourceVoiceMailModelAccessMember struct {
 }
 
 func (r *tfVoiceMailResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue_policy_voice_mail", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceVoiceMailModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
-	output, err := r.read(ctx, state.CallQueueID)
+	output, err := r.read(ctx, state.CallQueueID, state.SubAccountID, state.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone call queue policy voice mail", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error reading phone call queue policy voice mail", err))
 		return
 	}
 
@@ -133,10 +162,10 @@ func (r *tfVoiceMailResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 }
 
-func (r *tfVoiceMailResource) read(ctx context.Context, callQueueID types.String) (*resourceVoiceMailModel, error) {
+func (r *tfVoiceMailResource) read(ctx context.Context, callQueueID types.String, subAccountID types.String, timeouts timeouts.Value) (*resourceVoiceMailModel, error) {
 	dto, err := r.crud.read(ctx, callQueueID)
 	if err != nil {
-		return nil, fmt.Errorf("error read: %v", err)
+		return nil, fmt.Errorf("error read: %w", err)
 	}
 	if dto == nil {
 		return nil, nil // already deleted
@@ -153,28 +182,40 @@ func (r *tfVoiceMailResource) read(ctx context.Context, callQueueID types.String
 				SharedId:      item.sharedID,
 			}
 		}),
+		SubAccountID: subAccountID,
+		Timeouts:     timeouts,
 	}, nil
 }
 
 func (r *tfVoiceMailResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue_policy_voice_mail", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceVoiceMailModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_queue_policy_voice_mail")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, plan); err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone call queue policy voice mail",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error creating phone call queue policy voice mail", err))
 		return
 	}
 
-	output, err := r.read(ctx, plan.CallQueueID)
+	output, err := r.read(ctx, plan.CallQueueID, plan.SubAccountID, plan.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating phone call queue voice mail on reading", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error creating phone call queue voice mail on reading", err))
 		return
 	}
 	diags = resp.State.Set(ctx, output)
@@ -185,6 +226,9 @@ func (r *tfVoiceMailResource) Create(ctx context.Context, req resource.CreateReq
 }
 
 func (r *tfVoiceMailResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue_policy_voice_mail", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceVoiceMailModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -195,18 +239,25 @@ func (r *tfVoiceMailResource) Update(ctx context.Context, req resource.UpdateReq
 		)
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_queue_policy_voice_mail")
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, shared.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, plan); err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating phone call queue policy voice mail",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error updating phone call queue policy voice mail", err))
 		return
 	}
 
-	output, err := r.read(ctx, plan.CallQueueID)
+	output, err := r.read(ctx, plan.CallQueueID, plan.SubAccountID, plan.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating phone call queue voice mail", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error updating phone call queue voice mail", err))
 		return
 	}
 	diags = resp.State.Set(ctx, output)
@@ -217,7 +268,7 @@ func (r *tfVoiceMailResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *tfVoiceMailResource) sync(ctx context.Context, plan resourceVoiceMailModel) error {
-	asis, err := r.read(ctx, plan.CallQueueID)
+	asis, err := r.read(ctx, plan.CallQueueID, plan.SubAccountID, plan.Timeouts)
 	if err != nil {
 		return fmt.Errorf(
 			"could not sync phone call queue policy voice mail %s on read, unexpected error: %v",
@@ -302,23 +353,29 @@ func (r *tfVoiceMailResource) sync(ctx context.Context, plan resourceVoiceMailMo
 }
 
 func (r *tfVoiceMailResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_call_queue_policy_voice_mail", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceVoiceMailModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_call_queue_policy_voice_mail")
 
-	asis, err := r.read(ctx, state.CallQueueID)
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	asis, err := r.read(ctx, state.CallQueueID, state.SubAccountID, state.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone call queue policy voice mail on read",
-			fmt.Sprintf(
-				"Could not delete phone call queue policy %s, unexpected error: %s",
-				state.CallQueueID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error deleting phone call queue policy voice mail on read", err))
 		return
 	}
 	if asis == nil || len(asis.AccessMembers) == 0 {
@@ -334,14 +391,7 @@ func (r *tfVoiceMailResource) Delete(ctx context.Context, req resource.DeleteReq
 		policyType:  VoiceMail,
 		sharedIDs:   removeSharedIDs,
 	}); err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone call queue policy voice mail",
-			fmt.Sprintf(
-				"Could not delete phone call queue policy %s, unexpected error: %s",
-				state.CallQueueID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("call_queue_id"), "Error deleting phone call queue policy voice mail", err))
 		return
 	}
 
@@ -351,5 +401,5 @@ func (r *tfVoiceMailResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *tfVoiceMailResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("call_queue_id"), req, resp)
+	shared.ImportStateByKey(ctx, path.Root("call_queue_id"), req, resp, "call queue", r.providerData.Cache.CallQueueImportKey)
 }
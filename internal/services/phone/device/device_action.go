@@ -0,0 +1,43 @@
+package device
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+)
+
+// deviceAction is what the device actions have in common. It is embedded in each of them with the scopes it requires.
+type deviceAction struct {
+	scopes       shared.Scopes
+	crud         *crud
+	providerData *shared.ProviderData
+}
+
+func (a *deviceAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*shared.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	a.crud = newCrud(data.PhoneClient)
+	a.providerData = data
+}
+
+func (a *deviceAction) ValidateConfig(_ context.Context, _ action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	resp.Diagnostics.Append(a.providerData.ValidateScopes(a.scopes)...)
+}
+
+// sendProgress tells Terraform what the action is doing, which it shows while the action is invoked.
+func sendProgress(resp *action.InvokeResponse, format string, args ...any) {
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf(format, args...),
+	})
+}
@@ -0,0 +1,52 @@
+package device
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Levels of a desk phone resync.
+const (
+	syncLevelAccount = 1
+	syncLevelSite    = 2
+)
+
+func newCrud(client *zoomphone.Client) *crud {
+	return &crud{
+		client: client,
+	}
+}
+
+type crud struct {
+	client *zoomphone.Client
+}
+
+func (c *crud) reboot(ctx context.Context, deviceID types.String) error {
+	err := c.client.RebootPhoneDevice(ctx, zoomphone.RebootPhoneDeviceParams{
+		DeviceId: deviceID.ValueString(),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to reboot phone device: %w", err)
+	}
+	return nil
+}
+
+// sync resyncs the provisioning of the desk phones of a site, or of the whole account when siteID is null.
+func (c *crud) sync(ctx context.Context, siteID types.String) error {
+	level := syncLevelAccount
+	if !siteID.IsNull() {
+		level = syncLevelSite
+	}
+	err := c.client.SyncPhoneDevice(ctx, zoomphone.NewOptSyncPhoneDeviceReq(zoomphone.SyncPhoneDeviceReq{
+		Level:  level,
+		SiteID: util.ToPhoneOptString(siteID),
+	}))
+	if err != nil {
+		return fmt.Errorf("unable to sync phone devices: %w", err)
+	}
+	return nil
+}
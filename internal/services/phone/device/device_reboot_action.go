@@ -0,0 +1,104 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+)
+
+var (
+	_ action.Action                   = &tfRebootAction{}
+	_ action.ActionWithConfigure      = &tfRebootAction{}
+	_ action.ActionWithValidateConfig = &tfRebootAction{}
+)
+
+// rebootActionScopes are the OAuth scopes this action requires.
+var rebootActionScopes = shared.Scopes{
+	"phone:write:reboot_device:admin",
+}
+
+func NewPhoneDeviceRebootAction() action.Action {
+	return &tfRebootAction{deviceAction: deviceAction{scopes: rebootActionScopes}}
+}
+
+type tfRebootAction struct {
+	deviceAction
+}
+
+type rebootActionModel struct {
+	DeviceIDs    []types.String `tfsdk:"device_ids"`
+	SubAccountID types.String   `tfsdk:"sub_account_id"`
+}
+
+func (a *tfRebootAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_phone_device_reboot"
+}
+
+func (a *tfRebootAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Reboots desk phones, e.g. after their line keys or provision template have been changed.
+Only online zero-touch or assisted-provisioning devices can be rebooted.
+
+The devices are rebooted one by one, and a device that fails to reboot is reported without stopping the others.
+
+## API Permissions
+
+The following API permissions are required in order to use this action.
+This action requires the ` + rebootActionScopes.Markdown() + ".",
+		Attributes: map[string]schema.Attribute{
+			"device_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the devices to reboot.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"sub_account_id": shared.SubAccountIDActionAttribute(),
+		},
+	}
+}
+
+func (a *tfRebootAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	ctx, span := shared.StartSpan(ctx, "action.zoom_phone_device_reboot", "Invoke")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
+	var config rebootActionModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = shared.WithSubAccountID(ctx, config.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_device_reboot")
+
+	deviceIDs := lo.Map(config.DeviceIDs, func(item types.String, _ int) string {
+		return item.ValueString()
+	})
+	slices.Sort(deviceIDs)
+
+	var rebooted int
+	for i, deviceID := range deviceIDs {
+		sendProgress(resp, "Rebooting phone device %s (%d/%d)", deviceID, i+1, len(deviceIDs))
+		if err := a.crud.reboot(ctx, types.StringValue(deviceID)); err != nil {
+			resp.Diagnostics.Append(util.ErrorDiagnostic(
+				path.Root("device_ids").AtSetValue(types.StringValue(deviceID)),
+				fmt.Sprintf("Error rebooting phone device %s", deviceID),
+				err,
+			))
+			continue
+		}
+		rebooted++
+	}
+	sendProgress(resp, "Requested the reboot of %d of %d phone devices", rebooted, len(deviceIDs))
+}
@@ -0,0 +1,43 @@
+package device_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccPhoneDeviceRebootAction reboots an unknown device, so that it runs against any account without touching its devices.
+func TestAccPhoneDeviceRebootAction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactories(t),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: acceptance.ProviderConfig + `
+resource "terraform_data" "test" {
+  input = "reboot"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.zoom_phone_device_reboot.test]
+    }
+  }
+}
+
+action "zoom_phone_device_reboot" "test" {
+  config {
+    device_ids = ["acctest-unknown-device"]
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Error rebooting phone device acctest-unknown-device`),
+			},
+		},
+	})
+}
@@ -0,0 +1,93 @@
+package device
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ action.Action                   = &tfSyncAction{}
+	_ action.ActionWithConfigure      = &tfSyncAction{}
+	_ action.ActionWithValidateConfig = &tfSyncAction{}
+)
+
+// syncActionScopes are the OAuth scopes this action requires.
+var syncActionScopes = shared.Scopes{
+	"phone:write:sync_device:admin",
+}
+
+func NewPhoneDeviceSyncAction() action.Action {
+	return &tfSyncAction{deviceAction: deviceAction{scopes: syncActionScopes}}
+}
+
+type tfSyncAction struct {
+	deviceAction
+}
+
+type syncActionModel struct {
+	SiteID       types.String `tfsdk:"site_id"`
+	SubAccountID types.String `tfsdk:"sub_account_id"`
+}
+
+func (a *tfSyncAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_phone_device_sync"
+}
+
+func (a *tfSyncAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Resyncs the provisioning of desk phones, so that they pick up changed line keys or provision templates without a reboot.
+Zoom resyncs all online zero-touch or assisted-provisioning devices of the account, or of the given site.
+
+Zoom accepts one resync request every 15 minutes, so the action resyncs a single site. Resync the whole account instead when the devices of several sites have to pick up a change.
+
+## API Permissions
+
+The following API permissions are required in order to use this action.
+This action requires the ` + syncActionScopes.Markdown() + ".",
+		Attributes: map[string]schema.Attribute{
+			"site_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the site whose devices are resynced. The devices of the whole account are resynced when it is not set.",
+				Optional:            true,
+			},
+			"sub_account_id": shared.SubAccountIDActionAttribute(),
+		},
+	}
+}
+
+func (a *tfSyncAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	ctx, span := shared.StartSpan(ctx, "action.zoom_phone_device_sync", "Invoke")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
+	var config syncActionModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = shared.WithSubAccountID(ctx, config.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_device_sync")
+
+	if config.SiteID.IsNull() {
+		sendProgress(resp, "Resyncing the phone devices of the account")
+		if err := a.crud.sync(ctx, config.SiteID); err != nil {
+			resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error resyncing phone devices", err))
+		}
+		return
+	}
+
+	sendProgress(resp, "Resyncing the phone devices of site %s", config.SiteID.ValueString())
+	if err := a.crud.sync(ctx, config.SiteID); err != nil {
+		resp.Diagnostics.Append(util.ErrorDiagnostic(
+			path.Root("site_id"),
+			fmt.Sprintf("Error resyncing the phone devices of site %s", config.SiteID.ValueString()),
+			err,
+		))
+	}
+}
@@ -0,0 +1,43 @@
+package device_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccPhoneDeviceSyncAction resyncs the devices of an unknown site, so that it runs against any account without touching its devices.
+func TestAccPhoneDeviceSyncAction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactories(t),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: acceptance.ProviderConfig + `
+resource "terraform_data" "test" {
+  input = "sync"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.zoom_phone_device_sync.test]
+    }
+  }
+}
+
+action "zoom_phone_device_sync" "test" {
+  config {
+    site_id = "acctest-unknown-site"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Error resyncing the phone devices of site acctest-unknown-site`),
+			},
+		},
+	})
+}
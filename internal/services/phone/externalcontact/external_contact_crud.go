@@ -2,11 +2,12 @@ package externalcontact
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"github.com/samber/lo"
+	"iter"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -21,18 +22,43 @@ type crud struct {
 	client *zoomphone.Client
 }
 
+// list iterates over the external contacts of the account, for the list resource.
+func (c *crud) list(ctx context.Context) iter.Seq2[zoomphone.ListExternalContactsOKExternalContactsItem, error] {
+	return util.Paginate(ctx, 100, func(ctx context.Context, pageSize int, nextPageToken string) ([]zoomphone.ListExternalContactsOKExternalContactsItem, string, error) {
+		res, err := c.client.ListExternalContacts(ctx, zoomphone.ListExternalContactsParams{
+			NextPageToken: util.ToPhoneOptPageToken(nextPageToken),
+			PageSize:      zoomphone.NewOptInt(pageSize), // max 100
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return res.ExternalContacts, res.NextPageToken.Value, nil
+	})
+}
+
+// importKey resolves an external contact by email, extension number or name.
+func (c *crud) importKey(ctx context.Context, key string) ([]shared.ImportCandidate, error) {
+	externalContacts, err := util.CollectAll(c.list(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read phone external contacts: %w", err)
+	}
+	return shared.MatchImportKey(externalContacts, key, func(item zoomphone.ListExternalContactsOKExternalContactsItem) (shared.ImportCandidate, []string) {
+		return shared.ImportCandidate{
+			ID:    item.ExternalContactID.Value,
+			Label: fmt.Sprintf("external contact %s <%s>", item.Name.Value, item.Email.Value),
+		}, []string{item.Email.Value, item.ExtensionNumber.Value, item.Name.Value}
+	}), nil
+}
+
 func (c *crud) read(ctx context.Context, externalContactID types.String) (*readDto, error) {
 	detail, err := c.client.GetAExternalContact(ctx, zoomphone.GetAExternalContactParams{
 		ExternalContactId: externalContactID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 300 {
-				return nil, nil // already deleted
-			}
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
-		return nil, fmt.Errorf("unable to read phone external contact: %v", err)
+		return nil, fmt.Errorf("unable to read phone external contact: %w", err)
 	}
 
 	return &readDto{
@@ -51,30 +77,54 @@ func (c *crud) read(ctx context.Context, externalContactID types.String) (*readD
 }
 
 func (c *crud) create(ctx context.Context, dto *createDto) (*createdDto, error) {
-	res, err := c.client.AddExternalContact(ctx, zoomphone.OptAddExternalContactReq{
-		Value: zoomphone.AddExternalContactReq{
-			Description:     util.ToPhoneOptString(dto.description),
-			Email:           util.ToPhoneOptString(dto.email),
-			ExtensionNumber: util.ToPhoneOptString(dto.extensionNumber),
-			ID:              util.ToPhoneOptString(dto.id),
-			Name:            dto.name.ValueString(),
-			PhoneNumbers: lo.Map(dto.phoneNumbers, func(item types.String, index int) string {
-				return item.ValueString()
-			}),
-			RoutingPath:      util.ToPhoneOptString(dto.routingPath),
-			AutoCallRecorded: util.ToPhoneOptBool(dto.autoCallRecorded),
-		},
-		Set: true,
+	externalContactID, err := shared.ReconcileCreate(ctx, func(ctx context.Context) ([]string, error) {
+		return c.matching(ctx, dto)
+	}, func() (string, error) {
+		res, err := c.client.AddExternalContact(ctx, zoomphone.OptAddExternalContactReq{
+			Value: zoomphone.AddExternalContactReq{
+				Description:     util.ToPhoneOptString(dto.description),
+				Email:           util.ToPhoneOptString(dto.email),
+				ExtensionNumber: util.ToPhoneOptString(dto.extensionNumber),
+				ID:              util.ToPhoneOptString(dto.id),
+				Name:            dto.name.ValueString(),
+				PhoneNumbers: lo.Map(dto.phoneNumbers, func(item types.String, index int) string {
+					return item.ValueString()
+				}),
+				RoutingPath:      util.ToPhoneOptString(dto.routingPath),
+				AutoCallRecorded: util.ToPhoneOptBool(dto.autoCallRecorded),
+			},
+			Set: true,
+		})
+		if err != nil {
+			return "", err
+		}
+		return res.ExternalContactID.Value, nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error creating phone external contact: %v", err)
+		return nil, fmt.Errorf("error creating phone external contact: %w", err)
 	}
 
 	return &createdDto{
-		externalContactID: util.FromOptString(res.ExternalContactID),
+		externalContactID: types.StringValue(externalContactID),
 	}, nil
 }
 
+// matching returns the IDs of the external contacts of the name, email and extension number of a create request.
+func (c *crud) matching(ctx context.Context, dto *createDto) ([]string, error) {
+	var ids []string
+	for item, err := range c.list(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		if item.Name.Value == dto.name.ValueString() &&
+			(dto.email.IsNull() || item.Email.Value == dto.email.ValueString()) &&
+			(dto.extensionNumber.IsNull() || item.ExtensionNumber.Value == dto.extensionNumber.ValueString()) {
+			ids = append(ids, item.ExternalContactID.Value)
+		}
+	}
+	return ids, nil
+}
+
 func (c *crud) update(ctx context.Context, dto *updateDto) error {
 	err := c.client.UpdateExternalContact(ctx, zoomphone.OptUpdateExternalContactReq{
 		Value: zoomphone.UpdateExternalContactReq{
@@ -94,7 +144,7 @@ func (c *crud) update(ctx context.Context, dto *updateDto) error {
 		ExternalContactId: dto.externalContactID.ValueString(),
 	})
 	if err != nil {
-		return fmt.Errorf("error updating phone external contact: %v", err)
+		return fmt.Errorf("error updating phone external contact: %w", err)
 	}
 
 	return nil
@@ -105,13 +155,10 @@ func (c *crud) delete(ctx context.Context, externalContactId types.String) error
 		ExternalContactId: externalContactId.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 300 {
-				return nil
-			}
+		if util.IsNotFound(err) {
+			return nil
 		}
-		return fmt.Errorf("error deleting phone external contact: %v", err)
+		return fmt.Errorf("error deleting phone external contact: %w", err)
 	}
 
 	return nil
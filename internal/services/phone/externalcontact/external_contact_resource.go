@@ -3,14 +3,17 @@ package externalcontact
 import (
 	"context"
 	"fmt"
-	"strings"
 
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -19,17 +22,28 @@ import (
 )
 
 var (
-	_ resource.Resource                = &tfResource{}
-	_ resource.ResourceWithConfigure   = &tfResource{}
-	_ resource.ResourceWithImportState = &tfResource{}
+	_ resource.Resource                   = &tfResource{}
+	_ resource.ResourceWithConfigure      = &tfResource{}
+	_ resource.ResourceWithImportState    = &tfResource{}
+	_ resource.ResourceWithIdentity       = &tfResource{}
+	_ resource.ResourceWithValidateConfig = &tfResource{}
 )
 
+// resourceScopes are the OAuth scopes this resource requires.
+var resourceScopes = shared.Scopes{
+	"phone:read:external_contact:admin",
+	"phone:write:external_contact:admin",
+	"phone:update:external_contact:admin",
+	"phone:delete:external_contact:admin",
+}
+
 func NewPhoneExternalContactResource() resource.Resource {
 	return &tfResource{}
 }
 
 type tfResource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -45,29 +59,30 @@ func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest,
 		return
 	}
 	r.crud = newCrud(data.PhoneClient)
+	r.providerData = data
+}
+
+func (r *tfResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(resourceScopes)...)
 }
 
 func (r *tfResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_phone_external_contact"
 }
 
-func (r *tfResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tfResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `External contact's information.
 
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:external_contact:admin`",
-			"`phone:write:external_contact:admin`",
-			"`phone:update:external_contact:admin`",
-			"`phone:delete:external_contact:admin`",
-		}, ", ") + ".",
+This resource requires the ` + resourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 				MarkdownDescription: "The customer-configured external contact ID. It is recommended that you use a primary key from the original phone system. If you do not use this parameter, the API automatically generates an `external_contact_id`.",
 			},
 			"name": schema.StringAttribute{
@@ -108,13 +123,19 @@ This resource requires the ` + strings.Join([]string{
 			},
 			"external_contact_id": schema.StringAttribute{
 				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 				MarkdownDescription: "The Zoom-generated external contact ID.",
 			},
 			"routing_path": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 				MarkdownDescription: "The external contact's SIP group, to define the call routing path. This is for customers that use SIP trunking.",
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -129,19 +150,39 @@ type resourceModel struct {
 	AutoCallRecorded  types.Bool     `tfsdk:"auto_call_recorded"`
 	ExternalContactID types.String   `tfsdk:"external_contact_id"`
 	RoutingPath       types.String   `tfsdk:"routing_path"`
+	SubAccountID      types.String   `tfsdk:"sub_account_id"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *tfResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = shared.IdentitySchema("external_contact_id")
 }
 
 func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_external_contact", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	// The identity is set even when the object has been deleted outside of Terraform, as it does not change.
+	resp.Diagnostics.Append(shared.SetIdentity(ctx, resp.Identity, "external_contact_id", state.ExternalContactID, state.SubAccountID)...)
 
-	output, err := r.read(ctx, state.ExternalContactID)
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	output, err := r.read(ctx, state.ExternalContactID, state.SubAccountID, state.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone external contact", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("external_contact_id"), "Error reading phone external contact", err))
 		return
 	}
 
@@ -152,10 +193,10 @@ func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 	}
 }
 
-func (r *tfResource) read(ctx context.Context, externalContactID types.String) (*resourceModel, error) {
+func (r *tfResource) read(ctx context.Context, externalContactID types.String, subAccountID types.String, timeouts timeouts.Value) (*resourceModel, error) {
 	dto, err := r.crud.read(ctx, externalContactID)
 	if err != nil {
-		return nil, fmt.Errorf("error read: %v", err)
+		return nil, fmt.Errorf("error read: %w", err)
 	}
 	if dto == nil {
 		return nil, nil // already deleted
@@ -171,16 +212,31 @@ func (r *tfResource) read(ctx context.Context, externalContactID types.String) (
 		AutoCallRecorded:  dto.autoCallRecorded,
 		ExternalContactID: dto.externalContactID,
 		RoutingPath:       dto.routingPath,
+		SubAccountID:      subAccountID,
+		Timeouts:          timeouts,
 	}, nil
 }
 
 func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_external_contact", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_external_contact")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	ret, err := r.crud.create(ctx, &createDto{
 		description:       plan.Description,
@@ -194,16 +250,13 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 		autoCallRecorded:  plan.AutoCallRecorded,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone external contact",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone external contact", err))
 		return
 	}
 
-	output, err := r.read(ctx, ret.externalContactID)
+	output, err := r.read(ctx, ret.externalContactID, plan.SubAccountID, plan.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating phone external contact on reading", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone external contact on reading", err))
 		return
 	}
 
@@ -212,9 +265,13 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(shared.SetIdentityFromState(ctx, resp.State, resp.Identity, "external_contact_id")...)
 }
 
 func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_external_contact", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -225,6 +282,16 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		)
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_external_contact")
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, shared.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	if err := r.crud.update(ctx, &updateDto{
 		externalContactID: plan.ExternalContactID,
@@ -237,20 +304,13 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		routingPath:       plan.RoutingPath,
 		autoCallRecorded:  plan.AutoCallRecorded,
 	}); err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating phone external contact",
-			fmt.Sprintf(
-				"Could not update phone external contact %s, unexpected error: %s",
-				plan.ID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("external_contact_id"), "Error updating phone external contact", err))
 		return
 	}
 
-	output, err := r.read(ctx, plan.ExternalContactID)
+	output, err := r.read(ctx, plan.ExternalContactID, plan.SubAccountID, plan.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating phone external contact", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("external_contact_id"), "Error updating phone external contact", err))
 		return
 	}
 
@@ -259,25 +319,32 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(shared.SetIdentityFromState(ctx, resp.State, resp.Identity, "external_contact_id")...)
 }
 
 func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_external_contact", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_external_contact")
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	if err := r.crud.delete(ctx, state.ExternalContactID); err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone external contact",
-			fmt.Sprintf(
-				"Could not delete phone external contact %s, unexpected error: %s",
-				state.ID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("external_contact_id"), "Error deleting phone external contact", err))
 		return
 	}
 
@@ -287,5 +354,5 @@ func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 }
 
 func (r *tfResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("external_contact_id"), req, resp)
+	shared.ImportStateByKey(ctx, path.Root("external_contact_id"), req, resp, "external contact", r.crud.importKey)
 }
@@ -0,0 +1,84 @@
+package externalcontact_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccPhoneExternalContact(t *testing.T) {
+	td := acceptance.NewTestData(t, "zoom_phone_external_contact", "test")
+	name := fmt.Sprintf("acctest-%s", td.RandomStringOfLength(5))
+	email := fmt.Sprintf("%s@example.com", name)
+	updatedName := fmt.Sprintf("acctest-%s", td.RandomStringOfLength(5))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactories(t),
+		CheckDestroy: acceptance.CheckDestroy(td.TerraformResourceType, func(ctx context.Context, rs *terraform.ResourceState) error {
+			_, err := acceptance.ProviderData(t).PhoneClient.GetAExternalContact(ctx, zoomphone.GetAExternalContactParams{
+				ExternalContactId: rs.Primary.Attributes["external_contact_id"],
+			})
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: acceptance.ProviderConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  name          = %[3]q
+  email         = %[4]q
+  phone_numbers = ["+12025550144"]
+}
+`, td.TerraformResourceType, td.ResourceLabel, name, email),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "name", name),
+					resource.TestCheckResourceAttr(td.ResourceName, "email", email),
+					resource.TestCheckResourceAttr(td.ResourceName, "phone_numbers.#", "1"),
+					resource.TestCheckTypeSetElemAttr(td.ResourceName, "phone_numbers.*", "+12025550144"),
+					resource.TestCheckResourceAttr(td.ResourceName, "auto_call_recorded", "false"),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "external_contact_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         td.ResourceName,
+				ImportState:                          true,
+				ImportStateIdFunc:                    acceptance.ImportStateIDFromAttribute(td.ResourceName, "external_contact_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "external_contact_id",
+			},
+			// ImportState testing by email
+			{
+				ResourceName:                         td.ResourceName,
+				ImportState:                          true,
+				ImportStateId:                        email,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "external_contact_id",
+			},
+			// Update and Read testing
+			{
+				Config: acceptance.ProviderConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  name               = %[3]q
+  email              = %[4]q
+  description        = "updated by acctest"
+  phone_numbers      = ["+12025550144", "+12025550145"]
+  auto_call_recorded = true
+}
+`, td.TerraformResourceType, td.ResourceLabel, updatedName, email),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "name", updatedName),
+					resource.TestCheckResourceAttr(td.ResourceName, "description", "updated by acctest"),
+					resource.TestCheckResourceAttr(td.ResourceName, "phone_numbers.#", "2"),
+					resource.TestCheckTypeSetElemAttr(td.ResourceName, "phone_numbers.*", "+12025550145"),
+					resource.TestCheckResourceAttr(td.ResourceName, "auto_call_recorded", "true"),
+				),
+			},
+		},
+	})
+}
@@ -21,12 +21,10 @@ type crud struct {
 }
 
 func (c *crud) read(ctx context.Context, dto *readQueryDto) (*readDto, error) {
-	var phoneNumbers []*readDtoPhoneNumber
-	nextPageToken := zoomphone.OptString{}
-	for {
+	items, err := util.CollectAll(util.Paginate(ctx, 300, func(ctx context.Context, pageSize int, nextPageToken string) ([]zoomphone.ListAccountPhoneNumbersOKPhoneNumbersItem, string, error) {
 		ret, err := c.client.ListAccountPhoneNumbers(ctx, zoomphone.ListAccountPhoneNumbersParams{
-			NextPageToken:  nextPageToken,
-			PageSize:       zoomphone.NewOptInt(300), // max 300
+			NextPageToken:  util.ToPhoneOptPageToken(nextPageToken),
+			PageSize:       zoomphone.NewOptInt(pageSize), // max 300
 			Type:           util.ToPhoneOptString(dto.typ),
 			ExtensionType:  util.ToPhoneOptString(dto.extensionType),
 			NumberType:     util.ToPhoneOptString(dto.numberType),
@@ -34,9 +32,16 @@ func (c *crud) read(ctx context.Context, dto *readQueryDto) (*readDto, error) {
 			SiteID:         util.ToPhoneOptString(dto.siteID),
 		})
 		if err != nil {
-			return nil, fmt.Errorf("unable to read phone numbers: %v", err)
+			return nil, "", err
 		}
-		phoneNumbers = append(phoneNumbers, lo.Map(ret.PhoneNumbers, func(item zoomphone.ListAccountPhoneNumbersOKPhoneNumbersItem, _index int) *readDtoPhoneNumber {
+		return ret.PhoneNumbers, ret.NextPageToken.Value, nil
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read phone numbers: %w", err)
+	}
+
+	return &readDto{
+		phoneNumbers: lo.Map(items, func(item zoomphone.ListAccountPhoneNumbersOKPhoneNumbersItem, _index int) *readDtoPhoneNumber {
 			capability := lo.Map(item.Capability, func(item string, index int) types.String {
 				return types.StringValue(item)
 			})
@@ -98,14 +103,6 @@ func (c *crud) read(ctx context.Context, dto *readQueryDto) (*readDto, error) {
 				source:                     util.FromOptString(item.Source),
 				status:                     util.FromOptString(item.Status),
 			}
-		})...)
-		if ret.NextPageToken.Value == "" {
-			break
-		}
-		nextPageToken = ret.NextPageToken
-	}
-
-	return &readDto{
-		phoneNumbers: phoneNumbers,
+		}),
 	}, nil
 }
@@ -3,12 +3,13 @@ package phonenumbers
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -16,16 +17,23 @@ import (
 )
 
 var (
-	_ datasource.DataSource              = &tfDataSource{}
-	_ datasource.DataSourceWithConfigure = &tfDataSource{}
+	_ datasource.DataSource                   = &tfDataSource{}
+	_ datasource.DataSourceWithConfigure      = &tfDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &tfDataSource{}
 )
 
+// dataSourceScopes are the OAuth scopes this data source requires.
+var dataSourceScopes = shared.Scopes{
+	"phone:read:list_numbers:admin",
+}
+
 func NewPhonePhoneNumbersDataSource() datasource.DataSource {
 	return &tfDataSource{}
 }
 
 type tfDataSource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (d *tfDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -41,6 +49,11 @@ func (d *tfDataSource) Configure(_ context.Context, req datasource.ConfigureRequ
 		return
 	}
 	d.crud = newCrud(data.PhoneClient)
+	d.providerData = data
+}
+
+func (d *tfDataSource) ValidateConfig(_ context.Context, _ datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(d.providerData.ValidateScopes(dataSourceScopes)...)
 }
 
 func (d *tfDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -54,9 +67,7 @@ func (d *tfDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, res
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:list_numbers:admin`",
-		}, ", "),
+This resource requires the ` + dataSourceScopes.Markdown(),
 		Attributes: map[string]schema.Attribute{
 			"filter": schema.SingleNestedAttribute{
 				Optional: true,
@@ -246,6 +257,7 @@ This field indicates to whom the phone number belongs.
 					},
 				},
 			},
+			"sub_account_id": shared.SubAccountIDDataSourceAttribute(),
 		},
 	}
 }
@@ -253,6 +265,7 @@ This field indicates to whom the phone number belongs.
 type dataSourceModel struct {
 	Filter       *dataSourceModelFilter        `tfsdk:"filter"`
 	PhoneNumbers []*dataSourceModelPhoneNumber `tfsdk:"phone_numbers"`
+	SubAccountID types.String                  `tfsdk:"sub_account_id"`
 }
 
 type dataSourceModelPhoneNumber struct {
@@ -313,11 +326,15 @@ type dataSourceModelSite struct {
 }
 
 func (d *tfDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "data.zoom_phone_phone_numbers", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var data dataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, data.SubAccountID)
 
 	typ := types.StringNull()
 	extensionType := types.StringNull()
@@ -339,7 +356,7 @@ func (d *tfDataSource) Read(ctx context.Context, req datasource.ReadRequest, res
 		siteID:         siteID,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone numbers", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error reading phone numbers", err))
 		return
 	}
 
@@ -417,6 +434,7 @@ func (d *tfDataSource) Read(ctx context.Context, req datasource.ReadRequest, res
 				Status:                     item.status,
 			}
 		}),
+		SubAccountID: data.SubAccountID,
 	}
 
 	diags := resp.State.Set(ctx, &output)
@@ -2,22 +2,42 @@ package sharedlinegroup
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"iter"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
 )
 
-func newCrud(client *zoomphone.Client) *crud {
+func newCrud(client *zoomphone.Client, cache *shared.Cache, locks *shared.ObjectLocks) *crud {
 	return &crud{
 		client: client,
+		cache:  cache,
+		locks:  locks,
 	}
 }
 
 type crud struct {
 	client *zoomphone.Client
+	cache  *shared.Cache
+	locks  *shared.ObjectLocks
+}
+
+// list iterates over the shared line groups of the account, for the list resource.
+func (c *crud) list(ctx context.Context) iter.Seq2[zoomphone.ListSharedLineGroupsOKSharedLineGroupsItem, error] {
+	return util.Paginate(ctx, 100, func(ctx context.Context, pageSize int, nextPageToken string) ([]zoomphone.ListSharedLineGroupsOKSharedLineGroupsItem, string, error) {
+		res, err := c.client.ListSharedLineGroups(ctx, zoomphone.ListSharedLineGroupsParams{
+			NextPageToken: util.ToPhoneOptPageToken(nextPageToken),
+			PageSize:      zoomphone.NewOptInt(pageSize), // max 100
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return res.SharedLineGroups, res.NextPageToken.Value, nil
+	})
 }
 
 func (c *crud) read(ctx context.Context, sharedLineGroupID types.String) (*readDto, error) {
@@ -25,13 +45,10 @@ func (c *crud) read(ctx context.Context, sharedLineGroupID types.String) (*readD
 		SharedLineGroupId: sharedLineGroupID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 300 {
-				return nil, nil // already deleted
-			}
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
-		return nil, fmt.Errorf("unable to read phone shared line group: %v", err)
+		return nil, fmt.Errorf("unable to read phone shared line group: %w", err)
 	}
 
 	var site *readDtoSite
@@ -53,26 +70,57 @@ func (c *crud) read(ctx context.Context, sharedLineGroupID types.String) (*readD
 }
 
 func (c *crud) create(ctx context.Context, dto *createDto) (*createdDto, error) {
-	res, err := c.client.CreateASharedLineGroup(ctx, zoomphone.OptCreateASharedLineGroupReq{
-		Value: zoomphone.CreateASharedLineGroupReq{
-			DisplayName:     dto.displayName.ValueString(),
-			Description:     util.ToPhoneOptString(dto.description),
-			ExtensionNumber: util.ToPhoneOptInt64(dto.extensionNumber),
-			SiteID:          util.ToPhoneOptString(dto.siteID),
-		},
-		Set: true,
+	sharedLineGroupID, err := shared.ReconcileCreate(ctx, func(ctx context.Context) ([]string, error) {
+		return c.matching(ctx, dto)
+	}, func() (string, error) {
+		defer c.cache.InvalidateSharedLineGroups()
+
+		res, err := c.client.CreateASharedLineGroup(ctx, zoomphone.OptCreateASharedLineGroupReq{
+			Value: zoomphone.CreateASharedLineGroupReq{
+				DisplayName:     dto.displayName.ValueString(),
+				Description:     util.ToPhoneOptString(dto.description),
+				ExtensionNumber: util.ToPhoneOptInt64(dto.extensionNumber),
+				SiteID:          util.ToPhoneOptString(dto.siteID),
+			},
+			Set: true,
+		})
+		if err != nil {
+			return "", err
+		}
+		return res.ID.Value, nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error creating phone shared line group: %v", err)
+		return nil, fmt.Errorf("error creating phone shared line group: %w", err)
 	}
 
 	return &createdDto{
-		sharedLineGroupID: util.FromOptString(res.ID),
+		sharedLineGroupID: types.StringValue(sharedLineGroupID),
 	}, nil
 }
 
+// matching returns the IDs of the shared line groups of the display name, site and extension number of a create request.
+func (c *crud) matching(ctx context.Context, dto *createDto) ([]string, error) {
+	sharedLineGroups, err := c.cache.SharedLineGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return lo.FilterMap(sharedLineGroups, func(item zoomphone.ListSharedLineGroupsOKSharedLineGroupsItem, _ int) (string, bool) {
+		return item.ID.Value, item.DisplayName.Value == dto.displayName.ValueString() &&
+			(dto.siteID.IsNull() || item.Site.Value.ID.Value == dto.siteID.ValueString()) &&
+			(dto.extensionNumber.IsNull() || item.ExtensionNumber.Value == dto.extensionNumber.ValueInt64())
+	}), nil
+}
+
 func (c *crud) update(ctx context.Context, dto *updateDto) error {
-	err := c.client.UpdateASharedLineGroup(ctx, zoomphone.OptUpdateASharedLineGroupReq{
+	unlock, err := c.locks.Lock(ctx, "shared_line_group", dto.sharedLineGroupID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidateSharedLineGroups()
+
+	err = c.client.UpdateASharedLineGroup(ctx, zoomphone.OptUpdateASharedLineGroupReq{
 		Value: zoomphone.UpdateASharedLineGroupReq{
 			ExtensionNumber: util.ToPhoneOptInt64(dto.extensionNumber),
 			DisplayName:     util.ToPhoneOptString(dto.displayName),
@@ -85,24 +133,29 @@ func (c *crud) update(ctx context.Context, dto *updateDto) error {
 		SharedLineGroupId: dto.sharedLineGroupID.ValueString(),
 	})
 	if err != nil {
-		return fmt.Errorf("error updating phone shared line group: %v", err)
+		return fmt.Errorf("error updating phone shared line group: %w", err)
 	}
 
 	return nil
 }
 
 func (c *crud) delete(ctx context.Context, sharedLineGroupId types.String) error {
-	err := c.client.DeleteASharedLineGroup(ctx, zoomphone.DeleteASharedLineGroupParams{
+	unlock, err := c.locks.Lock(ctx, "shared_line_group", sharedLineGroupId.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidateSharedLineGroups()
+
+	err = c.client.DeleteASharedLineGroup(ctx, zoomphone.DeleteASharedLineGroupParams{
 		SharedLineGroupId: sharedLineGroupId.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 404 {
-				return nil
-			}
+		if util.IsNotFound(err) {
+			return nil
 		}
-		return fmt.Errorf("error deleting phone shared line group: %v", err)
+		return fmt.Errorf("error deleting phone shared line group: %w", err)
 	}
 
 	return nil
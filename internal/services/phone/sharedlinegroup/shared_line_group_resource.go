@@ -3,9 +3,10 @@ package sharedlinegroup
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -20,17 +21,28 @@ import (
 )
 
 var (
-	_ resource.Resource                = &tfResource{}
-	_ resource.ResourceWithConfigure   = &tfResource{}
-	_ resource.ResourceWithImportState = &tfResource{}
+	_ resource.Resource                   = &tfResource{}
+	_ resource.ResourceWithConfigure      = &tfResource{}
+	_ resource.ResourceWithImportState    = &tfResource{}
+	_ resource.ResourceWithIdentity       = &tfResource{}
+	_ resource.ResourceWithValidateConfig = &tfResource{}
 )
 
+// resourceScopes are the OAuth scopes this resource requires.
+var resourceScopes = shared.Scopes{
+	"phone:read:shared_line_group:admin",
+	"phone:write:shared_line_group:admin",
+	"phone:update:shared_line_group:admin",
+	"phone:delete:shared_line_group:admin",
+}
+
 func NewPhoneSharedLineGroupResource() resource.Resource {
 	return &tfResource{}
 }
 
 type tfResource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -45,26 +57,26 @@ func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest,
 		)
 		return
 	}
-	r.crud = newCrud(data.PhoneClient)
+	r.crud = newCrud(data.PhoneClient, data.Cache, data.Locks)
+	r.providerData = data
+}
+
+func (r *tfResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(resourceScopes)...)
 }
 
 func (r *tfResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_phone_shared_line_group"
 }
 
-func (r *tfResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tfResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `A [shared line group](https://support.zoom.us/hc/en-us/articles/360038850792) allows Zoom Phone admins to share a phone number and extension with a group of phone users or common areas. This gives members of the shared line group access to the group's direct phone number and voicemail.
 
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:shared_line_group:admin`",
-			"`phone:write:shared_line_group:admin`",
-			"`phone:update:shared_line_group:admin`",
-			"`phone:delete:shared_line_group:admin`",
-		}, ", ") + ".",
+This resource requires the ` + resourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -112,31 +124,55 @@ The primary number shares the same line as the extension number. This means if a
 				MarkdownDescription: `The status of the shared line group.
   - Allowed: active┃inactive`,
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
 
 type resourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	DisplayName     types.String `tfsdk:"display_name"`
-	ExtensionID     types.String `tfsdk:"extension_id"`
-	ExtensionNumber types.Int64  `tfsdk:"extension_number"`
-	PrimaryNumber   types.String `tfsdk:"primary_number"`
-	SiteID          types.String `tfsdk:"site_id"`
-	Status          types.String `tfsdk:"status"`
+	ID              types.String   `tfsdk:"id"`
+	DisplayName     types.String   `tfsdk:"display_name"`
+	ExtensionID     types.String   `tfsdk:"extension_id"`
+	ExtensionNumber types.Int64    `tfsdk:"extension_number"`
+	PrimaryNumber   types.String   `tfsdk:"primary_number"`
+	SiteID          types.String   `tfsdk:"site_id"`
+	Status          types.String   `tfsdk:"status"`
+	SubAccountID    types.String   `tfsdk:"sub_account_id"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *tfResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = shared.IdentitySchema("id")
 }
 
 func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_shared_line_group", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	// The identity is set even when the object has been deleted outside of Terraform, as it does not change.
+	resp.Diagnostics.Append(shared.SetIdentity(ctx, resp.Identity, "id", state.ID, state.SubAccountID)...)
+
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
-	output, err := r.read(ctx, state.ID)
+	output, err := r.read(ctx, state.ID, state.SubAccountID, state.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone shared line group", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error reading phone shared line group", err))
 		return
 	}
 
@@ -147,10 +183,10 @@ func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 	}
 }
 
-func (r *tfResource) read(ctx context.Context, sharedLineGroupId types.String) (*resourceModel, error) {
+func (r *tfResource) read(ctx context.Context, sharedLineGroupId types.String, subAccountID types.String, timeouts timeouts.Value) (*resourceModel, error) {
 	dto, err := r.crud.read(ctx, sharedLineGroupId)
 	if err != nil {
-		return nil, fmt.Errorf("error read: %v", err)
+		return nil, fmt.Errorf("error read: %w", err)
 	}
 	if dto == nil {
 		return nil, nil // already deleted
@@ -168,26 +204,38 @@ func (r *tfResource) read(ctx context.Context, sharedLineGroupId types.String) (
 		PrimaryNumber:   dto.primaryNumber,
 		Status:          dto.status,
 		SiteID:          siteID,
+		SubAccountID:    subAccountID,
+		Timeouts:        timeouts,
 	}, nil
 }
 
 func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_shared_line_group", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_shared_line_group")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	ret, err := r.crud.create(ctx, &createDto{
 		displayName:     plan.DisplayName,
 		extensionNumber: plan.ExtensionNumber,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone shared line group",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone shared line group", err))
 		return
 	}
 
@@ -200,16 +248,13 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 	}); err != nil {
 		// TODO change delete logic with marking resource as taint
 		_ = r.crud.delete(ctx, ret.sharedLineGroupID)
-		resp.Diagnostics.AddError(
-			"Error creating phone shared line group on updating",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone shared line group on updating", err))
 		return
 	}
 
-	output, err := r.read(ctx, ret.sharedLineGroupID)
+	output, err := r.read(ctx, ret.sharedLineGroupID, plan.SubAccountID, plan.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating phone shared line group on reading", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone shared line group on reading", err))
 		return
 	}
 
@@ -218,9 +263,13 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(shared.SetIdentityFromState(ctx, resp.State, resp.Identity, "id")...)
 }
 
 func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_shared_line_group", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -231,6 +280,16 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		)
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_shared_line_group")
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, shared.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	if err := r.crud.update(ctx, &updateDto{
 		sharedLineGroupID: plan.ID,
@@ -238,20 +297,13 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		displayName:       plan.DisplayName,
 		status:            plan.Status,
 	}); err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating phone shared line group",
-			fmt.Sprintf(
-				"Could not update phone shared line group %s, unexpected error: %s",
-				plan.ID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error updating phone shared line group", err))
 		return
 	}
 
-	output, err := r.read(ctx, plan.ID)
+	output, err := r.read(ctx, plan.ID, plan.SubAccountID, plan.Timeouts)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating phone shared line group", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error updating phone shared line group", err))
 		return
 	}
 
@@ -260,25 +312,32 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(shared.SetIdentityFromState(ctx, resp.State, resp.Identity, "id")...)
 }
 
 func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_shared_line_group", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_shared_line_group")
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	if err := r.crud.delete(ctx, state.ID); err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone shared line group",
-			fmt.Sprintf(
-				"Could not delete phone shared line group %s, unexpected error: %s",
-				state.ID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error deleting phone shared line group", err))
 		return
 	}
 
@@ -288,5 +347,5 @@ func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 }
 
 func (r *tfResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	shared.ImportStateByKey(ctx, path.Root("id"), req, resp, "shared line group", r.providerData.Cache.SharedLineGroupImportKey)
 }
@@ -0,0 +1,78 @@
+package sharedlinegroup_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccPhoneSharedLineGroup(t *testing.T) {
+	td := acceptance.NewTestData(t, "zoom_phone_shared_line_group", "test")
+	siteID := acceptance.SiteID(t)
+	displayName := fmt.Sprintf("acctest-%s", td.RandomStringOfLength(5))
+	updatedDisplayName := fmt.Sprintf("acctest-%s", td.RandomStringOfLength(5))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactories(t),
+		CheckDestroy: acceptance.CheckDestroy(td.TerraformResourceType, func(ctx context.Context, rs *terraform.ResourceState) error {
+			_, err := acceptance.ProviderData(t).PhoneClient.GetASharedLineGroup(ctx, zoomphone.GetASharedLineGroupParams{
+				SharedLineGroupId: rs.Primary.ID,
+			})
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: acceptance.ProviderConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  display_name     = %[3]q
+  extension_number = 81031
+  site_id          = %[4]q
+}
+`, td.TerraformResourceType, td.ResourceLabel, displayName, siteID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "display_name", displayName),
+					resource.TestCheckResourceAttr(td.ResourceName, "extension_number", "81031"),
+					resource.TestCheckResourceAttr(td.ResourceName, "site_id", siteID),
+					resource.TestCheckResourceAttr(td.ResourceName, "status", "active"),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "id"),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "extension_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      td.ResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState testing by extension number
+			{
+				ResourceName:      td.ResourceName,
+				ImportState:       true,
+				ImportStateIdFunc: acceptance.ImportStateIDFromAttribute(td.ResourceName, "extension_number"),
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: acceptance.ProviderConfig + fmt.Sprintf(`
+resource %[1]q %[2]q {
+  display_name     = %[3]q
+  extension_number = 81032
+  site_id          = %[4]q
+  status           = "inactive"
+}
+`, td.TerraformResourceType, td.ResourceLabel, updatedDisplayName, siteID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "display_name", updatedDisplayName),
+					resource.TestCheckResourceAttr(td.ResourceName, "extension_number", "81032"),
+					resource.TestCheckResourceAttr(td.ResourceName, "status", "inactive"),
+				),
+			},
+		},
+	})
+}
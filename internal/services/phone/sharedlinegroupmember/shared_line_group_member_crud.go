@@ -2,23 +2,27 @@ package sharedlinegroupmember
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/samber/lo"
 )
 
-func newCrud(client *zoomphone.Client) *crud {
+func newCrud(client *zoomphone.Client, cache *shared.Cache, locks *shared.ObjectLocks) *crud {
 	return &crud{
 		client: client,
+		cache:  cache,
+		locks:  locks,
 	}
 }
 
 type crud struct {
 	client *zoomphone.Client
+	cache  *shared.Cache
+	locks  *shared.ObjectLocks
 }
 
 func (c *crud) read(ctx context.Context, sharedLineGroupID types.String) (*readDto, error) {
@@ -28,13 +32,10 @@ func (c *crud) read(ctx context.Context, sharedLineGroupID types.String) (*readD
 		SharedLineGroupId: sharedLineGroupID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 300 {
-				return nil, nil // already deleted
-			}
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
-		return nil, fmt.Errorf("unable to read phone shared line group members: %v", err)
+		return nil, fmt.Errorf("unable to read phone shared line group members: %w", err)
 	}
 	if ret.Members.Set {
 		commonAreas = lo.Map(ret.Members.Value.CommonAreas, func(member zoomphone.GetASharedLineGroupOKMembersCommonAreasItem, _index int) *readDtoCommonArea {
@@ -75,30 +76,22 @@ func (c *crud) readUsersByExtensionIDs(ctx context.Context, extensionIDs []types
 	})
 }
 
+// readUsersByCond resolves members through the phone users cached for the run,
+// instead of paging through all phone users on every read of every resource.
 func (c *crud) readUsersByCond(ctx context.Context, cond func(u zoomphone.ListPhoneUsersOKUsersItem) bool) (*readUsersDto, error) {
+	phoneUsers, err := c.cache.PhoneUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
 	var users []*readUsersDtoUser
-	nextPageToken := zoomphone.OptString{}
-	for {
-		res, err := c.client.ListPhoneUsers(ctx, zoomphone.ListPhoneUsersParams{
-			NextPageToken: nextPageToken,
-			PageSize:      zoomphone.NewOptInt(100), // Max 100
-		})
-		if err != nil {
-			return nil, fmt.Errorf("error listing phone users: %v", err)
+	for _, user := range phoneUsers {
+		isSearchedUser := cond(user)
+		if isSearchedUser {
+			users = append(users, &readUsersDtoUser{
+				email:       util.FromOptString(user.Email),
+				extensionID: util.FromOptString(user.ExtensionID),
+			})
 		}
-		for _, user := range res.Users {
-			isSearchedUser := cond(user)
-			if isSearchedUser {
-				users = append(users, &readUsersDtoUser{
-					email:       util.FromOptString(user.Email),
-					extensionID: util.FromOptString(user.ExtensionID),
-				})
-			}
-		}
-		if res.NextPageToken.Value == "" {
-			break
-		}
-		nextPageToken = res.NextPageToken
 	}
 	return &readUsersDto{
 		users: users,
@@ -106,6 +99,12 @@ func (c *crud) readUsersByCond(ctx context.Context, cond func(u zoomphone.ListPh
 }
 
 func (c *crud) assign(ctx context.Context, dto *assignDto) error {
+	unlock, err := c.locks.Lock(ctx, "shared_line_group", dto.sharedLineGroupID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	commonAreaIDs := make([]string, len(dto.commonAreaIDs))
 	for i, id := range dto.commonAreaIDs {
 		commonAreaIDs[i] = id.ValueString()
@@ -131,7 +130,7 @@ func (c *crud) assign(ctx context.Context, dto *assignDto) error {
 			SharedLineGroupId: dto.sharedLineGroupID.ValueString(),
 		})
 		if err != nil {
-			return fmt.Errorf("error assigning phone shared line group members: %v", err)
+			return fmt.Errorf("error assigning phone shared line group members: %w", err)
 		}
 	}
 	for _, userChunked := range lo.Chunk(users, 10) {
@@ -146,7 +145,7 @@ func (c *crud) assign(ctx context.Context, dto *assignDto) error {
 			SharedLineGroupId: dto.sharedLineGroupID.ValueString(),
 		})
 		if err != nil {
-			return fmt.Errorf("error assigning phone shared line group members: %v", err)
+			return fmt.Errorf("error assigning phone shared line group members: %w", err)
 		}
 	}
 
@@ -154,19 +153,22 @@ func (c *crud) assign(ctx context.Context, dto *assignDto) error {
 }
 
 func (c *crud) unassign(ctx context.Context, dto *unassignDto) error {
+	unlock, err := c.locks.Lock(ctx, "shared_line_group", dto.sharedLineGroupID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	for _, memberID := range dto.memberIDs {
 		err := c.client.DeleteAMemberSLG(ctx, zoomphone.DeleteAMemberSLGParams{
 			SharedLineGroupId: dto.sharedLineGroupID.ValueString(),
 			MemberId:          memberID.ValueString(),
 		})
 		if err != nil {
-			var status *zoomphone.ErrorResponseStatusCode
-			if errors.As(err, &status) {
-				if status.StatusCode == 404 {
-					continue
-				}
+			if util.IsNotFound(err) {
+				continue
 			}
-			return fmt.Errorf("error unassigning phone shared line group members: %v", err)
+			return fmt.Errorf("error unassigning phone shared line group members: %w", err)
 		}
 	}
 
@@ -174,17 +176,20 @@ func (c *crud) unassign(ctx context.Context, dto *unassignDto) error {
 }
 
 func (c *crud) unassignAll(ctx context.Context, sharedLineGroupID types.String) error {
-	err := c.client.DeleteMembersOfSLG(ctx, zoomphone.DeleteMembersOfSLGParams{
+	unlock, err := c.locks.Lock(ctx, "shared_line_group", sharedLineGroupID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = c.client.DeleteMembersOfSLG(ctx, zoomphone.DeleteMembersOfSLGParams{
 		SharedLineGroupId: sharedLineGroupID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 404 {
-				return nil
-			}
+		if util.IsNotFound(err) {
+			return nil
 		}
-		return fmt.Errorf("error unassigning all phone shared line group members: %v", err)
+		return fmt.Errorf("error unassigning all phone shared line group members: %w", err)
 	}
 	return nil
 }
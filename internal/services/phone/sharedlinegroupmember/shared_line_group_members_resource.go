@@ -3,9 +3,10 @@ package sharedlinegroupmember
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -17,17 +18,27 @@ import (
 )
 
 var (
-	_ resource.Resource                = &tfResource{}
-	_ resource.ResourceWithConfigure   = &tfResource{}
-	_ resource.ResourceWithImportState = &tfResource{}
+	_ resource.Resource                   = &tfResource{}
+	_ resource.ResourceWithConfigure      = &tfResource{}
+	_ resource.ResourceWithImportState    = &tfResource{}
+	_ resource.ResourceWithValidateConfig = &tfResource{}
 )
 
+// resourceScopes are the OAuth scopes this resource requires.
+var resourceScopes = shared.Scopes{
+	"phone:read:list_users:admin",
+	"phone:read:list_shared_line_group_members:admin",
+	"phone:write:shared_line_group_member:admin",
+	"phone:delete:shared_line_group_member:admin",
+}
+
 func NewPhoneSharedLineGroupMembersResource() resource.Resource {
 	return &tfResource{}
 }
 
 type tfResource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -42,26 +53,26 @@ func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest,
 		)
 		return
 	}
-	r.crud = newCrud(data.PhoneClient)
+	r.crud = newCrud(data.PhoneClient, data.Cache, data.Locks)
+	r.providerData = data
+}
+
+func (r *tfResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(resourceScopes)...)
 }
 
 func (r *tfResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_phone_shared_line_group_members"
 }
 
-func (r *tfResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tfResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `A [shared line group](https://support.zoom.us/hc/en-us/articles/360038850792) allows Zoom Phone admins to share a phone number and extension with a group of phone users or common areas. This gives members of the shared line group access to the group's direct phone number and voicemail. Note that a member can only be added to one shared line group.
 
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:list_users:admin`",
-			"`phone:read:list_shared_line_group_members:admin`",
-			"`phone:write:shared_line_group_member:admin`",
-			"`phone:delete:shared_line_group_member:admin`",
-		}, ", ") + ".",
+This resource requires the ` + resourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"shared_line_group_id": schema.StringAttribute{
 				Required:            true,
@@ -114,6 +125,10 @@ This resource requires the ` + strings.Join([]string{
 					},
 				},
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -122,6 +137,8 @@ type resourceModel struct {
 	SharedLineGroupID types.String               `tfsdk:"shared_line_group_id"`
 	CommonAreas       []*resourceModelCommonArea `tfsdk:"common_areas"`
 	Users             []*resourceModelUser       `tfsdk:"users"`
+	SubAccountID      types.String               `tfsdk:"sub_account_id"`
+	Timeouts          timeouts.Value             `tfsdk:"timeouts"`
 }
 
 type resourceModelCommonArea struct {
@@ -138,16 +155,28 @@ type resourceModelUser struct {
 }
 
 func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_shared_line_group_members", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	output, err := r.read(ctx, state)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone shared line group members", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("shared_line_group_id"), "Error reading phone shared line group members", err))
 		return
 	}
 
@@ -203,6 +232,8 @@ func (r *tfResource) read(ctx context.Context, plan resourceModel) (*resourceMod
 		SharedLineGroupID: plan.SharedLineGroupID,
 		CommonAreas:       commonAreas,
 		Users:             users,
+		SubAccountID:      plan.SubAccountID,
+		Timeouts:          plan.Timeouts,
 	}, nil
 }
 
@@ -282,24 +313,34 @@ func (r *tfResource) sync(ctx context.Context, plan resourceModel) error {
 }
 
 func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_shared_line_group_members", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_shared_line_group_members")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, plan); err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone shared line group members",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("shared_line_group_id"), "Error creating phone shared line group members", err))
 		return
 	}
 
 	output, err := r.read(ctx, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating phone shared line group members on reading", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("shared_line_group_id"), "Error creating phone shared line group members on reading", err))
 		return
 	}
 
@@ -311,6 +352,9 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 }
 
 func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_shared_line_group_members", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -321,18 +365,25 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		)
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_shared_line_group_members")
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, shared.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, plan); err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone shared line group members",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("shared_line_group_id"), "Error creating phone shared line group members", err))
 		return
 	}
 
 	output, err := r.read(ctx, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating phone shared line group members", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("shared_line_group_id"), "Error updating phone shared line group members", err))
 		return
 	}
 
@@ -344,22 +395,28 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 }
 
 func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_shared_line_group_members", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_shared_line_group_members")
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	if err := r.crud.unassignAll(ctx, state.SharedLineGroupID); err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone shared line group members",
-			fmt.Sprintf(
-				"Could not delete phone shared line group members %s, unexpected error: %s",
-				state.SharedLineGroupID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("shared_line_group_id"), "Error deleting phone shared line group members", err))
 		return
 	}
 
@@ -369,5 +426,5 @@ func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 }
 
 func (r *tfResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("shared_line_group_id"), req, resp)
+	shared.ImportStateByKey(ctx, path.Root("shared_line_group_id"), req, resp, "shared line group", r.providerData.Cache.SharedLineGroupImportKey)
 }
@@ -2,23 +2,25 @@ package sharedlinegroupphonenumber
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/samber/lo"
 )
 
-func newCrud(client *zoomphone.Client) *crud {
+func newCrud(client *zoomphone.Client, locks *shared.ObjectLocks) *crud {
 	return &crud{
 		client: client,
+		locks:  locks,
 	}
 }
 
 type crud struct {
 	client *zoomphone.Client
+	locks  *shared.ObjectLocks
 }
 
 func (c *crud) read(ctx context.Context, sharedLineGroupID types.String) (*readDto, error) {
@@ -26,13 +28,10 @@ func (c *crud) read(ctx context.Context, sharedLineGroupID types.String) (*readD
 		SharedLineGroupId: sharedLineGroupID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 300 {
-				return nil, nil // already deleted
-			}
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
-		return nil, fmt.Errorf("unable to read phone shared line group phone numbers: %v", err)
+		return nil, fmt.Errorf("unable to read phone shared line group phone numbers: %w", err)
 	}
 	phoneNumbers := lo.Map(ret.PhoneNumbers, func(p zoomphone.GetASharedLineGroupOKPhoneNumbersItem, _index int) *readDtoPhoneNumber {
 		return &readDtoPhoneNumber{
@@ -47,6 +46,12 @@ func (c *crud) read(ctx context.Context, sharedLineGroupID types.String) (*readD
 }
 
 func (c *crud) assign(ctx context.Context, dto *assignDto) error {
+	unlock, err := c.locks.Lock(ctx, "shared_line_group", dto.sharedLineGroupID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Only a max of 5 numbers can be assigned to a shared line group at a time.
 	for _, phoneNumberIDs := range lo.Chunk(dto.phoneNumberIDs, 5) {
 		err := c.client.AssignPhoneNumbersSLG(ctx, zoomphone.NewOptAssignPhoneNumbersSLGReq(
@@ -59,7 +64,7 @@ func (c *crud) assign(ctx context.Context, dto *assignDto) error {
 			},
 		), zoomphone.AssignPhoneNumbersSLGParams{SharedLineGroupId: dto.sharedLineGroupID.ValueString()})
 		if err != nil {
-			return fmt.Errorf("error assigning phone shared line group phone numbers by phone number id: %v", err)
+			return fmt.Errorf("error assigning phone shared line group phone numbers by phone number id: %w", err)
 		}
 	}
 	for _, phoneNumbers := range lo.Chunk(dto.phoneNumbers, 5) {
@@ -73,50 +78,62 @@ func (c *crud) assign(ctx context.Context, dto *assignDto) error {
 			},
 		), zoomphone.AssignPhoneNumbersSLGParams{SharedLineGroupId: dto.sharedLineGroupID.ValueString()})
 		if err != nil {
-			return fmt.Errorf("error assigning phone shared line group phone numbers by phone number: %v", err)
+			return fmt.Errorf("error assigning phone shared line group phone numbers by phone number: %w", err)
 		}
 	}
 	return nil
 }
 
 func (c *crud) unassign(ctx context.Context, dto *unassignDto) error {
+	unlock, err := c.locks.Lock(ctx, "shared_line_group", dto.sharedLineGroupID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	for _, phoneNumberID := range dto.phoneNumberIDs {
 		err := c.client.DeleteAPhoneNumberSLG(ctx, zoomphone.DeleteAPhoneNumberSLGParams{
 			SharedLineGroupId: dto.sharedLineGroupID.ValueString(),
 			PhoneNumberId:     phoneNumberID.ValueString(),
 		})
 		if err != nil {
-			var status *zoomphone.ErrorResponseStatusCode
-			if errors.As(err, &status) {
-				if status.StatusCode == 404 {
-					continue
-				}
+			if util.IsNotFound(err) {
+				continue
 			}
-			return fmt.Errorf("error unassigning phone shared line group phone numbers: %v", err)
+			return fmt.Errorf("error unassigning phone shared line group phone numbers: %w", err)
 		}
 	}
 	return nil
 }
 
 func (c *crud) unassignAll(ctx context.Context, sharedLineGroupID types.String) error {
-	err := c.client.DeletePhoneNumbersSLG(ctx, zoomphone.DeletePhoneNumbersSLGParams{
+	unlock, err := c.locks.Lock(ctx, "shared_line_group", sharedLineGroupID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = c.client.DeletePhoneNumbersSLG(ctx, zoomphone.DeletePhoneNumbersSLGParams{
 		SharedLineGroupId: sharedLineGroupID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 404 {
-				return nil
-			}
+		if util.IsNotFound(err) {
+			return nil
 		}
-		return fmt.Errorf("error unassigning all phone shared line group phone numbers: %v", err)
+		return fmt.Errorf("error unassigning all phone shared line group phone numbers: %w", err)
 	}
 	return nil
 }
 
 func (c *crud) updatePrimaryNumber(ctx context.Context, sharedLineGroupID, primaryNumber types.String) error {
+	unlock, err := c.locks.Lock(ctx, "shared_line_group", sharedLineGroupID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// UpdateASharedLineGroup is PATCH, so just update PrimaryNumber only
-	err := c.client.UpdateASharedLineGroup(ctx, zoomphone.OptUpdateASharedLineGroupReq{
+	err = c.client.UpdateASharedLineGroup(ctx, zoomphone.OptUpdateASharedLineGroupReq{
 		Value: zoomphone.UpdateASharedLineGroupReq{
 			PrimaryNumber: util.ToPhoneOptString(primaryNumber),
 		},
@@ -125,7 +142,7 @@ func (c *crud) updatePrimaryNumber(ctx context.Context, sharedLineGroupID, prima
 		SharedLineGroupId: sharedLineGroupID.ValueString(),
 	})
 	if err != nil {
-		return fmt.Errorf("error updating phone shared line group primary number: %v", err)
+		return fmt.Errorf("error updating phone shared line group primary number: %w", err)
 	}
 	return nil
 }
@@ -3,9 +3,10 @@ package sharedlinegroupphonenumber
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -17,17 +18,27 @@ import (
 )
 
 var (
-	_ resource.Resource                = &tfResource{}
-	_ resource.ResourceWithConfigure   = &tfResource{}
-	_ resource.ResourceWithImportState = &tfResource{}
+	_ resource.Resource                   = &tfResource{}
+	_ resource.ResourceWithConfigure      = &tfResource{}
+	_ resource.ResourceWithImportState    = &tfResource{}
+	_ resource.ResourceWithValidateConfig = &tfResource{}
 )
 
+// resourceScopes are the OAuth scopes this resource requires.
+var resourceScopes = shared.Scopes{
+	"phone:read:shared_line_group:admin",
+	"phone:write:shared_line_group:admin",
+	"phone:write:shared_line_group_number:admin",
+	"phone:delete:shared_line_group_number:admin",
+}
+
 func NewPhoneSharedLineGroupPhoneNumbersResource() resource.Resource {
 	return &tfResource{}
 }
 
 type tfResource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -42,26 +53,26 @@ func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest,
 		)
 		return
 	}
-	r.crud = newCrud(data.PhoneClient)
+	r.crud = newCrud(data.PhoneClient, data.Locks)
+	r.providerData = data
+}
+
+func (r *tfResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(resourceScopes)...)
 }
 
 func (r *tfResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_phone_shared_line_group_phone_numbers"
 }
 
-func (r *tfResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tfResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Assigns phone numbers to a shared line groups. These direct phone numbers will be shared among members of the [shared line group](https://support.zoom.us/hc/en-us/articles/360038850792-Setting-up-shared-line-groups).
 
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:shared_line_group:admin`",
-			"`phone:write:shared_line_group:admin`",
-			"`phone:write:shared_line_group_number:admin`",
-			"`phone:delete:shared_line_group_number:admin`",
-		}, ", ") + ".",
+This resource requires the ` + resourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"shared_line_group_id": schema.StringAttribute{
 				Required:            true,
@@ -90,6 +101,10 @@ The primary number shares the same line as the extension number. This means if a
 					},
 				},
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -98,6 +113,8 @@ type resourceModel struct {
 	SharedLineGroupID types.String                `tfsdk:"shared_line_group_id"`
 	PrimaryNumber     types.String                `tfsdk:"primary_number"`
 	PhoneNumbers      []*resourceModelPhoneNumber `tfsdk:"phone_numbers"`
+	SubAccountID      types.String                `tfsdk:"sub_account_id"`
+	Timeouts          timeouts.Value              `tfsdk:"timeouts"`
 }
 
 type resourceModelPhoneNumber struct {
@@ -106,16 +123,28 @@ type resourceModelPhoneNumber struct {
 }
 
 func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_shared_line_group_phone_numbers", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	output, err := r.read(ctx, state)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone shared line group phone numbers", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("shared_line_group_id"), "Error reading phone shared line group phone numbers", err))
 		return
 	}
 
@@ -145,6 +174,8 @@ func (r *tfResource) read(ctx context.Context, plan resourceModel) (*resourceMod
 		SharedLineGroupID: plan.SharedLineGroupID,
 		PrimaryNumber:     dto.primaryNumber,
 		PhoneNumbers:      phoneNumbers,
+		SubAccountID:      plan.SubAccountID,
+		Timeouts:          plan.Timeouts,
 	}, nil
 }
 
@@ -220,24 +251,34 @@ func (r *tfResource) sync(ctx context.Context, plan resourceModel) error {
 }
 
 func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_shared_line_group_phone_numbers", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_shared_line_group_phone_numbers")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, plan); err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone shared line group phone numbers",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("shared_line_group_id"), "Error creating phone shared line group phone numbers", err))
 		return
 	}
 
 	output, err := r.read(ctx, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating phone shared line group phone numbers on reading", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("shared_line_group_id"), "Error creating phone shared line group phone numbers on reading", err))
 		return
 	}
 
@@ -249,6 +290,9 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 }
 
 func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_shared_line_group_phone_numbers", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -259,18 +303,25 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		)
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_shared_line_group_phone_numbers")
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, shared.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	if err := r.sync(ctx, plan); err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone shared line group phone numbers",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("shared_line_group_id"), "Error creating phone shared line group phone numbers", err))
 		return
 	}
 
 	output, err := r.read(ctx, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating phone shared line group phone numbers", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("shared_line_group_id"), "Error updating phone shared line group phone numbers", err))
 		return
 	}
 
@@ -282,22 +333,28 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 }
 
 func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_shared_line_group_phone_numbers", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_shared_line_group_phone_numbers")
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	if err := r.crud.unassignAll(ctx, state.SharedLineGroupID); err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone shared line group phone numbers",
-			fmt.Sprintf(
-				"Could not delete phone shared line group phone numbers %s, unexpected error: %s",
-				state.SharedLineGroupID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("shared_line_group_id"), "Error deleting phone shared line group phone numbers", err))
 		return
 	}
 
@@ -307,5 +364,5 @@ func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 }
 
 func (r *tfResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("shared_line_group_id"), req, resp)
+	shared.ImportStateByKey(ctx, path.Root("shared_line_group_id"), req, resp, "shared line group", r.providerData.Cache.SharedLineGroupImportKey)
 }
@@ -2,23 +2,42 @@ package site
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"iter"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/samber/lo"
 )
 
-func newCrud(client *zoomphone.Client) *crud {
+func newCrud(client *zoomphone.Client, cache *shared.Cache, locks *shared.ObjectLocks) *crud {
 	return &crud{
 		client: client,
+		cache:  cache,
+		locks:  locks,
 	}
 }
 
 type crud struct {
 	client *zoomphone.Client
+	cache  *shared.Cache
+	locks  *shared.ObjectLocks
+}
+
+// list iterates over the phone sites of the account, for the list resource.
+func (c *crud) list(ctx context.Context) iter.Seq2[zoomphone.ListPhoneSitesOKSitesItem, error] {
+	return util.Paginate(ctx, 300, func(ctx context.Context, pageSize int, nextPageToken string) ([]zoomphone.ListPhoneSitesOKSitesItem, string, error) {
+		res, err := c.client.ListPhoneSites(ctx, zoomphone.ListPhoneSitesParams{
+			NextPageToken: util.ToPhoneOptPageToken(nextPageToken),
+			PageSize:      zoomphone.NewOptInt(pageSize), // max 300
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return res.Sites, res.NextPageToken.Value, nil
+	})
 }
 
 func (c *crud) read(ctx context.Context, siteID types.String) (*readDto, error) {
@@ -26,13 +45,10 @@ func (c *crud) read(ctx context.Context, siteID types.String) (*readDto, error)
 		SiteId: siteID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 300 {
-				return nil, nil // already deleted
-			}
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
-		return nil, fmt.Errorf("unable to read phone site: %v", err)
+		return nil, fmt.Errorf("unable to read phone site: %w", err)
 	}
 
 	return &readDto{
@@ -64,48 +80,85 @@ func (c *crud) read(ctx context.Context, siteID types.String) (*readDto, error)
 }
 
 func (c *crud) create(ctx context.Context, dto *createDto) (*createdDto, error) {
-	res, err := c.client.CreatePhoneSite(ctx, zoomphone.NewOptCreatePhoneSiteReq(
-		zoomphone.CreatePhoneSiteReq{
-			AutoReceptionistName:     dto.autoReceptionistName.ValueString(),
-			SourceAutoReceptionistID: util.ToPhoneOptString(dto.sourceAutoReceptionistID),
-			DefaultEmergencyAddress: zoomphone.CreatePhoneSiteReqDefaultEmergencyAddress{
-				AddressLine1: dto.defaultEmergencyAddress.addressLine1.ValueString(),
-				AddressLine2: util.ToPhoneOptString(dto.defaultEmergencyAddress.addressLine2),
-				City:         dto.defaultEmergencyAddress.city.ValueString(),
-				StateCode:    dto.defaultEmergencyAddress.stateCode.ValueString(),
-				Country:      dto.defaultEmergencyAddress.countryCode.ValueString(),
-				Zip:          dto.defaultEmergencyAddress.zip.ValueString(),
-			},
-			Name: dto.name.ValueString(),
-			ShortExtension: zoomphone.NewOptCreatePhoneSiteReqShortExtension(
-				zoomphone.CreatePhoneSiteReqShortExtension{
-					Length: util.ToPhoneOptInt(dto.shortExtensionLength),
-				},
-			),
-			SiteCode: util.ToPhoneOptInt(dto.siteCode),
-			SipZone: zoomphone.NewOptCreatePhoneSiteReqSipZone(
-				zoomphone.CreatePhoneSiteReqSipZone{
-					ID: util.ToPhoneOptString(dto.sipZoneID),
+	// site names are unique in an account, so a new site of the name was created by the request
+	siteID, err := shared.ReconcileCreate(ctx, func(ctx context.Context) ([]string, error) {
+		return c.matching(ctx, dto.name)
+	}, func() (string, error) {
+		defer c.cache.InvalidateSites()
+		// Zoom creates the main auto receptionist of the site.
+		defer c.cache.InvalidateAutoReceptionists()
+
+		res, err := c.client.CreatePhoneSite(ctx, zoomphone.NewOptCreatePhoneSiteReq(
+			zoomphone.CreatePhoneSiteReq{
+				AutoReceptionistName:     dto.autoReceptionistName.ValueString(),
+				SourceAutoReceptionistID: util.ToPhoneOptString(dto.sourceAutoReceptionistID),
+				DefaultEmergencyAddress: zoomphone.CreatePhoneSiteReqDefaultEmergencyAddress{
+					AddressLine1: dto.defaultEmergencyAddress.addressLine1.ValueString(),
+					AddressLine2: util.ToPhoneOptString(dto.defaultEmergencyAddress.addressLine2),
+					City:         dto.defaultEmergencyAddress.city.ValueString(),
+					StateCode:    dto.defaultEmergencyAddress.stateCode.ValueString(),
+					Country:      dto.defaultEmergencyAddress.countryCode.ValueString(),
+					Zip:          dto.defaultEmergencyAddress.zip.ValueString(),
 				},
-			),
-			IndiaStateCode:  util.ToPhoneOptString(dto.indiaStateCode),
-			IndiaCity:       util.ToPhoneOptString(dto.indiaCity),
-			IndiaSdcaNpa:    util.ToPhoneOptString(dto.indiaSdcaNpa),
-			IndiaEntityName: util.ToPhoneOptString(dto.indiaEntityName),
-		},
-	))
+				Name: dto.name.ValueString(),
+				ShortExtension: zoomphone.NewOptCreatePhoneSiteReqShortExtension(
+					zoomphone.CreatePhoneSiteReqShortExtension{
+						Length: util.ToPhoneOptInt(dto.shortExtensionLength),
+					},
+				),
+				SiteCode: util.ToPhoneOptInt(dto.siteCode),
+				SipZone: zoomphone.NewOptCreatePhoneSiteReqSipZone(
+					zoomphone.CreatePhoneSiteReqSipZone{
+						ID: util.ToPhoneOptString(dto.sipZoneID),
+					},
+				),
+				IndiaStateCode:  util.ToPhoneOptString(dto.indiaStateCode),
+				IndiaCity:       util.ToPhoneOptString(dto.indiaCity),
+				IndiaSdcaNpa:    util.ToPhoneOptString(dto.indiaSdcaNpa),
+				IndiaEntityName: util.ToPhoneOptString(dto.indiaEntityName),
+			},
+		))
+		if err != nil {
+			return "", err
+		}
+		return res.ID.Value, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error creating phone site: %v", err)
+		return nil, fmt.Errorf("error creating phone site: %w", err)
 	}
 
 	return &createdDto{
-		id:   util.FromOptString(res.ID),
-		name: util.FromOptString(res.Name),
+		id:   types.StringValue(siteID),
+		name: dto.name,
 	}, nil
 }
 
+// matching returns the IDs of the sites of the name.
+func (c *crud) matching(ctx context.Context, name types.String) ([]string, error) {
+	sites, err := c.cache.Sites(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return lo.FilterMap(sites, func(item zoomphone.ListPhoneSitesOKSitesItem, _ int) (string, bool) {
+		return item.ID.Value, item.Name.Value == name.ValueString()
+	}), nil
+}
+
 func (c *crud) update(ctx context.Context, dto *updateDto) error {
-	err := c.client.UpdateSiteDetails(ctx,
+	unlock, err := c.locks.Lock(ctx, "site", dto.id.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidateSites()
+	// The other lists embed the name of the site.
+	defer c.cache.InvalidatePhoneUsers()
+	defer c.cache.InvalidateCallQueues()
+	defer c.cache.InvalidateSharedLineGroups()
+	defer c.cache.InvalidateAutoReceptionists()
+
+	err = c.client.UpdateSiteDetails(ctx,
 		zoomphone.NewOptUpdateSiteDetailsReq(zoomphone.UpdateSiteDetailsReq{
 			Name:     util.ToPhoneOptString(dto.name),
 			SiteCode: util.ToPhoneOptInt(dto.siteCode),
@@ -131,51 +184,51 @@ func (c *crud) update(ctx context.Context, dto *updateDto) error {
 			SiteId: dto.id.ValueString(),
 		})
 	if err != nil {
-		return fmt.Errorf("error updating phone site: %v", err)
+		return fmt.Errorf("error updating phone site: %w", err)
 	}
 
 	return nil
 }
 
 func (c *crud) delete(ctx context.Context, siteID types.String, transferSiteID types.String) error {
-	err := c.client.DeletePhoneSite(ctx, zoomphone.DeletePhoneSiteParams{
+	unlock, err := c.locks.Lock(ctx, "site", siteID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidateSites()
+	// Phone users, call queues and shared line groups of the site are moved to the transfer site,
+	// and its main auto receptionist is deleted.
+	defer c.cache.InvalidatePhoneUsers()
+	defer c.cache.InvalidateCallQueues()
+	defer c.cache.InvalidateSharedLineGroups()
+	defer c.cache.InvalidateAutoReceptionists()
+
+	err = c.client.DeletePhoneSite(ctx, zoomphone.DeletePhoneSiteParams{
 		SiteId:         siteID.ValueString(),
 		TransferSiteID: transferSiteID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 400 && status.Response.Code.Value == 404 {
-				return nil
-			}
+		if util.IsNotFound(err) {
+			return nil
 		}
-		return fmt.Errorf("error deleting phone site: %v", err)
+		return fmt.Errorf("error deleting phone site: %w", err)
 	}
 
 	return nil
 }
 
 func (c *crud) readMain(ctx context.Context) (*readDto, error) {
-	nextPageToken := zoomphone.OptString{}
-	for {
-		ret, err := c.client.ListPhoneSites(ctx, zoomphone.ListPhoneSitesParams{
-			NextPageToken: nextPageToken,
-			PageSize:      zoomphone.NewOptInt(300), // max 300
-		})
-		if err != nil {
-			return nil, fmt.Errorf("unable to read sites: %v", err)
-		}
-
-		for _, site := range ret.Sites {
-			if site.Level.IsSet() && site.Level.Value == "main" {
-				return c.read(ctx, util.FromOptString(site.ID))
-			}
-		}
+	sites, err := c.cache.Sites(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-		if ret.NextPageToken.Value == "" {
-			break
+	for _, site := range sites {
+		if site.Level.IsSet() && site.Level.Value == "main" {
+			return c.read(ctx, util.FromOptString(site.ID))
 		}
-		nextPageToken = ret.NextPageToken
 	}
 
 	return nil, fmt.Errorf("main site not found")
@@ -3,27 +3,35 @@ package site
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/samber/lo"
 )
 
 var (
-	_ datasource.DataSource              = &tfDataSource{}
-	_ datasource.DataSourceWithConfigure = &tfDataSource{}
+	_ datasource.DataSource                   = &tfDataSource{}
+	_ datasource.DataSourceWithConfigure      = &tfDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &tfDataSource{}
 )
 
+// dataSourceScopes are the OAuth scopes this data source requires.
+var dataSourceScopes = shared.Scopes{
+	"phone:read:site:admin",
+}
+
 func NewPhoneSiteDataSource() datasource.DataSource {
 	return &tfDataSource{}
 }
 
 type tfDataSource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (d *tfDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -38,7 +46,12 @@ func (d *tfDataSource) Configure(_ context.Context, req datasource.ConfigureRequ
 		)
 		return
 	}
-	d.crud = newCrud(data.PhoneClient)
+	d.crud = newCrud(data.PhoneClient, data.Cache, data.Locks)
+	d.providerData = data
+}
+
+func (d *tfDataSource) ValidateConfig(_ context.Context, _ datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(d.providerData.ValidateScopes(dataSourceScopes)...)
 }
 
 func (d *tfDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -52,9 +65,7 @@ func (d *tfDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, res
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:site:admin`",
-		}, ", ") + ".",
+This resource requires the ` + dataSourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Required:            true,
@@ -144,6 +155,7 @@ This resource requires the ` + strings.Join([]string{
 				Computed:            true,
 				MarkdownDescription: "When select the Indian sip zone, then need to set the entity name. This field only applies to India based accounts.",
 			},
+			"sub_account_id": shared.SubAccountIDDataSourceAttribute(),
 		},
 	}
 }
@@ -162,6 +174,7 @@ type dataSourceModel struct {
 	IndiaCity            types.String                         `tfsdk:"india_city"`
 	IndiaSdcaNpa         types.String                         `tfsdk:"india_sdca_npa"`
 	IndiaEntityName      types.String                         `tfsdk:"india_entity_name"`
+	SubAccountID         types.String                         `tfsdk:"sub_account_id"`
 }
 
 type dataSourceModelCountry struct {
@@ -184,15 +197,19 @@ type dataSourceModelSipZone struct {
 }
 
 func (d *tfDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "data.zoom_phone_site", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var data dataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, data.SubAccountID)
 
 	dto, err := d.crud.read(ctx, data.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone site", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error reading phone site", err))
 		return
 	}
 	if dto == nil {
@@ -232,6 +249,7 @@ func (d *tfDataSource) Read(ctx context.Context, req datasource.ReadRequest, res
 		IndiaCity:       lo.Ternary(dto.indiaCity.ValueString() == "", types.StringNull(), dto.indiaCity),
 		IndiaSdcaNpa:    lo.Ternary(dto.indiaSdcaNpa.ValueString() == "", types.StringNull(), dto.indiaSdcaNpa),
 		IndiaEntityName: lo.Ternary(dto.indiaEntityName.ValueString() == "", types.StringNull(), dto.indiaEntityName),
+		SubAccountID:    data.SubAccountID,
 	}
 	diags := resp.State.Set(ctx, &output)
 	resp.Diagnostics.Append(diags...)
@@ -23,8 +23,8 @@ data "zoom_phone_site" "test" {
 	expectedDataSourceName := "data.zoom_phone_site.test"
 
 	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: acceptance.TestAccProviderFactories,
-		CheckDestroy:             testAccPhoneSiteResourceDestroy(td),
+		ProtoV6ProviderFactories: acceptance.ProviderFactories(t),
+		CheckDestroy:             testAccPhoneSiteResourceDestroy(t, td),
 		Steps: []resource.TestStep{
 			// Read testing
 			{
@@ -0,0 +1,55 @@
+package site_test
+
+import (
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck/queryfilter"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccListPhoneSite(t *testing.T) {
+	td := acceptance.NewTestData(t, "zoom_phone_site", "test")
+
+	siteTestResource := newSiteResource(td)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactories(t),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		CheckDestroy: testAccPhoneSiteResourceDestroy(t, td),
+		Steps: []resource.TestStep{
+			{
+				Config: acceptance.ProviderConfig + siteTestResource.requiredConfig(),
+			},
+			// Query testing
+			{
+				Query: true,
+				Config: acceptance.ProviderConfig + `
+list "zoom_phone_site" "test" {
+  provider         = zoom
+  include_resource = true
+}
+`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLengthAtLeast("zoom_phone_site.test", 1),
+					querycheck.ExpectResourceDisplayName("zoom_phone_site.test",
+						queryfilter.ByDisplayName(knownvalue.StringExact(siteTestResource.Name)),
+						knownvalue.StringExact(siteTestResource.Name),
+					),
+					querycheck.ExpectResourceKnownValues("zoom_phone_site.test",
+						queryfilter.ByDisplayName(knownvalue.StringExact(siteTestResource.Name)),
+						[]querycheck.KnownValueCheck{
+							{Path: tfjsonpath.New("main_auto_receptionist").AtMapKey("name"), KnownValue: knownvalue.StringExact(siteTestResource.MainAutoReceptionistName)},
+						},
+					),
+				},
+			},
+		},
+	})
+}
@@ -4,9 +4,10 @@ import (
 	"context"
 	"fmt"
 	"regexp"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -24,17 +25,29 @@ import (
 )
 
 var (
-	_ resource.Resource                = &tfResource{}
-	_ resource.ResourceWithConfigure   = &tfResource{}
-	_ resource.ResourceWithImportState = &tfResource{}
+	_ resource.Resource                   = &tfResource{}
+	_ resource.ResourceWithConfigure      = &tfResource{}
+	_ resource.ResourceWithImportState    = &tfResource{}
+	_ resource.ResourceWithIdentity       = &tfResource{}
+	_ resource.ResourceWithValidateConfig = &tfResource{}
 )
 
+// resourceScopes are the OAuth scopes this resource requires.
+var resourceScopes = shared.Scopes{
+	"phone:read:site:admin",
+	"phone:write:site:admin",
+	"phone:update:site:admin",
+	"phone:delete:site:admin",
+	"phone:read:list_sites:admin",
+}
+
 func NewPhoneSiteResource() resource.Resource {
 	return &tfResource{}
 }
 
 type tfResource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -49,27 +62,26 @@ func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest,
 		)
 		return
 	}
-	r.crud = newCrud(data.PhoneClient)
+	r.crud = newCrud(data.PhoneClient, data.Cache, data.Locks)
+	r.providerData = data
+}
+
+func (r *tfResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(resourceScopes)...)
 }
 
 func (r *tfResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_phone_site"
 }
 
-func (r *tfResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tfResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Manages a site within Zoom Phone.
 
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:site:admin`",
-			"`phone:write:site:admin`",
-			"`phone:update:site:admin`",
-			"`phone:delete:site:admin`",
-			"`phone:read:list_sites:admin`",
-		}, ", ") + ".",
+This resource requires the ` + resourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -237,6 +249,10 @@ This resource requires the ` + strings.Join([]string{
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplaceIfConfigured()},
 				MarkdownDescription: "When select the Indian sip zone, then need to set the entity name. This field only applies to India based accounts.",
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -256,6 +272,8 @@ type resourceModel struct {
 	IndiaCity                types.String                          `tfsdk:"india_city"`
 	IndiaSdcaNpa             types.String                          `tfsdk:"india_sdca_npa"`
 	IndiaEntityName          types.String                          `tfsdk:"india_entity_name"`
+	SubAccountID             types.String                          `tfsdk:"sub_account_id"`
+	Timeouts                 timeouts.Value                        `tfsdk:"timeouts"`
 }
 
 type resourceModelMainAutoReceptionist struct {
@@ -282,17 +300,35 @@ type resourceModelShortExtensionRange struct {
 	RangeTo   types.String `tfsdk:"range_to"`
 }
 
+func (r *tfResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = shared.IdentitySchema("id")
+}
+
 func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_site", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	// The identity is set even when the object has been deleted outside of Terraform, as it does not change.
+	resp.Diagnostics.Append(shared.SetIdentity(ctx, resp.Identity, "id", state.ID, state.SubAccountID)...)
+
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	output, err := r.read(ctx, state)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone site", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error reading phone site", err))
 		return
 	}
 
@@ -306,7 +342,7 @@ func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 func (r *tfResource) read(ctx context.Context, plan resourceModel) (*resourceModel, error) {
 	dto, err := r.crud.read(ctx, plan.ID)
 	if err != nil {
-		return nil, fmt.Errorf("error read phone site: %v", err)
+		return nil, fmt.Errorf("error read phone site: %w", err)
 	}
 	if dto == nil {
 		return nil, nil // already deleted
@@ -358,16 +394,31 @@ func (r *tfResource) read(ctx context.Context, plan resourceModel) (*resourceMod
 		IndiaCity:       lo.Ternary(dto.indiaCity.ValueString() != "", dto.indiaCity, types.StringNull()),
 		IndiaSdcaNpa:    lo.Ternary(dto.indiaSdcaNpa.ValueString() != "", dto.indiaSdcaNpa, types.StringNull()),
 		IndiaEntityName: lo.Ternary(dto.indiaEntityName.ValueString() != "", dto.indiaEntityName, types.StringNull()),
+		SubAccountID:    plan.SubAccountID,
+		Timeouts:        plan.Timeouts,
 	}, nil
 }
 
 func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_site", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_site")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	ret, err := r.crud.create(ctx, &createDto{
 		autoReceptionistName:     plan.MainAutoReceptionist.Name,
@@ -394,10 +445,7 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 		indiaEntityName: plan.IndiaEntityName,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone site",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone site", err))
 		return
 	}
 
@@ -425,10 +473,7 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 		callerIDName: plan.CallerIDName,
 	}); err != nil {
 		_ = r.delete(ctx, ret.id)
-		resp.Diagnostics.AddError(
-			"Error creating phone site on updating",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone site on updating", err))
 		return
 	}
 
@@ -436,7 +481,7 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 	plan.ID = ret.id
 	output, err := r.read(ctx, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating phone site on reading", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error creating phone site on reading", err))
 		return
 	}
 
@@ -445,9 +490,13 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(shared.SetIdentityFromState(ctx, resp.State, resp.Identity, "id")...)
 }
 
 func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_site", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -458,6 +507,16 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		)
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_site")
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, shared.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	if err := r.crud.update(ctx, &updateDto{
 		id:       plan.ID,
@@ -481,20 +540,13 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		sipZoneID:    plan.SipZoneID,
 		callerIDName: plan.CallerIDName,
 	}); err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating phone site",
-			fmt.Sprintf(
-				"Could not update phone site %s, unexpected error: %s",
-				plan.ID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error updating phone site", err))
 		return
 	}
 
 	output, err := r.read(ctx, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating phone site", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error updating phone site", err))
 		return
 	}
 
@@ -503,25 +555,32 @@ func (r *tfResource) Update(ctx context.Context, req resource.UpdateRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(shared.SetIdentityFromState(ctx, resp.State, resp.Identity, "id")...)
 }
 
 func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_site", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_site")
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	if err := r.delete(ctx, state.ID); err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone site",
-			fmt.Sprintf(
-				"Could not delete phone site %s, unexpected error: %s",
-				state.ID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("id"), "Error deleting phone site", err))
 		return
 	}
 
@@ -533,11 +592,11 @@ func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 func (r *tfResource) delete(ctx context.Context, siteId types.String) error {
 	mainSite, err := r.crud.readMain(ctx)
 	if err != nil {
-		return fmt.Errorf("error read phone main site: %v", err)
+		return fmt.Errorf("error read phone main site: %w", err)
 	}
 	return r.crud.delete(ctx, siteId, mainSite.id)
 }
 
 func (r *tfResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	shared.ImportStateByKey(ctx, path.Root("id"), req, resp, "site", r.providerData.Cache.SiteImportKey)
 }
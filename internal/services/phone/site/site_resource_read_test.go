@@ -0,0 +1,96 @@
+package site_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/services/phone/site"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type staticToken struct{}
+
+func (staticToken) OpenapiAuthorization(_ context.Context, _ string) (zoomphone.OpenapiAuthorization, error) {
+	return zoomphone.OpenapiAuthorization{}, nil
+}
+
+func (staticToken) OpenapiOAuth(_ context.Context, _ string) (zoomphone.OpenapiOAuth, error) {
+	return zoomphone.OpenapiOAuth{Token: "token"}, nil
+}
+
+// readSite runs the Read of zoom_phone_site against a Zoom API that answers every request with status and body.
+func readSite(t *testing.T, status int, body string) *resource.ReadResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+	client, err := zoomphone.NewClient(server.URL, staticToken{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := site.NewPhoneSiteResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: &shared.ProviderData{
+		PhoneClient: client,
+		Cache:       shared.NewCache(client),
+		Locks:       shared.NewObjectLocks(),
+	}}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", configureResp.Diagnostics)
+	}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		Schema: schemaResp.Schema,
+	}
+	if diags := state.SetAttribute(ctx, path.Root("id"), "site-1"); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+	return resp
+}
+
+func TestPhoneSiteReadErrors(t *testing.T) {
+	t.Run("not found removes the site", func(t *testing.T) {
+		resp := readSite(t, http.StatusNotFound, `{"code":404,"message":"Site does not exist."}`)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if !resp.State.Raw.IsNull() {
+			t.Fatalf("expected the site to be removed from the state, got %v", resp.State.Raw)
+		}
+	})
+
+	t.Run("missing scope is classified", func(t *testing.T) {
+		resp := readSite(t, http.StatusForbidden, `{"code":4711,"message":"Invalid access token, does not contain scopes:[phone:read:site:admin]."}`)
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected an error")
+		}
+		detail := resp.Diagnostics.Errors()[0].Detail()
+		for _, want := range []string{
+			"Zoom API responded with HTTP 403, code 4711",
+			"Add the missing scopes",
+		} {
+			if !strings.Contains(detail, want) {
+				t.Errorf("expected detail to contain %q, got:\n%s", want, detail)
+			}
+		}
+	})
+}
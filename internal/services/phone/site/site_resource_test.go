@@ -15,8 +15,8 @@ func TestAccPhoneSite(t *testing.T) {
 	updatesiteTestResource := siteTestResource.copyWithName(fmt.Sprintf("acctest-%s", td.RandomStringOfLength(5)))
 
 	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: acceptance.TestAccProviderFactories,
-		CheckDestroy:             testAccPhoneSiteResourceDestroy(td),
+		ProtoV6ProviderFactories: acceptance.ProviderFactories(t),
+		CheckDestroy:             testAccPhoneSiteResourceDestroy(t, td),
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
@@ -43,6 +43,14 @@ func TestAccPhoneSite(t *testing.T) {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"default_emergency_address"},
 			},
+			// ImportState testing by name
+			{
+				ResourceName:            td.ResourceName,
+				ImportState:             true,
+				ImportStateId:           siteTestResource.Name,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"default_emergency_address"},
+			},
 			// Update and Read testing
 			{
 				Config: acceptance.ProviderConfig + updatesiteTestResource.requiredConfig(),
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"testing"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
 	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
@@ -66,14 +67,14 @@ resource %[1]q %[2]q {
 	)
 }
 
-func testAccPhoneSiteResourceDestroy(td acceptance.TestData) func(s *terraform.State) error {
+func testAccPhoneSiteResourceDestroy(t *testing.T, td acceptance.TestData) func(s *terraform.State) error {
 	return func(s *terraform.State) error {
 		for label, resourceState := range s.RootModule().Resources {
 			if resourceState.Type != td.TerraformResourceType || label != td.ResourceName {
 				continue
 			}
 
-			result, err := acceptance.Provider.ProviderData.PhoneClient.GetASite(context.Background(), zoomphone.GetASiteParams{
+			result, err := acceptance.ProviderData(t).PhoneClient.GetASite(context.Background(), zoomphone.GetASiteParams{
 				SiteId: resourceState.Primary.ID,
 			})
 			if result == nil && err == nil {
@@ -2,38 +2,38 @@ package user
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"net/http"
 	"strconv"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomuser"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/samber/lo"
 )
 
-func newCrud(phoneClient *zoomphone.Client, userClient *zoomuser.Client) *crud {
+func newCrud(phoneClient *zoomphone.Client, userClient *zoomuser.Client, cache *shared.Cache, locks *shared.ObjectLocks) *crud {
 	return &crud{
 		phoneClient: phoneClient,
 		userClient:  userClient,
+		cache:       cache,
+		locks:       locks,
 	}
 }
 
 type crud struct {
 	phoneClient *zoomphone.Client
 	userClient  *zoomuser.Client
+	cache       *shared.Cache
+	locks       *shared.ObjectLocks
 }
 
 func (c *crud) list(ctx context.Context, dto listQueryDto) (*listDto, error) {
-	var users []listDtoUser
-	nextPageToken := zoomphone.OptString{}
-
-	for {
+	items, err := util.CollectAll(util.Paginate(ctx, 100, func(ctx context.Context, pageSize int, nextPageToken string) ([]zoomphone.ListPhoneUsersOKUsersItem, string, error) {
 		ret, err := c.phoneClient.ListPhoneUsers(ctx, zoomphone.ListPhoneUsersParams{
-			PageSize:      zoomphone.NewOptInt(100), // Max 100
-			NextPageToken: nextPageToken,
+			PageSize:      zoomphone.NewOptInt(pageSize), // Max 100
+			NextPageToken: util.ToPhoneOptPageToken(nextPageToken),
 			SiteID:        util.ToPhoneOptString(dto.siteID),
 			CallingType:   util.ToPhoneOptInt(dto.callingType),
 			Status:        util.ToPhoneOptString(dto.status),
@@ -42,50 +42,49 @@ func (c *crud) list(ctx context.Context, dto listQueryDto) (*listDto, error) {
 			Keyword:       util.ToPhoneOptString(dto.keyword),
 		})
 		if err != nil {
-			return nil, fmt.Errorf("unable to list users: %v", err)
+			return nil, "", err
 		}
+		return ret.Users, ret.NextPageToken.Value, nil
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list users: %w", err)
+	}
 
-		users = append(users, lo.Map(ret.Users, func(item zoomphone.ListPhoneUsersOKUsersItem, _ int) listDtoUser {
-			return listDtoUser{
-				callingPlans: lo.Map(item.CallingPlans, func(item zoomphone.ListPhoneUsersOKUsersItemCallingPlansItem, index int) *listDtoUserCallingPlan {
-					return &listDtoUserCallingPlan{
-						name:               util.FromOptString(item.Name),
-						typ:                util.FromOptInt(item.Type),
-						billingAccountID:   util.FromOptString(item.BillingAccountID),
-						billingAccountName: util.FromOptString(item.BillingAccountName),
-					}
-				}),
-				email:           util.FromOptString(item.Email),
-				extensionID:     util.FromOptString(item.ExtensionID),
-				extensionNumber: util.FromOptInt64(item.ExtensionNumber),
-				userID:          util.FromOptString(item.ID),
-				name:            util.FromOptString(item.Name),
-				phoneUserID:     util.FromOptString(item.PhoneUserID),
-				site: lo.TernaryF(item.Site.IsSet(), func() *listDtoUserSite {
-					return &listDtoUserSite{
-						id:   util.FromOptString(item.Site.Value.ID),
-						name: util.FromOptString(item.Site.Value.Name),
-					}
-				}, func() *listDtoUserSite {
-					return nil
-				}),
-				status: util.FromOptString(item.Status),
-				phoneNumbers: lo.Map(item.PhoneNumbers, func(item zoomphone.ListPhoneUsersOKUsersItemPhoneNumbersItem, index int) *listDtoUserPhoneNumber {
-					return &listDtoUserPhoneNumber{
-						id:     util.FromOptString(item.ID),
-						number: util.FromOptString(item.Number),
-					}
-				}),
-				department: util.FromOptString(item.Department),
-				costCenter: util.FromOptString(item.CostCenter),
-			}
-		})...)
-
-		if !ret.NextPageToken.IsSet() || ret.NextPageToken.Value == "" {
-			break
+	users := lo.Map(items, func(item zoomphone.ListPhoneUsersOKUsersItem, _ int) listDtoUser {
+		return listDtoUser{
+			callingPlans: lo.Map(item.CallingPlans, func(item zoomphone.ListPhoneUsersOKUsersItemCallingPlansItem, index int) *listDtoUserCallingPlan {
+				return &listDtoUserCallingPlan{
+					name:               util.FromOptString(item.Name),
+					typ:                util.FromOptInt(item.Type),
+					billingAccountID:   util.FromOptString(item.BillingAccountID),
+					billingAccountName: util.FromOptString(item.BillingAccountName),
+				}
+			}),
+			email:           util.FromOptString(item.Email),
+			extensionID:     util.FromOptString(item.ExtensionID),
+			extensionNumber: util.FromOptInt64(item.ExtensionNumber),
+			userID:          util.FromOptString(item.ID),
+			name:            util.FromOptString(item.Name),
+			phoneUserID:     util.FromOptString(item.PhoneUserID),
+			site: lo.TernaryF(item.Site.IsSet(), func() *listDtoUserSite {
+				return &listDtoUserSite{
+					id:   util.FromOptString(item.Site.Value.ID),
+					name: util.FromOptString(item.Site.Value.Name),
+				}
+			}, func() *listDtoUserSite {
+				return nil
+			}),
+			status: util.FromOptString(item.Status),
+			phoneNumbers: lo.Map(item.PhoneNumbers, func(item zoomphone.ListPhoneUsersOKUsersItemPhoneNumbersItem, index int) *listDtoUserPhoneNumber {
+				return &listDtoUserPhoneNumber{
+					id:     util.FromOptString(item.ID),
+					number: util.FromOptString(item.Number),
+				}
+			}),
+			department: util.FromOptString(item.Department),
+			costCenter: util.FromOptString(item.CostCenter),
 		}
-		nextPageToken = ret.NextPageToken
-	}
+	})
 
 	return &listDto{
 		// Ensure uniqueness by using user ID, as duplicate data may occasionally be retrieved.
@@ -100,7 +99,7 @@ func (c *crud) read(ctx context.Context, zoomUserID types.String) (*readDto, err
 		UserId: zoomUserID.ValueString(),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to read phone user: %v", err)
+		return nil, fmt.Errorf("unable to read phone user: %w", err)
 	}
 
 	return &readDto{
@@ -131,9 +130,17 @@ func (c *crud) read(ctx context.Context, zoomUserID types.String) (*readDto, err
 }
 
 func (c *crud) create(ctx context.Context, dto createDto) (*createdDto, error) {
+	unlock, err := c.locks.Lock(ctx, "user", dto.zoomUserID.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidatePhoneUsers()
+
 	// There is no API to create a zoom phone user.
 	// Using the behavior that a phone user is created by changing the feature.zoom_phone attribute of the zoom user.
-	err := c.userClient.UserUpdate(ctx, zoomuser.NewOptUserUpdateReq(zoomuser.UserUpdateReq{
+	err = c.userClient.UserUpdate(ctx, zoomuser.NewOptUserUpdateReq(zoomuser.UserUpdateReq{
 		Feature: zoomuser.NewOptUserUpdateReqFeature(zoomuser.UserUpdateReqFeature{
 			ZoomPhone: zoomuser.NewOptBool(true),
 		}),
@@ -142,14 +149,22 @@ func (c *crud) create(ctx context.Context, dto createDto) (*createdDto, error) {
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("error creating phone user: %v", err)
+		return nil, fmt.Errorf("error creating phone user: %w", err)
 	}
 
 	return &createdDto{}, nil
 }
 
 func (c *crud) update(ctx context.Context, dto updateDto) error {
-	err := c.phoneClient.UpdateUserProfile(ctx, zoomphone.NewOptUpdateUserProfileReq(
+	unlock, err := c.locks.Lock(ctx, "user", dto.zoomUserID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidatePhoneUsers()
+
+	err = c.phoneClient.UpdateUserProfile(ctx, zoomphone.NewOptUpdateUserProfileReq(
 		zoomphone.UpdateUserProfileReq{
 			EmergencyAddressID: util.ToPhoneOptString(dto.emergencyAddressID),
 			ExtensionNumber: lo.TernaryF(dto.extensionNumber.IsNull() || dto.extensionNumber.IsUnknown(), func() zoomphone.OptString {
@@ -164,16 +179,24 @@ func (c *crud) update(ctx context.Context, dto updateDto) error {
 		UserId: dto.zoomUserID.ValueString(),
 	})
 	if err != nil {
-		return fmt.Errorf("error updating phone user: %v", err)
+		return fmt.Errorf("error updating phone user: %w", err)
 	}
 
 	return nil
 }
 
 func (c *crud) delete(ctx context.Context, zoomUserID types.String) error {
+	unlock, err := c.locks.Lock(ctx, "user", zoomUserID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidatePhoneUsers()
+
 	// There is no API to delete a zoom phone user.
 	// Using the behavior that a phone user is deleted by changing the feature.zoom_phone attribute of the zoom user.
-	err := c.userClient.UserUpdate(ctx, zoomuser.NewOptUserUpdateReq(zoomuser.UserUpdateReq{
+	err = c.userClient.UserUpdate(ctx, zoomuser.NewOptUserUpdateReq(zoomuser.UserUpdateReq{
 		Feature: zoomuser.NewOptUserUpdateReqFeature(zoomuser.UserUpdateReqFeature{
 			ZoomPhone: zoomuser.NewOptBool(false),
 		}),
@@ -182,13 +205,10 @@ func (c *crud) delete(ctx context.Context, zoomUserID types.String) error {
 	})
 
 	if err != nil {
-		var status *zoomuser.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.Response.Code.Value == http.StatusNotFound {
-				return nil
-			}
+		if util.IsNotFound(err) {
+			return nil
 		}
-		return fmt.Errorf("error deleting phone user: %v", err)
+		return fmt.Errorf("error deleting phone user: %w", err)
 	}
 
 	return nil
@@ -3,14 +3,12 @@ package user
 import (
 	"context"
 	"fmt"
-	"net/http"
-	"strings"
-	"time"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/schema/customvalidator"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -23,20 +21,31 @@ import (
 )
 
 var (
-	_ resource.Resource                = &tfResource{}
-	_ resource.ResourceWithConfigure   = &tfResource{}
-	_ resource.ResourceWithImportState = &tfResource{}
+	_ resource.Resource                   = &tfResource{}
+	_ resource.ResourceWithConfigure      = &tfResource{}
+	_ resource.ResourceWithImportState    = &tfResource{}
+	_ resource.ResourceWithIdentity       = &tfResource{}
+	_ resource.ResourceWithValidateConfig = &tfResource{}
 )
 
+// resourceScopes are the OAuth scopes this resource requires.
+var resourceScopes = shared.Scopes{
+	"user:update:user:admin",
+	"phone:read:user:admin",
+	"phone:update:user:admin",
+}
+
 type resourceModel struct {
-	UserID             types.String `tfsdk:"user_id"`
-	CostCenter         types.String `tfsdk:"cost_center"`
-	EmergencyAddressID types.String `tfsdk:"emergency_address_id"`
-	ExtensionID        types.String `tfsdk:"extension_id"`
-	ExtensionNumber    types.Int64  `tfsdk:"extension_number"`
-	PhoneUserID        types.String `tfsdk:"phone_user_id"`
-	SiteID             types.String `tfsdk:"site_id"`
-	TemplateID         types.String `tfsdk:"template_id"`
+	UserID             types.String   `tfsdk:"user_id"`
+	CostCenter         types.String   `tfsdk:"cost_center"`
+	EmergencyAddressID types.String   `tfsdk:"emergency_address_id"`
+	ExtensionID        types.String   `tfsdk:"extension_id"`
+	ExtensionNumber    types.Int64    `tfsdk:"extension_number"`
+	PhoneUserID        types.String   `tfsdk:"phone_user_id"`
+	SiteID             types.String   `tfsdk:"site_id"`
+	TemplateID         types.String   `tfsdk:"template_id"`
+	SubAccountID       types.String   `tfsdk:"sub_account_id"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewPhoneUserResource() resource.Resource {
@@ -44,8 +53,9 @@ func NewPhoneUserResource() resource.Resource {
 }
 
 type tfResource struct {
-	crud        *crud
-	phoneClient *zoomphone.Client
+	crud         *crud
+	phoneClient  *zoomphone.Client
+	providerData *shared.ProviderData
 }
 
 func (r *tfResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -64,8 +74,13 @@ func (r *tfResource) Configure(_ context.Context, req resource.ConfigureRequest,
 		)
 		return
 	}
-	r.crud = newCrud(data.PhoneClient, data.UserClient)
+	r.crud = newCrud(data.PhoneClient, data.UserClient, data.Cache, data.Locks)
 	r.phoneClient = data.PhoneClient
+	r.providerData = data
+}
+
+func (r *tfResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(r.providerData.ValidateScopes(resourceScopes)...)
 }
 
 func (r *tfResource) Schema(ctx context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
@@ -75,11 +90,7 @@ func (r *tfResource) Schema(ctx context.Context, _ resource.SchemaRequest, respo
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`user:update:user:admin`",
-			"`phone:read:user:admin`",
-			"`phone:update:user:admin`",
-		}, ", ") + ".",
+This resource requires the ` + resourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"user_id": schema.StringAttribute{
 				Required: true,
@@ -125,62 +136,72 @@ This resource requires the ` + strings.Join([]string{
 				},
 				MarkdownDescription: "The settings template ID. If the `site_id` is set, look for the template site with the value of the `site_id`. The template ID has precedence and the policy will be ignored even if the policy field is set.",
 			},
+			"sub_account_id": shared.SubAccountIDResourceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": shared.TimeoutsBlock(ctx),
 		},
 	}
 }
 
 func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_user", "Create")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_user")
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, shared.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	_, err := r.crud.create(ctx, createDto{
 		zoomUserID: plan.UserID,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating phone user",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("user_id"), "Error creating phone user", err))
 		return
 	}
 
-	// Wait until a phone user is created, as it is created asynchronously on Zoom.
-	if _, err := util.WaitForUpdateWithTimeout(ctx, 3*time.Minute, func(ctx context.Context) (*bool, error) {
+	// Wait until a phone user is created, as it is created asynchronously on Zoom, up to the create timeout.
+	if err := util.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
 		_, err := r.phoneClient.PhoneUser(ctx, zoomphone.PhoneUserParams{
 			UserId: plan.UserID.ValueString(),
 		})
 		if err != nil {
-			if errRes, ok := lo.ErrorsAs[*zoomphone.ErrorResponseStatusCode](err); ok {
-				if errRes.StatusCode == http.StatusNotFound {
-					return lo.ToPtr(false), nil
-				}
+			if util.IsNotFound(err) {
+				return lo.ToPtr(false), nil
 			}
 			return nil, err
 		}
 
 		return lo.ToPtr(true), nil
 	}); err != nil {
-		_ = r.crud.delete(ctx, plan.UserID)
-		resp.Diagnostics.AddError(
-			"Waiting for a phone user to be created, but it might have been never created.",
-			err.Error(),
-		)
+		// the create timeout may have passed, so clean up regardless of it
+		_ = r.crud.delete(context.WithoutCancel(ctx), plan.UserID)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("user_id"), "Waiting for a phone user to be created, but it might have been never created.", err))
 		return
 	}
 
 	if err := r.update(ctx, plan); err != nil {
 		_ = r.crud.delete(ctx, plan.UserID)
-		resp.Diagnostics.AddError("Error updating phone user on creating", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("user_id"), "Error updating phone user on creating", err))
 		return
 	}
 
 	output, err := r.read(ctx, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone user on creating", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("user_id"), "Error reading phone user on creating", err))
 		return
 	}
 
@@ -189,19 +210,38 @@ func (r *tfResource) Create(ctx context.Context, req resource.CreateRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(shared.SetIdentityFromState(ctx, resp.State, resp.Identity, "user_id")...)
+}
+
+func (r *tfResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = shared.IdentitySchema("user_id")
 }
 
 func (r *tfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_user", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	// The identity is set even when the object has been deleted outside of Terraform, as it does not change.
+	resp.Diagnostics.Append(shared.SetIdentity(ctx, resp.Identity, "user_id", state.UserID, state.SubAccountID)...)
+
+	readTimeout, diags := state.Timeouts.Read(ctx, shared.DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	output, err := r.read(ctx, state)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone user", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("user_id"), "Error reading phone user", err))
 		return
 	}
 
@@ -227,25 +267,40 @@ func (r *tfResource) read(ctx context.Context, plan resourceModel) (*resourceMod
 		PhoneUserID:        dto.phoneUserID,
 		SiteID:             dto.siteID,
 		TemplateID:         plan.TemplateID,
+		SubAccountID:       plan.SubAccountID,
+		Timeouts:           plan.Timeouts,
 	}, nil
 }
 
 func (r tfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_user", "Update")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var plan resourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, plan.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_user")
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, shared.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	if err := r.update(ctx, plan); err != nil {
-		resp.Diagnostics.AddError("Error updating phone user on updating", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("user_id"), "Error updating phone user on updating", err))
 		return
 	}
 
 	output, err := r.read(ctx, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading phone user on updating", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("user_id"), "Error reading phone user on updating", err))
 		return
 	}
 
@@ -254,6 +309,7 @@ func (r tfResource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(shared.SetIdentityFromState(ctx, resp.State, resp.Identity, "user_id")...)
 }
 
 func (r *tfResource) update(ctx context.Context, plan resourceModel) error {
@@ -264,29 +320,35 @@ func (r *tfResource) update(ctx context.Context, plan resourceModel) error {
 		siteID:             plan.SiteID,
 		templateID:         plan.TemplateID,
 	}); err != nil {
-		return fmt.Errorf("error updating phone user: %v", err)
+		return fmt.Errorf("error updating phone user: %w", err)
 	}
 
 	return nil
 }
 
 func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := shared.StartSpan(ctx, "zoom_phone_user", "Delete")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var state resourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, state.SubAccountID)
+	ctx = shared.WithResourceType(ctx, "zoom_phone_user")
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, shared.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	if err := r.crud.delete(ctx, state.UserID); err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting phone user",
-			fmt.Sprintf(
-				"Could not delete phone user %s, unexpected error: %s",
-				state.UserID.ValueString(),
-				err,
-			),
-		)
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Root("user_id"), "Error deleting phone user", err))
 		return
 	}
 
@@ -297,5 +359,5 @@ func (r *tfResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 }
 
 func (r *tfResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("user_id"), req, resp)
+	shared.ImportStateByKey(ctx, path.Root("user_id"), req, resp, "phone user", r.providerData.Cache.PhoneUserImportKey)
 }
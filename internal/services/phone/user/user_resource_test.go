@@ -0,0 +1,76 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccPhoneUser(t *testing.T) {
+	td := acceptance.NewTestData(t, "zoom_phone_user", "test")
+	siteID := acceptance.SiteID(t)
+	userID := acceptance.UserID(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactories(t),
+		// Zoom Phone of the user is disabled on destroy.
+		CheckDestroy: acceptance.CheckDestroy(td.TerraformResourceType, func(ctx context.Context, rs *terraform.ResourceState) error {
+			_, err := acceptance.ProviderData(t).PhoneClient.PhoneUser(ctx, zoomphone.PhoneUserParams{
+				UserId: rs.Primary.Attributes["user_id"],
+			})
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: acceptance.ProviderConfig + `
+resource "zoom_phone_user" "test" {
+  user_id = "` + userID + `"
+  site_id = "` + siteID + `"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "user_id", userID),
+					resource.TestCheckResourceAttr(td.ResourceName, "site_id", siteID),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "phone_user_id"),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "extension_id"),
+					resource.TestCheckResourceAttrSet(td.ResourceName, "extension_number"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         td.ResourceName,
+				ImportState:                          true,
+				ImportStateIdFunc:                    acceptance.ImportStateIDFromAttribute(td.ResourceName, "user_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "user_id",
+			},
+			// ImportState testing by extension number
+			{
+				ResourceName:                         td.ResourceName,
+				ImportState:                          true,
+				ImportStateIdFunc:                    acceptance.ImportStateIDFromAttribute(td.ResourceName, "extension_number"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "user_id",
+			},
+			// Update and Read testing
+			{
+				Config: acceptance.ProviderConfig + `
+resource "zoom_phone_user" "test" {
+  user_id          = "` + userID + `"
+  site_id          = "` + siteID + `"
+  extension_number = 81021
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(td.ResourceName, "user_id", userID),
+					resource.TestCheckResourceAttr(td.ResourceName, "extension_number", "81021"),
+				),
+			},
+		},
+	})
+}
@@ -3,26 +3,34 @@ package user
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/samber/lo"
 )
 
 var (
-	_ datasource.DataSource              = &tfDataSource{}
-	_ datasource.DataSourceWithConfigure = &tfDataSource{}
+	_ datasource.DataSource                   = &tfDataSource{}
+	_ datasource.DataSourceWithConfigure      = &tfDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &tfDataSource{}
 )
 
+// dataSourceScopes are the OAuth scopes this data source requires.
+var dataSourceScopes = shared.Scopes{
+	"phone:read:list_users:admin",
+}
+
 func NewPhoneUsersDataSource() datasource.DataSource {
 	return &tfDataSource{}
 }
 
 type tfDataSource struct {
-	crud *crud
+	crud         *crud
+	providerData *shared.ProviderData
 }
 
 func (d *tfDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -37,7 +45,12 @@ func (d *tfDataSource) Configure(_ context.Context, req datasource.ConfigureRequ
 		)
 		return
 	}
-	d.crud = newCrud(data.PhoneClient, data.UserClient)
+	d.crud = newCrud(data.PhoneClient, data.UserClient, data.Cache, data.Locks)
+	d.providerData = data
+}
+
+func (d *tfDataSource) ValidateConfig(_ context.Context, _ datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(d.providerData.ValidateScopes(dataSourceScopes)...)
 }
 
 func (d *tfDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -51,9 +64,7 @@ func (d *tfDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, res
 ## API Permissions
 
 The following API permissions are required in order to use this resource.
-This resource requires the ` + strings.Join([]string{
-			"`phone:read:list_users:admin`",
-		}, ", ") + ".",
+This resource requires the ` + dataSourceScopes.Markdown() + ".",
 		Attributes: map[string]schema.Attribute{
 			"query": schema.SingleNestedAttribute{
 				Optional:            true,
@@ -177,13 +188,15 @@ This resource requires the ` + strings.Join([]string{
 					},
 				},
 			},
+			"sub_account_id": shared.SubAccountIDDataSourceAttribute(),
 		},
 	}
 }
 
 type dataSourceModel struct {
-	Query *dataSourceModelQuery  `tfsdk:"query"`
-	Users []*dataSourceModelUser `tfsdk:"users"`
+	Query        *dataSourceModelQuery  `tfsdk:"query"`
+	Users        []*dataSourceModelUser `tfsdk:"users"`
+	SubAccountID types.String           `tfsdk:"sub_account_id"`
 }
 
 type dataSourceModelQuery struct {
@@ -223,11 +236,15 @@ type dataSourceModelUserCallingPlan struct {
 }
 
 func (d *tfDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := shared.StartSpan(ctx, "data.zoom_phone_users", "Read")
+	defer shared.EndSpan(span, &resp.Diagnostics)
+
 	var data dataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = shared.WithSubAccountID(ctx, data.SubAccountID)
 
 	dto, err := d.crud.list(ctx, lo.TernaryF(data.Query == nil, func() listQueryDto {
 		return listQueryDto{}
@@ -242,7 +259,7 @@ func (d *tfDataSource) Read(ctx context.Context, req datasource.ReadRequest, res
 		}
 	}))
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading users", err.Error())
+		resp.Diagnostics.Append(util.ErrorDiagnostic(path.Empty(), "Error reading users", err))
 		return
 	}
 
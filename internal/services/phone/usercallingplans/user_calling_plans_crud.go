@@ -7,20 +7,25 @@ import (
 	"strconv"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/samber/lo"
 	lop "github.com/samber/lo/parallel"
 )
 
-func newCrud(client *zoomphone.Client) *crud {
+func newCrud(client *zoomphone.Client, cache *shared.Cache, locks *shared.ObjectLocks) *crud {
 	return &crud{
 		client: client,
+		cache:  cache,
+		locks:  locks,
 	}
 }
 
 type crud struct {
 	client *zoomphone.Client
+	cache  *shared.Cache
+	locks  *shared.ObjectLocks
 }
 
 func (c *crud) read(ctx context.Context, userID types.String) (*readDto, error) {
@@ -28,7 +33,7 @@ func (c *crud) read(ctx context.Context, userID types.String) (*readDto, error)
 		UserId: userID.ValueString(),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to read phone user: %v", err)
+		return nil, fmt.Errorf("unable to read phone user: %w", err)
 	}
 
 	return &readDto{
@@ -43,7 +48,15 @@ func (c *crud) read(ctx context.Context, userID types.String) (*readDto, error)
 }
 
 func (c *crud) create(ctx context.Context, dto createDto) (*createdDto, error) {
-	err := c.client.AssignCallingPlan(ctx, zoomphone.NewOptAssignCallingPlanReq(zoomphone.AssignCallingPlanReq{
+	unlock, err := c.locks.Lock(ctx, "user", dto.userID.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidatePhoneUsers()
+
+	err = c.client.AssignCallingPlan(ctx, zoomphone.NewOptAssignCallingPlanReq(zoomphone.AssignCallingPlanReq{
 		CallingPlans: lo.Map(dto.callingPlans, func(v createDtoCallingPlan, _ int) zoomphone.AssignCallingPlanReqCallingPlansItem {
 			return zoomphone.AssignCallingPlanReqCallingPlansItem{
 				Type:             util.ToPhoneOptInt(v.callingPlanType),
@@ -54,12 +67,20 @@ func (c *crud) create(ctx context.Context, dto createDto) (*createdDto, error) {
 		UserId: dto.userID.ValueString(),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to create phone user calling plan: %v", err)
+		return nil, fmt.Errorf("unable to create phone user calling plan: %w", err)
 	}
 	return &createdDto{}, nil
 }
 
 func (c *crud) delete(ctx context.Context, dto deleteDto) error {
+	unlock, err := c.locks.Lock(ctx, "user", dto.userID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidatePhoneUsers()
+
 	errs := lo.Compact(lop.Map(dto.callingPlans, func(v deleteDtoCallingPlan, _ int) error {
 		err := c.client.UnassignCallingPlan(ctx, zoomphone.UnassignCallingPlanParams{
 			UserId:           dto.userID.ValueString(),
@@ -68,13 +89,10 @@ func (c *crud) delete(ctx context.Context, dto deleteDto) error {
 		})
 
 		if err != nil {
-			var status *zoomphone.ErrorResponseStatusCode
-			if errors.As(err, &status) {
-				if status.StatusCode == 404 {
-					return nil // already deleted
-				}
+			if util.IsNotFound(err) {
+				return nil // already deleted
 			}
-			return fmt.Errorf("unable to delete phone user calling plan: %v", err)
+			return fmt.Errorf("unable to delete phone user calling plan: %w", err)
 		}
 
 		return nil
@@ -2,23 +2,27 @@ package userphonenumber
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/internal/provider/shared"
 	"github.com/folio-sec/terraform-provider-zoom/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/samber/lo"
 )
 
-func newCrud(client *zoomphone.Client) *crud {
+func newCrud(client *zoomphone.Client, cache *shared.Cache, locks *shared.ObjectLocks) *crud {
 	return &crud{
 		client: client,
+		cache:  cache,
+		locks:  locks,
 	}
 }
 
 type crud struct {
 	client *zoomphone.Client
+	cache  *shared.Cache
+	locks  *shared.ObjectLocks
 }
 
 func (c *crud) read(ctx context.Context, userID types.String) (*readDto, error) {
@@ -26,13 +30,10 @@ func (c *crud) read(ctx context.Context, userID types.String) (*readDto, error)
 		UserId: userID.ValueString(),
 	})
 	if err != nil {
-		var status *zoomphone.ErrorResponseStatusCode
-		if errors.As(err, &status) {
-			if status.StatusCode == 404 {
-				return nil, nil // already deleted
-			}
+		if util.IsNotFound(err) {
+			return nil, nil // already deleted
 		}
-		return nil, fmt.Errorf("unable to read phone user: %v", err)
+		return nil, fmt.Errorf("unable to read phone user: %w", err)
 	}
 	phoneNumbers := lo.Map(ret.PhoneNumbers, func(p zoomphone.PhoneUserOKPhoneNumbersItem, _index int) *readDtoPhoneNumber {
 		return &readDtoPhoneNumber{
@@ -46,6 +47,14 @@ func (c *crud) read(ctx context.Context, userID types.String) (*readDto, error)
 }
 
 func (c *crud) assign(ctx context.Context, dto *assignDto) error {
+	unlock, err := c.locks.Lock(ctx, "user", dto.userID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidatePhoneUsers()
+
 	// Only a max of 5 numbers can be assigned to a call queue at a time.
 	for _, phoneNumberIDs := range lo.Chunk(dto.phoneNumberIDs, 5) {
 		_, err := c.client.AssignPhoneNumber(ctx, zoomphone.NewOptAssignPhoneNumberReq(
@@ -58,7 +67,7 @@ func (c *crud) assign(ctx context.Context, dto *assignDto) error {
 			},
 		), zoomphone.AssignPhoneNumberParams{UserId: dto.userID.ValueString()})
 		if err != nil {
-			return fmt.Errorf("error assigning phone user phone numbers by phone number id: %v", err)
+			return fmt.Errorf("error assigning phone user phone numbers by phone number id: %w", err)
 		}
 	}
 	for _, phoneNumbers := range lo.Chunk(dto.phoneNumbers, 5) {
@@ -72,26 +81,31 @@ func (c *crud) assign(ctx context.Context, dto *assignDto) error {
 			},
 		), zoomphone.AssignPhoneNumberParams{UserId: dto.userID.ValueString()})
 		if err != nil {
-			return fmt.Errorf("error assigning phone user phone numbers by phone number: %v", err)
+			return fmt.Errorf("error assigning phone user phone numbers by phone number: %w", err)
 		}
 	}
 	return nil
 }
 
 func (c *crud) unassign(ctx context.Context, dto *unassignDto) error {
+	unlock, err := c.locks.Lock(ctx, "user", dto.userID.ValueString())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	defer c.cache.InvalidatePhoneUsers()
+
 	for _, phoneNumberID := range dto.phoneNumberIDs {
 		err := c.client.UnassignPhoneNumber(ctx, zoomphone.UnassignPhoneNumberParams{
 			UserId:        dto.userID.ValueString(),
 			PhoneNumberId: phoneNumberID.ValueString(),
 		})
 		if err != nil {
-			var status *zoomphone.ErrorResponseStatusCode
-			if errors.As(err, &status) {
-				if status.StatusCode == 404 {
-					continue
-				}
+			if util.IsNotFound(err) {
+				continue
 			}
-			return fmt.Errorf("error unassigning phone user phone numbers: %v", err)
+			return fmt.Errorf("error unassigning phone user phone numbers: %w", err)
 		}
 	}
 	return nil
@@ -21,23 +21,26 @@ type crud struct {
 }
 
 func (c *crud) list(ctx context.Context, dto listQueryDto) (*listDto, error) {
-	var users []listDtoUser
-	nextPageToken := zoomuser.OptString{}
-
-	for {
+	items, err := util.CollectAll(util.Paginate(ctx, 300, func(ctx context.Context, pageSize int, nextPageToken string) ([]zoomuser.UsersOKUsersItem, string, error) {
 		ret, err := c.client.Users(ctx, zoomuser.UsersParams{
 			Status:        util.ToUserOptString(dto.status),
 			RoleID:        util.ToUserOptString(dto.roleID),
 			IncludeFields: util.ToUserOptString(dto.includeFields),
 			License:       util.ToUserOptString(dto.license),
-			PageSize:      zoomuser.NewOptInt(300),
-			NextPageToken: nextPageToken,
+			PageSize:      zoomuser.NewOptInt(pageSize),
+			NextPageToken: util.ToUserOptPageToken(nextPageToken),
 		})
 		if err != nil {
-			return nil, fmt.Errorf("unable to read users: %v", err)
+			return nil, "", err
 		}
+		return ret.Users, ret.NextPageToken.Value, nil
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read users: %w", err)
+	}
 
-		users = append(users, lo.Map(ret.Users, func(item zoomuser.UsersOKUsersItem, _ int) listDtoUser {
+	return &listDto{
+		users: lo.Map(items, func(item zoomuser.UsersOKUsersItem, _ int) listDtoUser {
 			return listDtoUser{
 				userID: util.FromOptString(item.ID),
 				customAttributes: lo.Map(item.CustomAttributes, func(item zoomuser.UsersOKUsersItemCustomAttributesItem, _ int) listDtoUserCustomAttribute {
@@ -71,15 +74,6 @@ func (c *crud) list(ctx context.Context, dto listQueryDto) (*listDto, error) {
 				userCreatedAt:     util.FromOptDateTime(item.UserCreatedAt),
 				verified:          util.FromOptInt(item.Verified),
 			}
-		})...)
-
-		if !ret.NextPageToken.IsSet() || ret.NextPageToken.Value == "" {
-			break
-		}
-		nextPageToken = ret.NextPageToken
-	}
-
-	return &listDto{
-		users: users,
+		}),
 	}, nil
 }
@@ -6,8 +6,9 @@ import (
 )
 
 type dataSourceModel struct {
-	Query *dataSourceModelQuery `tfsdk:"query"`
-	Users []dataSourceModelUser `tfsdk:"users"`
+	Query        *dataSourceModelQuery `tfsdk:"query"`
+	Users        []dataSourceModelUser `tfsdk:"users"`
+	SubAccountID types.String          `tfsdk:"sub_account_id"`
 }
 
 type dataSourceModelQuery struct {
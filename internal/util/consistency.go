@@ -18,6 +18,7 @@ import (
 
 type ChangeFunc func(ctx context.Context) (*bool, error)
 
+// WaitForDeletion polls f until it reports the object is gone, up to the deadline of ctx, i.e. the timeout of the operation.
 func WaitForDeletion(ctx context.Context, f ChangeFunc) error {
 	deadline, ok := ctx.Deadline()
 	if !ok {
@@ -49,21 +50,17 @@ func WaitForDeletion(ctx context.Context, f ChangeFunc) error {
 	return err
 }
 
+// WaitForUpdate polls f until it reports the change is done, up to the deadline of ctx, i.e. the timeout of the operation.
 func WaitForUpdate(ctx context.Context, f ChangeFunc) error {
 	deadline, ok := ctx.Deadline()
 	if !ok {
 		return errors.New("context has no deadline")
 	}
 
-	_, err := WaitForUpdateWithTimeout(ctx, time.Until(deadline), f)
-	return err
-}
-
-func WaitForUpdateWithTimeout(ctx context.Context, timeout time.Duration, f ChangeFunc) (bool, error) {
-	res, err := (&retry.StateChangeConf{ //nolint:staticcheck
+	_, err := (&retry.StateChangeConf{ //nolint:staticcheck
 		Pending:                   []string{"Waiting"},
 		Target:                    []string{"Done"},
-		Timeout:                   timeout,
+		Timeout:                   time.Until(deadline),
 		MinTimeout:                5 * time.Second,
 		ContinuousTargetOccurence: 5,
 		Refresh: func() (interface{}, string, error) {
@@ -80,9 +77,5 @@ func WaitForUpdateWithTimeout(ctx context.Context, timeout time.Duration, f Chan
 			return false, "Waiting", nil
 		},
 	}).WaitForStateContext(ctx)
-
-	if res == nil {
-		return false, err
-	}
-	return res.(bool), err
+	return err
 }
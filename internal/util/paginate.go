@@ -0,0 +1,83 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// PageFunc fetches one page of a list API. The first page is fetched with an empty nextPageToken,
+// and an empty returned token means there are no more pages.
+type PageFunc[T any] func(ctx context.Context, pageSize int, nextPageToken string) (items []T, next string, err error)
+
+// Paginate iterates over all items of a list API that pages with next_page_token.
+// pageSize should be the maximum the API accepts, to keep the number of requests down.
+//
+// While the items of a page are yielded, the next page is already being fetched.
+// Breaking out of the loop cancels that fetch and waits for it, so fetch never outlives the iteration.
+// A fetch error is yielded once and ends the iteration, and so is a next_page_token that Zoom hands out twice,
+// which would otherwise page forever.
+func Paginate[T any](ctx context.Context, pageSize int, fetch PageFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		type page struct {
+			items []T
+			next  string
+			err   error
+		}
+		fetchAsync := func(nextPageToken string) <-chan page {
+			ch := make(chan page, 1)
+			go func() {
+				items, next, err := fetch(ctx, pageSize, nextPageToken)
+				ch <- page{items: items, next: next, err: err}
+			}()
+			return ch
+		}
+
+		pending := fetchAsync("")
+		defer func() {
+			cancel()
+			if pending != nil {
+				<-pending
+			}
+		}()
+
+		seen := map[string]bool{}
+		for pending != nil {
+			p := <-pending
+			pending = nil
+			if p.err != nil {
+				var zero T
+				yield(zero, p.err)
+				return
+			}
+			repeated := seen[p.next]
+			if p.next != "" && !repeated {
+				seen[p.next] = true
+				pending = fetchAsync(p.next)
+			}
+			for _, item := range p.items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if repeated {
+				var zero T
+				yield(zero, fmt.Errorf("next_page_token %q was returned twice by Zoom", p.next))
+				return
+			}
+		}
+	}
+}
+
+// CollectAll gathers all items of seq, stopping at the first error.
+func CollectAll[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,89 @@
+package util_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+)
+
+// pages serves items in pages of pageSize, like the Zoom list APIs.
+func pages(items []int, calls *atomic.Int32) util.PageFunc[int] {
+	return func(ctx context.Context, pageSize int, nextPageToken string) ([]int, string, error) {
+		calls.Add(1)
+		start := 0
+		if nextPageToken != "" {
+			start = int(nextPageToken[0] - '0')
+		}
+		end := min(start+pageSize, len(items))
+		next := ""
+		if end < len(items) {
+			next = string(rune('0' + end))
+		}
+		return items[start:end], next, nil
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	var calls atomic.Int32
+	got, err := util.CollectAll(util.Paginate(context.Background(), 2, pages([]int{1, 2, 3, 4, 5}, &calls)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("unexpected items: %v", got)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected 3 pages, got %d", calls.Load())
+	}
+}
+
+func TestPaginateEarlyTermination(t *testing.T) {
+	var calls atomic.Int32
+	for item, err := range util.Paginate(context.Background(), 2, pages([]int{1, 2, 3, 4, 5, 6, 7}, &calls)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if item == 1 {
+			break
+		}
+	}
+	// The second page may have been prefetched, but nothing after it.
+	if calls.Load() > 2 {
+		t.Fatalf("expected at most 2 pages, got %d", calls.Load())
+	}
+}
+
+func TestPaginateError(t *testing.T) {
+	wantErr := errors.New("boom")
+	fetch := func(ctx context.Context, pageSize int, nextPageToken string) ([]int, string, error) {
+		if nextPageToken == "" {
+			return []int{1}, "next", nil
+		}
+		return nil, "", wantErr
+	}
+	if _, err := util.CollectAll(util.Paginate(context.Background(), 1, fetch)); !errors.Is(err, wantErr) {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+}
+
+func TestPaginateRepeatedToken(t *testing.T) {
+	fetch := func(ctx context.Context, pageSize int, nextPageToken string) ([]int, string, error) {
+		return []int{1}, "same", nil
+	}
+	var got []int
+	var gotErr error
+	for item, err := range util.Paginate(context.Background(), 1, fetch) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		got = append(got, item)
+	}
+	if len(got) != 2 || gotErr == nil || gotErr.Error() != `next_page_token "same" was returned twice by Zoom` {
+		t.Fatalf("expected paging to fail at the repeated token, got %v and %v", got, gotErr)
+	}
+}
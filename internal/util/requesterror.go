@@ -0,0 +1,43 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AmbiguousRequestError is returned when a non-idempotent request failed after it might have reached Zoom,
+// e.g. the connection was lost while waiting for the response. Whether Zoom applied it is unknown, so it is not retried.
+type AmbiguousRequestError struct {
+	Method string
+	URL    string
+	Err    error
+}
+
+func (e *AmbiguousRequestError) Error() string {
+	return fmt.Sprintf("%s %s failed and it is unknown whether Zoom applied it, so it was not retried: %v", e.Method, e.URL, e.Err)
+}
+
+func (e *AmbiguousRequestError) Unwrap() error {
+	return e.Err
+}
+
+// ReadOnlyError is returned instead of sending a request that could change the account in read-only mode.
+type ReadOnlyError struct {
+	// ResourceType is the resource type on whose behalf the request was made, e.g. zoom_phone_site, or empty when unknown.
+	ResourceType string
+	// Operation is the Terraform method of the resource that made the request, e.g. Create, or empty when unknown.
+	Operation string
+	Method    string
+	Path      string
+}
+
+func (e *ReadOnlyError) Error() string {
+	msg := fmt.Sprintf("the provider is read-only and refused to send %s %s", e.Method, e.Path)
+	switch {
+	case e.ResourceType != "" && e.Operation != "":
+		msg += fmt.Sprintf(" to %s %s", strings.ToLower(e.Operation), e.ResourceType)
+	case e.ResourceType != "":
+		msg += " for " + e.ResourceType
+	}
+	return msg
+}
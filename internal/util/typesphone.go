@@ -13,6 +13,14 @@ func ToPhoneOptBool(o types.Bool) zoomphone.OptBool {
 	return zoomphone.NewOptBool(o.ValueBool())
 }
 
+// ToPhoneOptPageToken leaves the token unset for the first page, so no empty next_page_token is sent.
+func ToPhoneOptPageToken(nextPageToken string) zoomphone.OptString {
+	if nextPageToken == "" {
+		return zoomphone.OptString{}
+	}
+	return zoomphone.NewOptString(nextPageToken)
+}
+
 func ToPhoneOptString(o types.String) zoomphone.OptString {
 	if o.IsNull() || o.IsUnknown() {
 		return zoomphone.OptString{}
@@ -13,6 +13,14 @@ func ToUserOptBool(o types.Bool) zoomuser.OptBool {
 	return zoomuser.NewOptBool(o.ValueBool())
 }
 
+// ToUserOptPageToken leaves the token unset for the first page, so no empty next_page_token is sent.
+func ToUserOptPageToken(nextPageToken string) zoomuser.OptString {
+	if nextPageToken == "" {
+		return zoomuser.OptString{}
+	}
+	return zoomuser.NewOptString(nextPageToken)
+}
+
 func ToUserOptString(o types.String) zoomuser.OptString {
 	if o.IsNull() || o.IsUnknown() {
 		return zoomuser.OptString{}
@@ -0,0 +1,186 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomuser"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// ZoomErrorKind classifies an error response of the Zoom API by what the user can do about it.
+type ZoomErrorKind int
+
+const (
+	ZoomErrorUnknown ZoomErrorKind = iota
+	ZoomErrorNotFound
+	ZoomErrorMissingScope
+	ZoomErrorRateLimited
+	ZoomErrorValidation
+	ZoomErrorConflict
+)
+
+func (k ZoomErrorKind) String() string {
+	switch k {
+	case ZoomErrorNotFound:
+		return "not found"
+	case ZoomErrorMissingScope:
+		return "missing scope"
+	case ZoomErrorRateLimited:
+		return "rate limited"
+	case ZoomErrorValidation:
+		return "validation failed"
+	case ZoomErrorConflict:
+		return "conflict"
+	default:
+		return "unknown"
+	}
+}
+
+// hint tells the user how to resolve an error of the kind.
+func (k ZoomErrorKind) hint() string {
+	switch k {
+	case ZoomErrorNotFound:
+		return "The object does not exist in Zoom. If it was deleted outside of Terraform, remove it from the state or apply again to re-create it."
+	case ZoomErrorMissingScope:
+		return "Add the missing scopes to the OAuth app in the Zoom App Marketplace, then reactivate a Server-to-Server OAuth app, or authorize a General OAuth app again, so that new access tokens are granted them."
+	case ZoomErrorRateLimited:
+		return "The Zoom API rate limit was exceeded. Retry later, or lower the parallelism of Terraform with -parallelism."
+	case ZoomErrorValidation:
+		return "Zoom rejected a value of the request. Check the configuration against the constraints documented for the attribute."
+	case ZoomErrorConflict:
+		return "The object conflicts with an existing one, for example by name or extension number. Import the existing object or choose another value."
+	default:
+		return ""
+	}
+}
+
+// ZoomError is an error response of the Zoom Phone or Zoom User API.
+type ZoomError struct {
+	StatusCode int
+	Code       int
+	Message    string
+	// Fields are the per-field messages of a validation error.
+	Fields []ZoomErrorField
+
+	err error
+}
+
+type ZoomErrorField struct {
+	Field   string
+	Message string
+}
+
+func (e *ZoomError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ZoomError) Unwrap() error {
+	return e.err
+}
+
+// Kind classifies the error. Zoom is not consistent across endpoints, e.g. a missing object is reported either with 404,
+// or with 400 and code 300 and a "does not exist" message.
+func (e *ZoomError) Kind() ZoomErrorKind {
+	message := strings.ToLower(e.Message)
+	switch {
+	case e.StatusCode == http.StatusTooManyRequests || e.Code == http.StatusTooManyRequests:
+		return ZoomErrorRateLimited
+	case e.Code == 4700 || e.Code == 4711 ||
+		((e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden) && strings.Contains(message, "scope")):
+		return ZoomErrorMissingScope
+	case e.StatusCode == http.StatusNotFound || e.Code == http.StatusNotFound:
+		return ZoomErrorNotFound
+	case e.StatusCode == http.StatusConflict || e.Code == http.StatusConflict || strings.Contains(message, "already exist"):
+		return ZoomErrorConflict
+	case e.StatusCode == http.StatusBadRequest && e.Code == 300 &&
+		(strings.Contains(message, "not exist") || strings.Contains(message, "not found")):
+		return ZoomErrorNotFound
+	case e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity:
+		return ZoomErrorValidation
+	default:
+		return ZoomErrorUnknown
+	}
+}
+
+// AsZoomError finds the error response of the Zoom API in the chain of err.
+func AsZoomError(err error) (*ZoomError, bool) {
+	var phoneStatus *zoomphone.ErrorResponseStatusCode
+	if errors.As(err, &phoneStatus) {
+		zerr := &ZoomError{
+			StatusCode: phoneStatus.StatusCode,
+			Code:       phoneStatus.Response.Code.Value,
+			Message:    phoneStatus.Response.Message.Value,
+			err:        phoneStatus,
+		}
+		for _, item := range phoneStatus.Response.Errors {
+			zerr.Fields = append(zerr.Fields, ZoomErrorField{Field: item.Field.Value, Message: item.Message.Value})
+		}
+		return zerr, true
+	}
+	var userStatus *zoomuser.ErrorResponseStatusCode
+	if errors.As(err, &userStatus) {
+		zerr := &ZoomError{
+			StatusCode: userStatus.StatusCode,
+			Code:       userStatus.Response.Code.Value,
+			Message:    userStatus.Response.Message.Value,
+			err:        userStatus,
+		}
+		for _, item := range userStatus.Response.Errors {
+			zerr.Fields = append(zerr.Fields, ZoomErrorField{Field: item.Field.Value, Message: item.Message.Value})
+		}
+		return zerr, true
+	}
+	return nil, false
+}
+
+// IsNotFound reports whether err means that the object looked up does not exist, e.g. it was deleted outside of Terraform.
+// Some endpoints answer a lookup of a missing object with 400 and code 300 whatever the message, so that counts as well.
+func IsNotFound(err error) bool {
+	zerr, ok := AsZoomError(err)
+	if !ok {
+		return false
+	}
+	kind := zerr.Kind()
+	return kind == ZoomErrorNotFound || (kind == ZoomErrorValidation && zerr.Code == 300)
+}
+
+// IsAmbiguous reports whether a create failed without telling whether Zoom applied it, e.g. the connection was lost
+// after the request was sent or a gateway timed out. The caller should look the object up before reporting the error.
+func IsAmbiguous(err error) bool {
+	var ambiguousErr *AmbiguousRequestError
+	if errors.As(err, &ambiguousErr) {
+		return true
+	}
+	zerr, ok := AsZoomError(err)
+	return ok && (zerr.StatusCode == http.StatusBadGateway || zerr.StatusCode == http.StatusGatewayTimeout)
+}
+
+// ErrorDiagnostic translates err into a diagnostic. Errors of the Zoom API are described by their code and message
+// with a hint how to resolve them, instead of the raw response. attrPath may be path.Empty() when no attribute applies.
+func ErrorDiagnostic(attrPath path.Path, summary string, err error) diag.Diagnostic {
+	detail := err.Error()
+	if zerr, ok := AsZoomError(err); ok {
+		// Keep the context added by the crud, such as "unable to read phone site: ", and replace the raw response.
+		detail = strings.TrimSuffix(detail, zerr.Error())
+		detail += fmt.Sprintf("Zoom API responded with HTTP %d, code %d: %s", zerr.StatusCode, zerr.Code, zerr.Message)
+		for _, field := range zerr.Fields {
+			detail += fmt.Sprintf("\n  - %s: %s", field.Field, field.Message)
+		}
+		if hint := zerr.Kind().hint(); hint != "" {
+			detail += "\n\n" + hint
+		}
+	}
+	var readOnlyErr *ReadOnlyError
+	if errors.As(err, &readOnlyErr) {
+		detail += "\n\nThe provider is configured with read_only, so it does not change the account. Unset read_only and the ZOOM_READ_ONLY environment variable to apply the change."
+	}
+	if len(attrPath.Steps()) == 0 {
+		return diag.NewErrorDiagnostic(summary, detail)
+	}
+	return diag.NewAttributeErrorDiagnostic(attrPath, summary, detail)
+}
@@ -0,0 +1,98 @@
+package util_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomphone"
+	"github.com/folio-sec/terraform-provider-zoom/generated/api/zoomuser"
+	"github.com/folio-sec/terraform-provider-zoom/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func phoneError(statusCode, code int, message string) error {
+	return &zoomphone.ErrorResponseStatusCode{
+		StatusCode: statusCode,
+		Response: zoomphone.ErrorResponse{
+			Code:    zoomphone.NewOptInt(code),
+			Message: zoomphone.NewOptString(message),
+		},
+	}
+}
+
+func TestZoomErrorKind(t *testing.T) {
+	for _, tc := range []struct {
+		err      error
+		kind     util.ZoomErrorKind
+		notFound bool
+	}{
+		{phoneError(404, 404, "Call queue does not exist."), util.ZoomErrorNotFound, true},
+		{phoneError(400, 300, "Site does not exist."), util.ZoomErrorNotFound, true},
+		{phoneError(400, 300, "Invalid parameter."), util.ZoomErrorValidation, true},
+		{phoneError(400, 404, "Policy does not exist."), util.ZoomErrorNotFound, true},
+		{phoneError(400, 4711, "Invalid access token, does not contain scopes:[phone:read:admin]."), util.ZoomErrorMissingScope, false},
+		{phoneError(429, 429, "You have reached the maximum per-second rate limit for this API."), util.ZoomErrorRateLimited, false},
+		{phoneError(409, 409, "Extension number already exists."), util.ZoomErrorConflict, false},
+		{phoneError(400, 300, "Site code already exists."), util.ZoomErrorConflict, false},
+		{phoneError(500, 500, "Internal error."), util.ZoomErrorUnknown, false},
+		{&zoomuser.ErrorResponseStatusCode{StatusCode: 404, Response: zoomuser.ErrorResponse{Code: zoomuser.NewOptInt(1001)}}, util.ZoomErrorNotFound, true},
+	} {
+		wrapped := fmt.Errorf("unable to read: %w", tc.err)
+		zerr, ok := util.AsZoomError(wrapped)
+		if !ok {
+			t.Fatalf("%v: not a zoom error", tc.err)
+		}
+		if zerr.Kind() != tc.kind {
+			t.Errorf("%v: expected %s, got %s", tc.err, tc.kind, zerr.Kind())
+		}
+		if util.IsNotFound(wrapped) != tc.notFound {
+			t.Errorf("%v: expected IsNotFound to be %v", tc.err, tc.notFound)
+		}
+	}
+
+	if util.IsNotFound(fmt.Errorf("unexpected EOF")) {
+		t.Error("expected a non Zoom error not to be not found")
+	}
+}
+
+func TestErrorDiagnostic(t *testing.T) {
+	err := fmt.Errorf("unable to create phone site: %w", &zoomphone.ErrorResponseStatusCode{
+		StatusCode: 400,
+		Response: zoomphone.ErrorResponse{
+			Code:    zoomphone.NewOptInt(300),
+			Message: zoomphone.NewOptString("Validation Failed."),
+			Errors: []zoomphone.ErrorResponseErrorsItem{
+				{Field: zoomphone.NewOptString("site_code"), Message: zoomphone.NewOptString("Invalid site code.")},
+			},
+		},
+	})
+
+	d := util.ErrorDiagnostic(path.Root("site_code"), "Error creating phone site", err)
+	if d.Severity() != diag.SeverityError || d.Summary() != "Error creating phone site" {
+		t.Fatalf("unexpected diagnostic: %v", d)
+	}
+	if withPath, ok := d.(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("site_code")) {
+		t.Fatalf("expected the diagnostic to have the attribute path: %v", d)
+	}
+	for _, want := range []string{
+		"unable to create phone site: Zoom API responded with HTTP 400, code 300: Validation Failed.",
+		"- site_code: Invalid site code.",
+		"Zoom rejected a value",
+	} {
+		if !strings.Contains(d.Detail(), want) {
+			t.Errorf("expected detail to contain %q, got:\n%s", want, d.Detail())
+		}
+	}
+
+	d = util.ErrorDiagnostic(path.Empty(), "Error reading users", fmt.Errorf("unexpected EOF"))
+	if _, ok := d.(diag.DiagnosticWithPath); ok || d.Detail() != "unexpected EOF" {
+		t.Fatalf("unexpected diagnostic: %v", d)
+	}
+
+	d = util.ErrorDiagnostic(path.Empty(), "Error deleting phone site", fmt.Errorf("unable to delete phone site: %w", &util.ReadOnlyError{ResourceType: "zoom_phone_site", Operation: "Delete", Method: "DELETE", Path: "/v2/phone/sites/abc"}))
+	if !strings.Contains(d.Detail(), "refused to send DELETE /v2/phone/sites/abc to delete zoom_phone_site") || !strings.Contains(d.Detail(), "Unset read_only") {
+		t.Fatalf("unexpected diagnostic: %v", d)
+	}
+}
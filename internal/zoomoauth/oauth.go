@@ -20,8 +20,12 @@ type TokenResponse struct {
 	ApiURL       string `json:"api_url"`
 }
 
+// DefaultTokenURL is the OAuth token endpoint of Zoom.
+const DefaultTokenURL = "https://zoom.us/oauth/token"
+
 type Client struct {
 	httpclient HttpRequestDoer
+	tokenURL   string
 }
 
 type HttpRequestDoer interface {
@@ -34,6 +38,7 @@ type ClientOption func(*Client) error
 func NewClient(opts ...ClientOption) (*Client, error) {
 	client := Client{
 		httpclient: http.DefaultClient,
+		tokenURL:   DefaultTokenURL,
 	}
 	for _, o := range opts {
 		if err := o(&client); err != nil {
@@ -52,15 +57,40 @@ func WithHTTPClient(doer HttpRequestDoer) ClientOption {
 	}
 }
 
+// WithTokenURL allows overriding the OAuth token endpoint, e.g. for ZoomGov or a local stand-in.
+func WithTokenURL(tokenURL string) ClientOption {
+	return func(c *Client) error {
+		if tokenURL == "" {
+			return fmt.Errorf("token url must not be empty")
+		}
+		c.tokenURL = tokenURL
+		return nil
+	}
+}
+
+// GetAccessToken gets an access token of a Server-to-Server OAuth app by the account credentials grant.
 func (c *Client) GetAccessToken(ctx context.Context, accountID string, clientID string, clientSecret string) (*TokenResponse, error) {
+	return c.requestToken(ctx, clientID, clientSecret, url.Values{
+		"grant_type": {"account_credentials"},
+		"account_id": {accountID},
+	})
+}
+
+// RefreshAccessToken gets an access token of a General OAuth app by the refresh token grant.
+// Zoom rotates refresh tokens, so the caller must keep the refresh_token of the response for the next refresh.
+func (c *Client) RefreshAccessToken(ctx context.Context, clientID string, clientSecret string, refreshToken string) (*TokenResponse, error) {
+	return c.requestToken(ctx, clientID, clientSecret, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+}
+
+func (c *Client) requestToken(ctx context.Context, clientID string, clientSecret string, form url.Values) (*TokenResponse, error) {
 	req, err := http.NewRequestWithContext(
 		ctx,
 		"POST",
-		"https://zoom.us/oauth/token",
-		strings.NewReader(url.Values{
-			"grant_type": {"account_credentials"},
-			"account_id": {accountID},
-		}.Encode()),
+		c.tokenURL,
+		strings.NewReader(form.Encode()),
 	)
 	if err != nil {
 		return nil, err
@@ -90,6 +120,9 @@ func (c *Client) GetAccessToken(ctx context.Context, accountID string, clientID
 	if err != nil {
 		return nil, fmt.Errorf("failed to read, io.ReadAll(): %w", err)
 	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get OAuth token: status %d: %s", res.StatusCode, string(resp))
+	}
 
 	var tokenResponse TokenResponse
 	err = json.Unmarshal(resp, &tokenResponse)
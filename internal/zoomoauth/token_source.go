@@ -0,0 +1,221 @@
+package zoomoauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// expiryDelta is how long before the actual expiry a token is treated as expired,
+// so that requests in flight never carry a token that lapses on the way to Zoom.
+const expiryDelta = 5 * time.Minute
+
+// ErrTokenNotRenewable is returned when a pre-issued access token expires or is rejected,
+// as the provider has no credentials to get a new one.
+var ErrTokenNotRenewable = errors.New("the access token was issued outside of the provider and cannot be renewed")
+
+// TokenSource supplies access tokens obtained by an OAuth grant.
+// It caches the current token, fetches a new one shortly before it expires and is safe for concurrent use.
+type TokenSource struct {
+	// grant gets a new token. It is called with mu held.
+	grant func(ctx context.Context) (*TokenResponse, error)
+
+	mu     sync.Mutex
+	token  *TokenResponse
+	expiry time.Time
+	now    func() time.Time
+	// saveRefreshToken saves the refresh token that Zoom rotated to, until it succeeds. It is nil when there is none to save.
+	saveRefreshToken func() error
+}
+
+// NewTokenSource creates a token source for a Server-to-Server OAuth app, using the account credentials grant.
+func NewTokenSource(client *Client, accountID string, clientID string, clientSecret string) *TokenSource {
+	return &TokenSource{
+		grant: func(ctx context.Context) (*TokenResponse, error) {
+			return client.GetAccessToken(ctx, accountID, clientID, clientSecret)
+		},
+		now: time.Now,
+	}
+}
+
+// NewRefreshTokenSource creates a token source that acts as the user who authorized a General OAuth app,
+// using the refresh token grant. Zoom issues a new refresh token with every access token,
+// so the token source keeps it for the next refresh and passes it to onRotate, if not nil, to be saved.
+//
+// Zoom revokes the previous refresh token at the same time, so the new one is kept even when onRotate fails.
+// The failure is returned by every call until a retry of onRotate succeeds.
+func NewRefreshTokenSource(client *Client, clientID string, clientSecret string, refreshToken string, onRotate func(refreshToken string) error) *TokenSource {
+	s := &TokenSource{now: time.Now}
+	s.grant = func(ctx context.Context) (*TokenResponse, error) {
+		res, err := client.RefreshAccessToken(ctx, clientID, clientSecret, refreshToken)
+		if err != nil {
+			return nil, err
+		}
+		if res.RefreshToken == "" || res.RefreshToken == refreshToken {
+			return res, nil
+		}
+		refreshToken = res.RefreshToken
+		if onRotate != nil {
+			rotated := refreshToken
+			s.saveRefreshToken = func() error {
+				return onRotate(rotated)
+			}
+		}
+		return res, nil
+	}
+	return s
+}
+
+// NewStaticTokenSource creates a token source that always supplies accessToken, e.g. a short-lived token issued by a secrets broker.
+// Once Zoom rejects it, Refresh fails with ErrTokenNotRenewable.
+func NewStaticTokenSource(accessToken string) *TokenSource {
+	return &TokenSource{
+		grant: func(context.Context) (*TokenResponse, error) {
+			return nil, ErrTokenNotRenewable
+		},
+		token: &TokenResponse{AccessToken: accessToken, TokenType: "bearer"},
+		now:   time.Now,
+	}
+}
+
+// Token returns a valid access token, fetching a new one when the cached token is missing or about to expire.
+func (s *TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.valid() {
+		if err := s.fetch(ctx); err != nil {
+			return "", err
+		}
+	}
+	if err := s.save(); err != nil {
+		return "", err
+	}
+	return s.token.AccessToken, nil
+}
+
+// TokenInfo is an access token with what is known about it.
+type TokenInfo struct {
+	AccessToken string
+	TokenType   string
+	// Expiry is when the token expires, or the zero time when Zoom did not return expires_in.
+	Expiry time.Time
+	// Scopes are the scopes granted to the token, or nil when they are unknown.
+	Scopes []string
+}
+
+// TokenInfo returns a valid access token like Token, along with its expiry and scopes.
+func (s *TokenSource) TokenInfo(ctx context.Context) (TokenInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.valid() {
+		if err := s.fetch(ctx); err != nil {
+			return TokenInfo{}, err
+		}
+	}
+	if err := s.save(); err != nil {
+		return TokenInfo{}, err
+	}
+	return TokenInfo{
+		AccessToken: s.token.AccessToken,
+		TokenType:   s.token.TokenType,
+		Expiry:      s.expiry,
+		Scopes:      s.scopes(),
+	}, nil
+}
+
+// APIURL returns the api_url returned along with the cached token, or an empty string when it is unknown.
+func (s *TokenSource) APIURL() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == nil {
+		return ""
+	}
+	return s.token.ApiURL
+}
+
+// Scopes returns the scopes granted to the cached token, or nil when they are unknown.
+func (s *TokenSource) Scopes() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.scopes()
+}
+
+// scopes returns the scopes granted to the cached token, or nil when Zoom returned no scope. The caller must hold s.mu.
+func (s *TokenSource) scopes() []string {
+	if s.token == nil {
+		return nil
+	}
+	scopes := strings.Fields(s.token.Scope)
+	if len(scopes) == 0 {
+		return nil
+	}
+	return scopes
+}
+
+// Refresh discards staleToken and returns a newly fetched access token.
+// When the cached token has already been replaced by another caller, the cached token is returned as is,
+// so concurrent requests rejected with the same token trigger only one refresh.
+func (s *TokenSource) Refresh(ctx context.Context, staleToken string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == nil || s.token.AccessToken == staleToken || !s.valid() {
+		if err := s.fetch(ctx); err != nil {
+			return "", err
+		}
+	}
+	if err := s.save(); err != nil {
+		return "", err
+	}
+	return s.token.AccessToken, nil
+}
+
+// save saves the refresh token that Zoom rotated to, if it has not been saved yet. The caller must hold s.mu.
+func (s *TokenSource) save() error {
+	if s.saveRefreshToken == nil {
+		return nil
+	}
+	if err := s.saveRefreshToken(); err != nil {
+		return fmt.Errorf("failed to save the new refresh token, which Zoom has replaced the previous one with: %w", err)
+	}
+	s.saveRefreshToken = nil
+	return nil
+}
+
+// valid reports whether the cached token can still be used. The caller must hold s.mu.
+func (s *TokenSource) valid() bool {
+	if s.token == nil || s.token.AccessToken == "" {
+		return false
+	}
+	// expires_in is not returned, so rely on the refresh triggered by 401 responses.
+	if s.expiry.IsZero() {
+		return true
+	}
+	return s.now().Before(s.expiry.Add(-expiryDelta))
+}
+
+// fetch gets a new access token and replaces the cached one. The caller must hold s.mu.
+func (s *TokenSource) fetch(ctx context.Context) error {
+	issuedAt := s.now()
+	res, err := s.grant(ctx)
+	if err != nil {
+		return err
+	}
+	if res.AccessToken == "" {
+		return errors.New("failed to get OAuth token: empty access token")
+	}
+
+	s.token = res
+	s.expiry = time.Time{}
+	if res.ExpiresIn > 0 {
+		s.expiry = issuedAt.Add(time.Duration(res.ExpiresIn) * time.Second)
+	}
+	return nil
+}
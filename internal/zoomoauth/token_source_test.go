@@ -0,0 +1,221 @@
+package zoomoauth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeTokenDoer struct {
+	calls atomic.Int32
+}
+
+func (d *fakeTokenDoer) Do(_ *http.Request) (*http.Response, error) {
+	n := d.calls.Add(1)
+	body := fmt.Sprintf(`{"access_token":"token-%d","token_type":"bearer","expires_in":3600}`, n)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}, nil
+}
+
+func newTestTokenSource(t *testing.T, doer HttpRequestDoer) *TokenSource {
+	t.Helper()
+	client, err := NewClient(WithHTTPClient(doer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewTokenSource(client, "account", "client", "secret")
+}
+
+func TestTokenSourceRefreshesBeforeExpiry(t *testing.T) {
+	doer := &fakeTokenDoer{}
+	ts := newTestTokenSource(t, doer)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts.now = func() time.Time { return now }
+
+	token, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "token-1" {
+		t.Fatalf("unexpected token: %s", token)
+	}
+
+	now = now.Add(50 * time.Minute)
+	if token, _ = ts.Token(context.Background()); token != "token-1" {
+		t.Fatalf("token should be cached, got %s", token)
+	}
+
+	now = now.Add(6 * time.Minute)
+	if token, _ = ts.Token(context.Background()); token != "token-2" {
+		t.Fatalf("token should be refreshed before expiry, got %s", token)
+	}
+}
+
+func TestTokenSourceRefreshOnce(t *testing.T) {
+	doer := &fakeTokenDoer{}
+	ts := newTestTokenSource(t, doer)
+
+	stale, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := ts.Refresh(context.Background(), stale)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if token != "token-2" {
+				t.Errorf("unexpected token: %s", token)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls := doer.calls.Load(); calls != 2 {
+		t.Fatalf("expected 2 token requests, got %d", calls)
+	}
+}
+
+type fakeRefreshTokenDoer struct {
+	refreshTokens []string
+}
+
+func (d *fakeRefreshTokenDoer) Do(req *http.Request) (*http.Response, error) {
+	if err := req.ParseForm(); err != nil {
+		return nil, err
+	}
+	if req.PostForm.Get("grant_type") != "refresh_token" {
+		return nil, fmt.Errorf("unexpected grant_type: %s", req.PostForm.Get("grant_type"))
+	}
+	d.refreshTokens = append(d.refreshTokens, req.PostForm.Get("refresh_token"))
+	n := len(d.refreshTokens)
+	body := fmt.Sprintf(`{"access_token":"token-%d","token_type":"bearer","refresh_token":"refresh-%d","expires_in":3600,"scope":"phone:read:admin"}`, n, n)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}, nil
+}
+
+func TestRefreshTokenSourceRotatesRefreshToken(t *testing.T) {
+	doer := &fakeRefreshTokenDoer{}
+	client, err := NewClient(WithHTTPClient(doer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved []string
+	ts := NewRefreshTokenSource(client, "client", "secret", "refresh-0", func(refreshToken string) error {
+		saved = append(saved, refreshToken)
+		return nil
+	})
+
+	token, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token, err = ts.Refresh(context.Background(), token); err != nil {
+		t.Fatal(err)
+	}
+	if token != "token-2" {
+		t.Fatalf("unexpected token: %s", token)
+	}
+	if fmt.Sprint(doer.refreshTokens) != "[refresh-0 refresh-1]" {
+		t.Fatalf("expected each refresh to use the latest refresh token, got %v", doer.refreshTokens)
+	}
+	if fmt.Sprint(saved) != "[refresh-1 refresh-2]" {
+		t.Fatalf("expected the new refresh tokens to be saved, got %v", saved)
+	}
+	if scopes := ts.Scopes(); len(scopes) != 1 || scopes[0] != "phone:read:admin" {
+		t.Fatalf("unexpected scopes: %v", scopes)
+	}
+}
+
+func TestRefreshTokenSourceRetriesSavingRefreshToken(t *testing.T) {
+	doer := &fakeRefreshTokenDoer{}
+	client, err := NewClient(WithHTTPClient(doer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved []string
+	failures := 2
+	ts := NewRefreshTokenSource(client, "client", "secret", "refresh-0", func(refreshToken string) error {
+		if failures > 0 {
+			failures--
+			return errors.New("read-only file system")
+		}
+		saved = append(saved, refreshToken)
+		return nil
+	})
+
+	for range 2 {
+		if _, err := ts.Token(context.Background()); err == nil || !strings.Contains(err.Error(), "failed to save the new refresh token") {
+			t.Fatalf("expected the failure to save the refresh token, got %v", err)
+		}
+	}
+	token, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the new tokens are kept while they cannot be saved, as Zoom has revoked refresh-0
+	if token != "token-1" || fmt.Sprint(doer.refreshTokens) != "[refresh-0]" {
+		t.Fatalf("expected token-1 from a single refresh, got %s from %v", token, doer.refreshTokens)
+	}
+	if fmt.Sprint(saved) != "[refresh-1]" {
+		t.Fatalf("expected the new refresh token to be saved once the retry succeeds, got %v", saved)
+	}
+}
+
+func TestStaticTokenSource(t *testing.T) {
+	ts := NewStaticTokenSource("pre-issued")
+
+	token, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "pre-issued" {
+		t.Fatalf("unexpected token: %s", token)
+	}
+	if _, err := ts.Refresh(context.Background(), token); !errors.Is(err, ErrTokenNotRenewable) {
+		t.Fatalf("expected ErrTokenNotRenewable, got %v", err)
+	}
+	if token, _ = ts.Token(context.Background()); token != "pre-issued" {
+		t.Fatalf("expected the token to be kept after a failed refresh, got %s", token)
+	}
+}
+
+func TestTokenSourceTokenInfo(t *testing.T) {
+	ts := newTestTokenSource(t, &fakeTokenDoer{})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts.now = func() time.Time { return now }
+
+	info, err := ts.TokenInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.AccessToken != "token-1" || info.TokenType != "bearer" || !info.Expiry.Equal(now.Add(time.Hour)) {
+		t.Fatalf("unexpected token info: %+v", info)
+	}
+	if info.Scopes != nil {
+		t.Fatalf("scopes should be unknown when Zoom returned none, got %#v", info.Scopes)
+	}
+
+	now = now.Add(56 * time.Minute)
+	if info, _ = ts.TokenInfo(context.Background()); info.AccessToken != "token-2" || !info.Expiry.Equal(now.Add(time.Hour)) {
+		t.Fatalf("token info should be refreshed before expiry, got %+v", info)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"flag"
 	"log"
+	"time"
 
 	"github.com/folio-sec/terraform-provider-zoom/internal/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -35,7 +36,19 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	ctx := context.Background()
+	shutdownTracing, err := provider.SetupTracing(ctx, version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	err = providerserver.Serve(ctx, provider.New(version), opts)
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	if shutdownErr := shutdownTracing(shutdownCtx); shutdownErr != nil {
+		log.Printf("failed to flush the traces: %s", shutdownErr.Error())
+	}
+	cancel()
 	if err != nil {
 		log.Fatal(err.Error())
 	}